@@ -6,6 +6,9 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	// internal
@@ -33,68 +36,194 @@ var (
 	redisCacheHost = flag.String("redis-host", "batch-cache", "host of the redis server to use as a response cache")
 	redisCachePort = flag.Int("redis-port", 6379, "host of the redis server to use as a response cache")
 	redisCacheDB   = flag.Int("redis-db", 0, "db of the redis server to use as a response cache")
+	batchRetention = flag.Duration("batch-retention", time.Hour*24*30, "(optional) how long batches are kept in the cache && listed by `ListBatches`")
+	operatorOwners = flag.String("operator-owners", "", "(optional) comma-separated owners (fingerprints of API keys, see the `owner` of a batch) allowed to cancel && retry other owners' batches")
 
 	// queue parameters
-	pubsubHost = flag.String("pubsub-host", "pubsub", "...")
-	pubsubPort = flag.Int("pubsub-port", 6379, "...")
-	pubsubDB   = flag.Int("pubsub-db", 0, "...")
+	pubsubHost    = flag.String("pubsub-host", "pubsub", "...")
+	pubsubPort    = flag.Int("pubsub-port", 6379, "...")
+	pubsubDB      = flag.Int("pubsub-db", 0, "...")
+	queueConsumer = flag.String("queue-consumer", "", "(optional) name of this server in the `batch-servers` consumer group, must be unique per server; the hostname if unset")
+
+	// management service options - used to check that a batch's dataset exists
+	mgmtServerHost = flag.String("mgmt-server-host", "gcaas-mgmt", "host addresss of the gcaas management server to check datasets against")
+	mgmtServerPort = flag.Int("mgmt-server-port", 50052, "port of the gcaas management server to check datasets against")
 )
 
+// serverStatusClaimIdle - status updates left pending by a server for this long are claimed by another server
+const serverStatusClaimIdle = time.Second * 60
+
+// serverQueueBlock - max. wait for a status update before checking for idle updates
+const serverQueueBlock = time.Second * 5
+
+// serverListDefaultLimit, serverListMaxLimit - batches per page of `ListBatches`
+const (
+	serverListDefaultLimit = 50
+	serverListMaxLimit     = 500
+)
+
+// serverListMaxScanned - max. batches read from the index per call to `ListBatches`, a page w. a `statuses` filter
+// that few batches match ends early && is continued from `next_cursor`
+const serverListMaxScanned = 2000
+
 // BatchServer -
 type BatchServer struct {
 	pb.UnimplementedBatchServer
 	cacheClient  *redis.Client
-	pubsubClient *redis.Client
 	spacesClient *s3.S3
+	createsQueue *srv.BatchQueue // new batches, consumed by the workers
+	statusQueue  *srv.BatchQueue // status updates from the workers, consumed by the batch servers
+	retention    time.Duration   // TTL of a batch in the cache
+	mgmtClient   pb.ManagementClient
+	operators    map[string]bool // owners allowed to act on any owner's batches
+	datasets     sync.Map        // datasets known to exist, datasets are never deleted so entries never go stale
 }
 
-// Listen - the batch server listens with one client (pubsub) and writes to cache
-// with the other
-func (s *BatchServer) Listen(ctx context.Context, topic string) {
-	sub := s.pubsubClient.Subscribe(ctx, topic)
-	defer func() {
-		log.Infof("exit from pub/sub channel: %s", topic)
-		sub.Unsubscribe(s.pubsubClient.Context(), topic)
-	}()
+// datasetExists - checks w. the management server that a dataset exists, returns an error if it couldn't be checked
+func (s *BatchServer) datasetExists(ctx context.Context, dataset string) (bool, error) {
+	if dataset == srv.DefaultDataset {
+		return true, nil
+	}
+	if _, ok := s.datasets.Load(dataset); ok {
+		return true, nil
+	}
+
+	_, err := s.mgmtClient.GetDatasetStats(ctx, &pb.DatasetStatsRequest{Dataset: dataset})
+	switch status.Code(err) {
+	case codes.OK:
+		s.datasets.Store(dataset, struct{}{})
+		return true, nil
+	case codes.NotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Listen - the batch server consumes status updates from the workers && writes them to the cache, each update is
+// handled by one server
+func (s *BatchServer) Listen(ctx context.Context) {
+	err := s.statusQueue.Consume(ctx, s.handleStatusUpdate)
+	log.WithFields(log.Fields{
+		"err": err,
+	}).Infof("exit from queue: %s", s.statusQueue.Stream)
+}
+
+// setBatchStatusScript - sets the fields of a batch unless it already finished || expired, returns 0 if the batch
+// finished && -1 if it expired; the first `ARGV[1]` args are the finished statuses, the rest are fields. A finished
+// batch keeps its status regardless of late updates, e.g. progress published just before a batch succeeded || updates
+// from a worker processing a batch that was canceled. An expired batch isn't recreated, the new hash would have no TTL
+// && `volatile-lru` would never evict it. Note: a batch is only requeued (`RetryBatch`) after its status is reset
+var setBatchStatusScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+local status = redis.call('HGET', KEYS[1], 'status')
+local n = tonumber(ARGV[1])
+for i = 2, n + 1 do
+	if status == ARGV[i] then
+		return 0
+	end
+end
+redis.call('HSET', KEYS[1], unpack(ARGV, n + 2))
+return 1
+`)
+
+// batchFinishedStatuses - statuses a batch can't leave w.o. `RetryBatch`
+var batchFinishedStatuses = []interface{}{
+	pb.BatchGeocodeStatus_SUCCESS.String(),
+	pb.BatchGeocodeStatus_FAILED.String(),
+	pb.BatchGeocodeStatus_CANCELED.String(),
+}
 
-	channel := sub.Channel()
+// batchStatusUpdateFields - the fields of a batch hash set by a status update, read back by `batchStatusFromCache`
+func batchStatusUpdateFields(r *pb.BatchStatusResponse, now time.Time) []interface{} {
+	var eta string
+	if r.Eta != nil {
+		eta = r.Eta.AsTime().Format(time.RFC3339Nano)
+	}
+
+	return []interface{}{
+		"status", r.Status.String(), "download_path", r.DownloadPath, "update_time", now.Format(time.RFC3339Nano),
+		"attempts", r.Attempts, "last_error", r.LastError,
+		"total", r.Total, "sent", r.Sent, "resolved", r.Resolved, "unmatched", r.Unmatched, "eta", eta,
+	}
+}
+
+// setBatchStatusArgs - the args of `setBatchStatusScript` for a status update, the finished statuses then the fields
+func setBatchStatusArgs(r *pb.BatchStatusResponse, now time.Time) []interface{} {
+	args := append([]interface{}{len(batchFinishedStatuses)}, batchFinishedStatuses...)
+	return append(args, batchStatusUpdateFields(r, now)...)
+}
+
+// setBatchStatus - writes a status update w. `setBatchStatusScript`, returns its result (-1 expired, 0 finished,
+// 1 set)
+func (s *BatchServer) setBatchStatus(ctx context.Context, r *pb.BatchStatusResponse) (int, error) {
+	return setBatchStatusScript.Run(ctx, s.cacheClient, []string{r.Id}, setBatchStatusArgs(r, time.Now())...).Int()
+}
+
+// handleStatusUpdate - writes a status update to the cache, updates that fail to write are left pending && retried
+func (s *BatchServer) handleStatusUpdate(ctx context.Context, msg redis.XMessage) error {
 
 	var r pb.BatchStatusResponse
 	var err error
 
-	// marshall msg from the pub/sub channel and write to cache
-	for msg := range channel {
-		log.Infof("batchserver.listener recv msg on %s", topic)
-
-		if err := proto.Unmarshal([]byte(msg.Payload), &r); err != nil {
-			log.WithFields(log.Fields{
-				"err": err,
-				"op":  "batchserver.listener",
-			}).Panic("failed to read message from pubsub")
-		}
+	log.Infof("batchserver.listener recv msg on %s", s.statusQueue.Stream)
 
-		_, err = s.cacheClient.Do(
-			ctx, "HSET", r.Id, "status", r.Status.String(), "download_path", r.DownloadPath, "update_time", time.Now(),
-		).Result()
+	// a malformed update can never be written, acknowledge it rather than retry forever
+	payload, _ := srv.SafeCast[string](msg.Values["payload"])
+	if err := proto.Unmarshal([]byte(payload), &r); err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"queue.id": msg.ID,
+			"op":       "batchserver.listener",
+		}).Error("failed to read message from queue")
+		return nil
+	}
 
-		if err != nil {
-			log.WithFields(log.Fields{
-				"err":                err,
-				"batch.id":           r.Id,
-				"batch.status":       r.Status,
-				"batch.downloadpath": r.DownloadPath,
-				"op":                 "batchserver.listener",
-			}).Error("failed to set status on batch-cache")
-			break
-		}
+	set, err := s.setBatchStatus(ctx, &r)
 
+	if err != nil {
 		log.WithFields(log.Fields{
+			"err":                err,
 			"batch.id":           r.Id,
 			"batch.status":       r.Status,
 			"batch.downloadpath": r.DownloadPath,
 			"op":                 "batchserver.listener",
-		}).Info("set status on batch-cache")
+		}).Error("failed to set status on batch-cache")
+		return err
+	}
+
+	// e.g. a worker still processing a batch that expired from the cache
+	if set == -1 {
+		log.WithFields(log.Fields{
+			"batch.id":     r.Id,
+			"batch.status": r.Status,
+			"op":           "batchserver.listener",
+		}).Warn("batch expired; ignored status update")
+		return nil
+	}
+
+	// e.g. a worker finished a batch just before it was canceled
+	if set == 0 {
+		log.WithFields(log.Fields{
+			"batch.id":     r.Id,
+			"batch.status": r.Status,
+			"op":           "batchserver.listener",
+		}).Info("batch already finished; ignored status update")
+		return nil
 	}
+
+	log.WithFields(log.Fields{
+		"batch.id":           r.Id,
+		"batch.status":       r.Status,
+		"batch.downloadpath": r.DownloadPath,
+		"batch.total":        r.Total,
+		"batch.resolved":     r.Resolved,
+		"batch.unmatched":    r.Unmatched,
+		"op":                 "batchserver.listener",
+	}).Info("set status on batch-cache")
+	return nil
 }
 
 // CreateBatch - creates a new batch and sends an event to the queue
@@ -106,10 +235,12 @@ func (s *BatchServer) CreateBatch(ctx context.Context, req *pb.CreateBatchReques
 	var batchRequestID = uuid.New().String() // create a new uuid for the request
 
 	reqLogger := log.WithFields(log.Fields{
-		"request.size":   len(req.Points) + len(req.Addresses),
-		"request.method": req.Method,
-		"method":         "/geocoder.Batch/CreateBatch",
-		"batch.id":       batchRequestID,
+		"request.size":    len(req.Points) + len(req.Addresses),
+		"request.method":  req.Method,
+		"request.dataset": req.Dataset,
+		"request.owner":   req.Owner,
+		"method":          "/geocoder.Batch/CreateBatch",
+		"batch.id":        batchRequestID,
 	})
 
 	defer func() {
@@ -127,11 +258,43 @@ func (s *BatchServer) CreateBatch(ctx context.Context, req *pb.CreateBatchReques
 		}
 	}()
 
+	// the dataset is persisted w. the request && passed to each geocode request by the workers, reject a batch
+	// for a dataset that doesn't exist rather than accept it && fail each of its geocode requests
+	dataset, err := srv.ParseDataset(req.Dataset)
+	if err != nil {
+		respCode = codes.InvalidArgument
+		return &pb.BatchStatusResponse{
+			Id:         batchRequestID,
+			Status:     pb.BatchGeocodeStatus_REJECTED,
+			UpdateTime: timestamppb.New(time.Now()),
+		}, status.Error(respCode, err.Error())
+	}
+
+	exists, err := s.datasetExists(ctx, dataset)
+	if (err != nil) || !exists {
+		respCode = codes.Unavailable // transient failure - management server unavailable
+		if err == nil {
+			respCode, err = codes.NotFound, srv.ErrDatasetNotFound
+		}
+		return &pb.BatchStatusResponse{
+			Id:         batchRequestID,
+			Status:     pb.BatchGeocodeStatus_REJECTED,
+			UpdateTime: timestamppb.New(time.Now()),
+		}, status.Error(respCode, err.Error())
+	}
+
 	// first thing we do is mark accepted and tell the client the request was
-	// accepted unless the cache rejected it upfront...
-	_, err = s.cacheClient.Do(
-		ctx, "HSET", batchRequestID, "status", pb.BatchGeocodeStatus_ACCEPTED.String(), "update_time", time.Now(),
-	).Result()
+	// accepted unless the cache rejected it upfront... the batch expires w. its index entries after `retention`
+	var createTime = time.Now()
+	_, err = s.cacheClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, batchRequestID,
+			"status", pb.BatchGeocodeStatus_ACCEPTED.String(), "update_time", createTime.Format(time.RFC3339Nano),
+			"create_time", createTime.Format(time.RFC3339Nano), "owner", req.Owner,
+		)
+		pipe.Expire(ctx, batchRequestID, s.retention)
+		srv.IndexBatch(ctx, pipe, batchRequestID, req.Owner, createTime, s.retention)
+		return nil
+	})
 	if err != nil {
 		respCode = codes.Unavailable // transient failure - batch status cache unavailable
 		return &pb.BatchStatusResponse{
@@ -165,11 +328,11 @@ func (s *BatchServer) CreateBatch(ctx context.Context, req *pb.CreateBatchReques
 				"err":    err,
 				"status": pb.BatchGeocodeStatus_FAILED.String(),
 			}).Error("failed to save batch to storage")
-			_, _ = s.cacheClient.Do(context.Background(),
-				"HSET", batchRequestID,
-				"status", pb.BatchGeocodeStatus_FAILED.String(),
-				"update_time", time.Now(),
-			).Result()
+			_, _ = s.setBatchStatus(context.Background(), &pb.BatchStatusResponse{
+				Id:        batchRequestID,
+				Status:    pb.BatchGeocodeStatus_FAILED,
+				LastError: err.Error(),
+			})
 			return
 		}
 
@@ -178,19 +341,17 @@ func (s *BatchServer) CreateBatch(ctx context.Context, req *pb.CreateBatchReques
 			"duration": -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
 		}).Info("batch saved to storage")
 
-		// Publish to Queue - the file is ready for workers to process
-		pubsubPipe := s.pubsubClient.TxPipeline()
-		pubsubPipe.Publish(writerCtx, "batch.creates", batchRequestID)
-		_, err = pubsubPipe.Exec(writerCtx)
+		// Publish to Queue - the file is ready for workers to process, the entry is kept until a worker handles it
+		_, err = s.createsQueue.Publish(writerCtx, map[string]interface{}{"batch_id": batchRequestID})
 		if err != nil {
 			storageLogger.WithFields(log.Fields{
 				"err": err,
-			}).Error("failed to publish event on batch.creates")
-			_, _ = s.cacheClient.Do(writerCtx,
-				"HSET", batchRequestID,
-				"status", pb.BatchGeocodeStatus_FAILED.String(),
-				"update_time", time.Now(),
-			).Result()
+			}).Errorf("failed to publish event on %s", srv.BatchCreatesStream)
+			_, _ = s.setBatchStatus(writerCtx, &pb.BatchStatusResponse{
+				Id:        batchRequestID,
+				Status:    pb.BatchGeocodeStatus_FAILED,
+				LastError: err.Error(),
+			})
 			return
 		}
 		return
@@ -200,6 +361,8 @@ func (s *BatchServer) CreateBatch(ctx context.Context, req *pb.CreateBatchReques
 		Id:         batchRequestID,
 		Status:     pb.BatchGeocodeStatus_ACCEPTED,
 		UpdateTime: timestamppb.New(time.Now()),
+		Owner:      req.Owner,
+		CreateTime: timestamppb.New(createTime),
 	}, nil
 
 }
@@ -232,7 +395,7 @@ func (s *BatchServer) GetBatchStatus(ctx context.Context, req *pb.BatchStatusReq
 	}()
 
 	// check for status of this request from the status cache
-	res, err := s.cacheClient.Do(ctx, "HMGET", req.Id, "status", "download_path", "update_time").Result()
+	res, err := s.cacheClient.Do(ctx, append([]interface{}{"HMGET", req.Id}, batchStatusFields...)...).Result()
 	if err != nil {
 		reqLogger.WithFields(log.Fields{
 			"err": err,
@@ -245,30 +408,379 @@ func (s *BatchServer) GetBatchStatus(ctx context.Context, req *pb.BatchStatusReq
 		}, status.Error(respCode, err.Error())
 	}
 
+	if resp, ok := batchStatusFromCache(req.Id, res); ok {
+		return resp, nil
+	}
+
+	batchStatus, _ := srv.SafeCast[string](res.([]interface{})[0])
+	reqLogger.Warnf("batch has unexpected state: %s", batchStatus)
+	return &pb.BatchStatusResponse{
+		Id:     req.Id,
+		Status: pb.BatchGeocodeStatus_UNDEFINED_STATUS,
+	}, status.Error(codes.NotFound, "batch not found")
+
+}
+
+// batchStatusFields - the fields of a batch hash read by `batchStatusFromCache`, in order
+var batchStatusFields = []interface{}{
+	"status", "download_path", "update_time", "attempts", "last_error",
+	"total", "sent", "resolved", "unmatched", "eta", "owner", "create_time",
+}
+
+// batchStatusFromCache - a batch from the reply to an HMGET of `batchStatusFields`, false if the batch doesn't exist
+// (e.g. it expired) || has an unknown status
+func batchStatusFromCache(id string, res interface{}) (*pb.BatchStatusResponse, bool) {
+
 	// todo: really don't like the interface conversion here - tolerate for the time being...
-	resultArr := res.([]interface{})
+	resultArr, _ := srv.SafeCast[[]interface{}](res)
+	if len(resultArr) < len(batchStatusFields) {
+		return nil, false
+	}
+
 	batchStatus, _ := srv.SafeCast[string](resultArr[0])
+	v, ok := pb.BatchGeocodeStatus_value[batchStatus]
+	if !ok {
+		return nil, false
+	}
+
 	downloadPath, _ := srv.SafeCast[string](resultArr[1])
+	lastError, _ := srv.SafeCast[string](resultArr[4])
+	owner, _ := srv.SafeCast[string](resultArr[10])
+
+	resp := &pb.BatchStatusResponse{
+		Id:           id,
+		Status:       pb.BatchGeocodeStatus(v), // OK
+		DownloadPath: downloadPath,
+		UpdateTime:   cacheTimestamp(resultArr[2]),
+		Attempts:     cacheInt32(resultArr[3]),
+		LastError:    lastError,
+		Total:        cacheInt32(resultArr[5]),
+		Sent:         cacheInt32(resultArr[6]),
+		Resolved:     cacheInt32(resultArr[7]),
+		Unmatched:    cacheInt32(resultArr[8]),
+		Owner:        owner,
+		CreateTime:   cacheTimestamp(resultArr[11]),
+	}
 
-	var evtTime time.Time
-	evtTime, _ = time.Parse(time.RFC3339Nano, resultArr[2].(string))
+	// the ETA of an attempt that ended is stale, e.g. a batch that failed && is waiting to be retried
+	if resp.Status == pb.BatchGeocodeStatus_IN_QUEUE {
+		resp.Eta = cacheTimestamp(resultArr[9])
+	}
+	return resp, true
+}
 
-	if v, ok := pb.BatchGeocodeStatus_value[batchStatus]; ok {
-		return &pb.BatchStatusResponse{
-			Id:           req.Id,
-			Status:       pb.BatchGeocodeStatus(v), // OK
-			DownloadPath: downloadPath,
-			UpdateTime:   timestamppb.New(evtTime),
-		}, nil
+// cacheTimestamp - a time field of a batch hash, nil if unset (e.g. batches created before the field was added)
+func cacheTimestamp(v interface{}) *timestamppb.Timestamp {
+	s, _ := srv.SafeCast[string](v)
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// cacheInt32 - a numeric field of a batch hash, 0 if unset (e.g. batches created before the field was added)
+func cacheInt32(v interface{}) int32 {
+	s, _ := srv.SafeCast[string](v)
+	n, _ := strconv.ParseInt(s, 10, 32)
+	return int32(n)
+}
+
+// retryBatchScript - marks a FAILED batch ACCEPTED && resets its attempts, returns -1 if the batch doesn't exist (||
+// isn't owned by `ARGV[5]` unless `ARGV[4]` is 1, see `ownerArgs`) && 0 if it isn't FAILED; checked && set
+// atomically so a batch is only requeued once
+var retryBatchScript = redis.NewScript(`
+local status = redis.call('HGET', KEYS[1], 'status')
+if not status then
+	return -1
+end
+if (ARGV[4] == '0') and ((redis.call('HGET', KEYS[1], 'owner') or '') ~= ARGV[5]) then
+	return -1
+end
+if status ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'status', ARGV[2], 'attempts', 0, 'last_error', '', 'update_time', ARGV[3])
+return 1
+`)
+
+// RetryBatch - requeues a FAILED batch (e.g. one that exhausted its attempts && was dead-lettered) w. a fresh set of
+// attempts && removes it from `batch.dead-letter`; only the batch's owner && operators (`--operator-owners`) can
+// retry it
+func (s *BatchServer) RetryBatch(ctx context.Context, req *pb.RetryBatchRequest) (*pb.BatchStatusResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code; returned as part of pb.IOResponse
+	var err error              // error; returned as part of pb.IOResponse
+
+	reqLogger := log.WithFields(log.Fields{
+		"method":        "/geocoder.Batch/RetryBatch",
+		"batch.id":      req.Id,
+		"request.owner": req.Owner,
+	})
+
+	defer func() {
+		if respCode == codes.OK {
+			reqLogger.WithFields(log.Fields{
+				"status":   respCode.String(),
+				"duration": -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			}).Info("retry batch request ok")
+		} else {
+			reqLogger.WithFields(log.Fields{
+				"err":      err,
+				"status":   respCode.String(),
+				"duration": -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			}).Error("retry batch request failed")
+		}
+	}()
+
+	args := append([]interface{}{
+		pb.BatchGeocodeStatus_FAILED.String(), pb.BatchGeocodeStatus_ACCEPTED.String(), time.Now().Format(time.RFC3339Nano),
+	}, s.ownerArgs(req.Owner)...)
+
+	// another owner's batch is reported as not found rather than forbidden, as in `CancelBatch`
+	res, err := retryBatchScript.Run(ctx, s.cacheClient, []string{req.Id}, args...).Int()
+	switch {
+	case err != nil:
+		respCode = codes.Unavailable // transient failure - batch status cache unavailable
+		return nil, status.Error(respCode, err.Error())
+	case res < 0:
+		err, respCode = srv.ErrBatchNotFound, codes.NotFound
+		return nil, status.Error(respCode, err.Error())
+	case res == 0:
+		err, respCode = srv.ErrBatchNotRetryable, codes.FailedPrecondition
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	// requeue as a new batch, the batch's input is still in storage
+	_, err = s.createsQueue.Publish(ctx, map[string]interface{}{"batch_id": req.Id, "attempt": 1})
+	if err != nil {
+		respCode = codes.Unavailable
+		_, _ = s.setBatchStatus(ctx, &pb.BatchStatusResponse{
+			Id:        req.Id,
+			Status:    pb.BatchGeocodeStatus_FAILED,
+			LastError: err.Error(),
+		})
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	// the batch is requeued regardless, a dead-letter entry left behind only misleads operators
+	if _, derr := srv.RemoveDeadLetter(ctx, s.createsQueue.Client, req.Id); derr != nil {
+		reqLogger.WithFields(log.Fields{
+			"err": derr,
+		}).Warnf("failed to remove batch from %s", srv.BatchDeadLetterStream)
 	}
 
-	reqLogger.Warnf("batch has unexpected state: %s", batchStatus)
 	return &pb.BatchStatusResponse{
 		Id:         req.Id,
-		Status:     pb.BatchGeocodeStatus_UNDEFINED_STATUS,
-		UpdateTime: timestamppb.New(evtTime),
-	}, status.Error(codes.NotFound, "batch not found")
+		Status:     pb.BatchGeocodeStatus_ACCEPTED,
+		UpdateTime: timestamppb.New(time.Now()),
+	}, nil
+}
+
+// ownerArgs - the args of a script that checks a batch's owner, any batch can be acted on by an operator; the owner of
+// batches created w.o. an API key is ""
+func (s *BatchServer) ownerArgs(owner string) []interface{} {
+	if s.operators[owner] {
+		return []interface{}{1, owner}
+	}
+	return []interface{}{0, owner}
+}
+
+// cancelBatchScript - marks a batch CANCELED, returns -1 if the batch doesn't exist (|| isn't owned by `ARGV[4]`
+// unless `ARGV[3]` is 1, see `ownerArgs`) && 0 if it already finished; a batch that's already CANCELED is left as is
+// so a cancel can be resent
+var cancelBatchScript = redis.NewScript(`
+local status = redis.call('HGET', KEYS[1], 'status')
+if not status then
+	return -1
+end
+if (ARGV[3] == '0') and ((redis.call('HGET', KEYS[1], 'owner') or '') ~= ARGV[4]) then
+	return -1
+end
+if status == ARGV[1] then
+	return 1
+end
+for i = 5, #ARGV do
+	if status == ARGV[i] then
+		return 0
+	end
+end
+redis.call('HSET', KEYS[1], 'status', ARGV[1], 'update_time', ARGV[2])
+return 1
+`)
+
+// CancelBatch - cancels a batch that hasn't finished, a batch that's queued || waiting to be retried is skipped && a
+// batch being geocoded is stopped before its results are saved, only the batch's owner && operators
+// (`--operator-owners`) can cancel it
+func (s *BatchServer) CancelBatch(ctx context.Context, req *pb.CancelBatchRequest) (*pb.BatchStatusResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code; returned as part of pb.IOResponse
+	var err error              // error; returned as part of pb.IOResponse
+
+	reqLogger := log.WithFields(log.Fields{
+		"method":        "/geocoder.Batch/CancelBatch",
+		"batch.id":      req.Id,
+		"request.owner": req.Owner,
+	})
+
+	defer func() {
+		if respCode == codes.OK {
+			reqLogger.WithFields(log.Fields{
+				"status":   respCode.String(),
+				"duration": -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			}).Info("cancel batch request ok")
+		} else {
+			reqLogger.WithFields(log.Fields{
+				"err":      err,
+				"status":   respCode.String(),
+				"duration": -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			}).Error("cancel batch request failed")
+		}
+	}()
+
+	args := append([]interface{}{pb.BatchGeocodeStatus_CANCELED.String(), time.Now().Format(time.RFC3339Nano)}, s.ownerArgs(req.Owner)...)
+	args = append(args,
+		pb.BatchGeocodeStatus_SUCCESS.String(), pb.BatchGeocodeStatus_FAILED.String(), pb.BatchGeocodeStatus_REJECTED.String(),
+	)
+
+	// another owner's batch is reported as not found rather than forbidden, so batch ids can't be probed
+	res, err := cancelBatchScript.Run(ctx, s.cacheClient, []string{req.Id}, args...).Int()
+	switch {
+	case err != nil:
+		respCode = codes.Unavailable // transient failure - batch status cache unavailable
+		return nil, status.Error(respCode, err.Error())
+	case res < 0:
+		err, respCode = srv.ErrBatchNotFound, codes.NotFound
+		return nil, status.Error(respCode, err.Error())
+	case res == 0:
+		err, respCode = srv.ErrBatchNotCancelable, codes.FailedPrecondition
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	// the batch is CANCELED in the cache regardless, but the workers only stop it once signalled; the client can
+	// resend the cancel
+	if err = srv.CancelBatch(ctx, s.createsQueue.Client, req.Id); err != nil {
+		respCode = codes.Unavailable
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	return &pb.BatchStatusResponse{
+		Id:         req.Id,
+		Status:     pb.BatchGeocodeStatus_CANCELED,
+		UpdateTime: timestamppb.New(time.Now()),
+	}, nil
+}
+
+// ListBatches - lists batches newest first, filtered by owner, status && create time; batches are read from an index
+// of create times (per owner if set) && filtered by status as they're read
+func (s *BatchServer) ListBatches(ctx context.Context, req *pb.ListBatchesRequest) (*pb.ListBatchesResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code; returned as part of pb.IOResponse
+	var err error              // error; returned as part of pb.IOResponse
+
+	reqLogger := log.WithFields(log.Fields{
+		"method":           "/geocoder.Batch/ListBatches",
+		"request.owner":    req.Owner,
+		"request.statuses": req.Statuses,
+		"request.limit":    req.Limit,
+	})
+
+	defer func() {
+		if respCode == codes.OK {
+			reqLogger.WithFields(log.Fields{
+				"status":   respCode.String(),
+				"duration": -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			}).Info("list batches request ok")
+		} else {
+			reqLogger.WithFields(log.Fields{
+				"err":      err,
+				"status":   respCode.String(),
+				"duration": -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			}).Error("list batches request failed")
+		}
+	}()
+
+	var after, before time.Time
+	if req.CreatedAfter != nil {
+		after = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		before = req.CreatedBefore.AsTime()
+	}
+
+	var limit = int(req.Limit)
+	if limit == 0 {
+		limit = serverListDefaultLimit
+	}
+
+	if (limit < 0) || (limit > serverListMaxLimit) || (!after.IsZero() && !before.IsZero() && !after.Before(before)) {
+		err, respCode = srv.ErrInvalidBatchListFilter, codes.InvalidArgument
+		return nil, status.Error(respCode, err.Error())
+	}
 
+	var statuses = make(map[pb.BatchGeocodeStatus]bool, len(req.Statuses))
+	for _, st := range req.Statuses {
+		statuses[st] = true
+	}
+
+	var resp = &pb.ListBatchesResponse{}
+	var cursor = req.Cursor
+	var page []redis.Z
+
+scan:
+	for scanned := 0; (len(resp.Batches) < limit) && (scanned < serverListMaxScanned); {
+
+		page, err = srv.ScanBatchIndex(ctx, s.cacheClient, req.Owner, after, before, cursor, limit)
+		if err == srv.ErrInvalidBatchCursor {
+			respCode = codes.InvalidArgument
+			return nil, status.Error(respCode, err.Error())
+		}
+		if err != nil {
+			respCode = codes.Unavailable // transient failure - batch status cache unavailable
+			return nil, status.Error(respCode, err.Error())
+		}
+
+		// read the page's batches in one round trip
+		cmds := make([]*redis.Cmd, len(page))
+		_, err = s.cacheClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, z := range page {
+				id, _ := srv.SafeCast[string](z.Member)
+				cmds[i] = pipe.Do(ctx, append([]interface{}{"HMGET", id}, batchStatusFields...)...)
+			}
+			return nil
+		})
+		if err != nil {
+			respCode = codes.Unavailable
+			return nil, status.Error(respCode, err.Error())
+		}
+
+		for i, z := range page {
+			if len(resp.Batches) == limit {
+				break scan
+			}
+			scanned++
+			cursor = srv.BatchIndexCursor(z)
+
+			// note: batches evicted from the cache are skipped, their index entries are trimmed after `retention`
+			id, _ := srv.SafeCast[string](z.Member)
+			batch, ok := batchStatusFromCache(id, cmds[i].Val())
+			if ok && ((len(statuses) == 0) || statuses[batch.Status]) {
+				resp.Batches = append(resp.Batches, batch)
+			}
+		}
+
+		// the index is exhausted, there's no next page
+		if len(page) < limit {
+			cursor = ""
+			break
+		}
+	}
+
+	resp.NextCursor = cursor
+	return resp, nil
 }
 
 func init() {
@@ -279,9 +791,26 @@ func init() {
 func main() {
 	flag.Parse()
 
+	if *queueConsumer == "" {
+		*queueConsumer, _ = os.Hostname()
+	}
+
+	queueClient := srv.MustRedisClient(
+		context.Background(),
+		&srv.RedisClientOptions{
+			DB:   *pubsubDB,
+			Host: *pubsubHost,
+			Port: *pubsubPort,
+		},
+	)
+
+	mgmtConn := srv.MustRPCClient(*mgmtServerHost, *mgmtServerPort)
+	defer mgmtConn.Close()
+
 	// init batch server object
 	batchServer := &BatchServer{
 		spacesClient: srv.MustSpacesClient(),
+		mgmtClient:   pb.NewManagementClient(mgmtConn),
 		cacheClient: srv.MustRedisClient(
 			context.Background(),
 			&srv.RedisClientOptions{
@@ -290,25 +819,32 @@ func main() {
 				Port: *redisCachePort,
 			},
 		),
-		pubsubClient: srv.MustRedisClient(
-			context.Background(),
-			&srv.RedisClientOptions{
-				DB:   *pubsubDB,
-				Host: *pubsubHost,
-				Port: *pubsubPort,
-			},
-		),
+		retention: *batchRetention,
+		operators: srv.ParseOperatorOwners(*operatorOwners),
+		createsQueue: &srv.BatchQueue{
+			Client: queueClient,
+			Stream: srv.BatchCreatesStream,
+		},
+		statusQueue: &srv.BatchQueue{
+			Client:    queueClient,
+			Stream:    srv.BatchStatusStream,
+			Group:     srv.BatchServersGroup,
+			Consumer:  *queueConsumer,
+			ClaimIdle: serverStatusClaimIdle,
+			Block:     serverQueueBlock,
+		},
 	}
 
-	// begin listening - the batch server listens for updates on `batch.status` and updates the cache
-	go batchServer.Listen(context.Background(), "batch.status")
+	// begin listening - the batch server consumes updates on `batch.status` and updates the cache
+	go batchServer.Listen(context.Background())
 
-	// apply server config - `CONFIGs SET maxmemory-policy allkeys-lru`
-	_, err := batchServer.cacheClient.Do(context.Background(), "CONFIG", "SET", "maxmemory-policy", "allkeys-lru").Result()
+	// apply server config - `CONFIGs SET maxmemory-policy volatile-lru`; batches expire after `--batch-retention`
+	// && are evicted before then if needed, the batch indexes have no TTL && are never evicted
+	_, err := batchServer.cacheClient.Do(context.Background(), "CONFIG", "SET", "maxmemory-policy", "volatile-lru").Result()
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err,
-		}).Warn("failed to set maxmemory-policy to allkeys-lru; proceed w. caution")
+		}).Warn("failed to set maxmemory-policy to volatile-lru; proceed w. caution")
 	}
 
 	// register && serve
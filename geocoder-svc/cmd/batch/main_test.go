@@ -0,0 +1,209 @@
+package main
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	// internal
+	srv "github.com/dmw2151/geocoder/geocoder-svc/internal"
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCacheInt32(t *testing.T) {
+	var tests = []struct {
+		v    interface{}
+		want int32
+	}{
+		{"42", 42},
+		{"0", 0},
+		{"", 0},
+		{nil, 0}, // batches created before the field was added
+		{"not a number", 0},
+		{int64(42), 0}, // hash fields are always read as strings
+	}
+
+	for _, tt := range tests {
+		if got := cacheInt32(tt.v); got != tt.want {
+			t.Errorf("cacheInt32(%v) = %d, want %d", tt.v, got, tt.want)
+		}
+	}
+}
+
+// hmget - the reply to an HMGET of `fields` from a hash set to `pairs`, values are stored as strings && unset fields
+// are nil
+func hmget(pairs []interface{}, fields []interface{}) []interface{} {
+	hash := make(map[interface{}]string)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		hash[pairs[i]] = fmt.Sprint(pairs[i+1])
+	}
+
+	res := make([]interface{}, len(fields))
+	for i, f := range fields {
+		if v, ok := hash[f]; ok {
+			res[i] = v
+		}
+	}
+	return res
+}
+
+func TestBatchStatusUpdateFields(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	eta := now.Add(time.Minute)
+
+	var tests = []struct {
+		name    string
+		update  *pb.BatchStatusResponse
+		wantEta bool
+	}{
+		{"in progress", &pb.BatchStatusResponse{
+			Status: pb.BatchGeocodeStatus_IN_QUEUE, Total: 100, Sent: 40, Resolved: 35, Unmatched: 5, Attempts: 2,
+			Eta: timestamppb.New(eta),
+		}, true},
+		{"failed", &pb.BatchStatusResponse{
+			Status: pb.BatchGeocodeStatus_RETRYING, Total: 100, Sent: 40, Resolved: 35, Unmatched: 5, Attempts: 2,
+			LastError: "geocoder unavailable", Eta: timestamppb.New(eta),
+		}, false}, // the ETA of an attempt that ended is stale
+		{"succeeded", &pb.BatchStatusResponse{
+			Status: pb.BatchGeocodeStatus_SUCCESS, Total: 100, Sent: 100, Resolved: 90, Unmatched: 10, Attempts: 1,
+			DownloadPath: "https://example.com/results.json",
+		}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := batchStatusFromCache("batch-1", hmget(batchStatusUpdateFields(tt.update, now), batchStatusFields))
+		if !ok {
+			t.Fatalf("batchStatusFromCache(%s) = not found", tt.name)
+		}
+
+		u := tt.update
+		if (got.Status != u.Status) || (got.Total != u.Total) || (got.Sent != u.Sent) || (got.Resolved != u.Resolved) ||
+			(got.Unmatched != u.Unmatched) || (got.Attempts != u.Attempts) || (got.LastError != u.LastError) ||
+			(got.DownloadPath != u.DownloadPath) || !got.UpdateTime.AsTime().Equal(now) {
+			t.Errorf("batchStatusFromCache(%s) = %v, want %v", tt.name, got, u)
+		}
+		if (tt.wantEta != (got.Eta != nil)) || (tt.wantEta && !got.Eta.AsTime().Equal(eta)) {
+			t.Errorf("batchStatusFromCache(%s) eta = %v, want %v (%v)", tt.name, got.Eta, eta, tt.wantEta)
+		}
+	}
+}
+
+func TestSetBatchStatusArgs(t *testing.T) {
+	now := time.Now()
+	update := &pb.BatchStatusResponse{Id: "batch-1", Status: pb.BatchGeocodeStatus_FAILED, LastError: "spaces unavailable"}
+	args := setBatchStatusArgs(update, now)
+
+	// the script reads the number of finished statuses, the statuses, then the fields to set
+	n, ok := args[0].(int)
+	if !ok || (n != len(batchFinishedStatuses)) || (len(args) < n+1) {
+		t.Fatalf("setBatchStatusArgs() = %v, want %d finished statuses first", args, len(batchFinishedStatuses))
+	}
+	for i, st := range batchFinishedStatuses {
+		if args[i+1] != st {
+			t.Errorf("setBatchStatusArgs()[%d] = %v, want %v", i+1, args[i+1], st)
+		}
+	}
+
+	got, ok := batchStatusFromCache("batch-1", hmget(args[n+1:], batchStatusFields))
+	if !ok || (got.Status != update.Status) || (got.LastError != update.LastError) {
+		t.Errorf("setBatchStatusArgs() fields = %v, want %v", got, update)
+	}
+}
+
+func TestOwnerArgs(t *testing.T) {
+	s := &BatchServer{operators: srv.ParseOperatorOwners("op-1, op-2")}
+
+	var tests = []struct {
+		owner string
+		want  []interface{}
+	}{
+		{"op-1", []interface{}{1, "op-1"}},
+		{"op-2", []interface{}{1, "op-2"}},
+		{"owner-1", []interface{}{0, "owner-1"}},
+		{"", []interface{}{0, ""}}, // batches created w.o. an API key
+	}
+
+	for _, tt := range tests {
+		if got := s.ownerArgs(tt.owner); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ownerArgs(%q) = %v, want %v", tt.owner, got, tt.want)
+		}
+	}
+}
+
+// fakeManagementClient - reports the datasets in `datasets` as existing && counts calls to `GetDatasetStats`, all
+// other calls panic
+type fakeManagementClient struct {
+	pb.ManagementClient
+	datasets map[string]bool
+	err      error
+	calls    int
+}
+
+func (f *fakeManagementClient) GetDatasetStats(ctx context.Context, req *pb.DatasetStatsRequest, opts ...grpc.CallOption) (*pb.DatasetStats, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	if !f.datasets[req.Dataset] {
+		return nil, status.Error(codes.NotFound, srv.ErrDatasetNotFound.Error())
+	}
+	return &pb.DatasetStats{}, nil
+}
+
+func TestDatasetExists(t *testing.T) {
+	client := &fakeManagementClient{datasets: map[string]bool{"nj": true}}
+	s := &BatchServer{mgmtClient: client}
+
+	var tests = []struct {
+		dataset string
+		want    bool
+		calls   int
+	}{
+		{srv.DefaultDataset, true, 0}, // always exists
+		{"nj", true, 1},
+		{"nj", true, 1}, // cached
+		{"ma", false, 2},
+		{"ma", false, 3}, // a missing dataset isn't cached, it may be loaded later
+	}
+
+	for _, tt := range tests {
+		got, err := s.datasetExists(context.Background(), tt.dataset)
+		if (err != nil) || (got != tt.want) || (client.calls != tt.calls) {
+			t.Errorf("datasetExists(%s) = %v, %v (%d calls), want %v, nil (%d calls)", tt.dataset, got, err, client.calls, tt.want, tt.calls)
+		}
+	}
+
+	client.err = status.Error(codes.Unavailable, "unavailable")
+	if _, err := s.datasetExists(context.Background(), "ma"); err == nil {
+		t.Errorf("datasetExists(ma) = nil error, want %v", client.err)
+	}
+}
+
+func TestCreateBatchRejectsDataset(t *testing.T) {
+	s := &BatchServer{mgmtClient: &fakeManagementClient{}}
+
+	var tests = []struct {
+		dataset string
+		want    codes.Code
+	}{
+		{"NJ", codes.InvalidArgument},
+		{"nj", codes.NotFound},
+	}
+
+	// both are rejected before the batch is written to the cache
+	for _, tt := range tests {
+		resp, err := s.CreateBatch(context.Background(), &pb.CreateBatchRequest{Dataset: tt.dataset})
+		if (status.Code(err) != tt.want) || (resp.GetStatus() != pb.BatchGeocodeStatus_REJECTED) {
+			t.Errorf("CreateBatch(%s) = %v, %v, want REJECTED, %v", tt.dataset, resp.GetStatus(), status.Code(err), tt.want)
+		}
+	}
+}
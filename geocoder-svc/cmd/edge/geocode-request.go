@@ -2,16 +2,81 @@ package main
 
 import (
 	// standard lib
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
+
+	// external
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	// internal
 	srv "github.com/dmw2151/geocoder/geocoder-svc/internal"
 	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
 )
 
+// batchOwner - the owner of the batches created by a request, a fingerprint of its `X-API-Key` header so the key
+// itself isn't stored; "" w.o. a key
+func batchOwner(r *http.Request) string {
+	key := strings.TrimSpace(r.Header.Get("X-API-Key"))
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// batchListRequestFromQuery - parses the query of `GET /batch/`, e.g. `?status=FAILED,CANCELED&created_after=...`;
+// lists the batches of `owner`, the query's `owner` is checked by the caller (see `GeocoderServerHandler.ListBatches`)
+func batchListRequestFromQuery(params url.Values, owner string) (*pb.ListBatchesRequest, error) {
+
+	var req = &pb.ListBatchesRequest{
+		Owner:  owner,
+		Cursor: params.Get("cursor"),
+	}
+
+	for _, name := range strings.Split(params.Get("status"), ",") {
+		if name = strings.ToUpper(strings.TrimSpace(name)); name == "" {
+			continue
+		}
+		v, ok := pb.BatchGeocodeStatus_value[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown `status` %s", name)
+		}
+		req.Statuses = append(req.Statuses, pb.BatchGeocodeStatus(v))
+	}
+
+	for param, ts := range map[string]**timestamppb.Timestamp{
+		"created_after":  &req.CreatedAfter,
+		"created_before": &req.CreatedBefore,
+	} {
+		if v := params.Get(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("`%s` must be an RFC3339 time, e.g. `2022-08-27T00:00:00Z`", param)
+			}
+			*ts = timestamppb.New(t)
+		}
+	}
+
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, srv.ErrInvalidBatchListFilter
+		}
+		req.Limit = int32(n)
+	}
+	return req, nil
+}
+
 type batchRequest struct {
 	Method         string     `json:"method"`
+	Dataset        string     `json:"dataset,omitempty"`
 	QueryAddresses []string   `json:"query_addr,omitempty"`
 	QueryPoints    []pb.Point `json:"query_pts,omitempty"`
 }
@@ -42,37 +107,98 @@ func (b *batchRequest) isValid() (bool, error) {
 		return false, srv.ErrInvalidReverseGeocodeRequest
 	}
 
+	if _, err := srv.ParseDataset(b.Dataset); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// boundariesRequest
+type boundariesRequest struct {
+	QueryLongitude float32  `json:"query_lng"`
+	QueryLatitude  float32  `json:"query_lat"`
+	Layers         []string `json:"layers,omitempty"`
+}
+
+// isValid
+func (b *boundariesRequest) isValid() (bool, error) {
+	if (math.Abs(float64(b.QueryLatitude)) > 90) || (math.Abs(float64(b.QueryLongitude)) > 180) {
+		return false, srv.ErrInvalidReverseGeocodeRequest
+	}
 	return true, nil
 }
 
 // genericGeocodeRequest
 type genericGeocodeRequest struct {
-	Method         string  `json:"method"`
-	MaxResults     uint32  `json:"max_results"`
-	QueryAddress   string  `json:"query_addr,omitempty"`
-	QueryLongitude float32 `json:"query_lng,omitempty"`
-	QueryLatitude  float32 `json:"query_lat,omitempty"`
+	Method          string                `json:"method"`
+	MaxResults      uint32                `json:"max_results"`
+	QueryAddress    string                `json:"query_addr,omitempty"`
+	QueryStructured *pb.StructuredAddress `json:"query_structured,omitempty"`
+	QueryLongitude  float32               `json:"query_lng,omitempty"`
+	QueryLatitude   float32               `json:"query_lat,omitempty"`
+	Filter          *pb.GeocodeFilter     `json:"filter,omitempty"`
+	MaxDistance     float32               `json:"max_distance_meters,omitempty"`
+	Dataset         string                `json:"dataset,omitempty"`
 }
 
 func (r *genericGeocodeRequest) generateReqCompositeStr() string {
+	dataset, _ := srv.ParseDataset(r.Dataset)
 	compositeStr := fmt.Sprintf(
-		"%s:%d:%d:%d:%s",
-		r.Method, r.MaxResults, int(r.QueryLatitude*edgeServiceCoordinatePrecison), int(r.QueryLongitude*edgeServiceCoordinatePrecison), r.QueryAddress,
+		"%s:%s:%d:%d:%d:%s:%s:%s:%d",
+		dataset, r.Method, r.MaxResults, int(r.QueryLatitude*edgeServiceCoordinatePrecison), int(r.QueryLongitude*edgeServiceCoordinatePrecison), r.QueryAddress,
+		r.structuredQueryStr(), r.filterStr(), int(r.MaxDistance),
 	)
 	return compositeStr
 }
 
+// structuredQueryStr - normalized composite of the structured query, empty if not set
+func (r *genericGeocodeRequest) structuredQueryStr() string {
+	if sq := r.QueryStructured; sq != nil {
+		return srv.ParseStructuredAddress(sq.HouseNumber, sq.Street, sq.Locality, sq.Region, sq.PostalCode).String()
+	}
+	return ""
+}
+
+// filterStr - normalized composite of the filter, empty if not set (or invalid)
+func (r *genericGeocodeRequest) filterStr() string {
+	f, err := srv.ParseGeocodeFilter(r.Filter)
+	if (err != nil) || (f == nil) {
+		return ""
+	}
+
+	var parts []string
+	if bb := f.BoundingBox; bb != nil {
+		parts = append(parts, fmt.Sprintf("bb(%d,%d,%d,%d)",
+			int(bb.Min.Latitude*edgeServiceCoordinatePrecison), int(bb.Min.Longitude*edgeServiceCoordinatePrecison),
+			int(bb.Max.Latitude*edgeServiceCoordinatePrecison), int(bb.Max.Longitude*edgeServiceCoordinatePrecison),
+		))
+	}
+	if f.Center != nil {
+		parts = append(parts, fmt.Sprintf("r(%d,%d,%d)",
+			int(f.Center.Latitude*edgeServiceCoordinatePrecison), int(f.Center.Longitude*edgeServiceCoordinatePrecison), int(f.RadiusMeters),
+		))
+	}
+	if len(f.PostalCodes) > 0 {
+		parts = append(parts, fmt.Sprintf("pc(%s)", strings.Join(f.PostalCodes, "|")))
+	}
+	if len(f.Localities) > 0 {
+		parts = append(parts, fmt.Sprintf("l(%s)", strings.Join(f.Localities, "|")))
+	}
+	return strings.Join(parts, ";")
+}
+
 // isValid
 func (r *genericGeocodeRequest) isValid() (bool, error) {
 
-	hasAddress := (strings.Trim(r.QueryAddress, "") != "")
+	hasAddress := (strings.Trim(r.QueryAddress, "") != "") || (r.structuredQueryStr() != "")
 	hasPoint := ((r.QueryLatitude != 0.0) && (r.QueryLongitude != 0.0))
 
-	if (r.Method != pb.Method_FWD_FUZZY.String()) && (r.Method != pb.Method_REV_NEAREST.String()) {
+	if _, ok := pb.Method_value[r.Method]; !ok {
 		return false, srv.ErrInvalidGeocodeMethod
 	}
 
-	if (r.Method == pb.Method_FWD_FUZZY.String()) && !(hasAddress) {
+	if (r.Method != pb.Method_REV_NEAREST.String()) && !(hasAddress) {
 		return false, srv.ErrInvalidForwardGeocodeRequest
 	}
 
@@ -84,13 +210,28 @@ func (r *genericGeocodeRequest) isValid() (bool, error) {
 		return false, srv.ErrMaxResultsOutofRange
 	}
 
+	if (r.MaxDistance < 0) || (r.MaxDistance > srv.ReverseMaxDistanceMeters) {
+		return false, srv.ErrMaxDistanceOutofRange
+	}
+
+	if _, err := srv.ParseGeocodeFilter(r.Filter); err != nil {
+		return false, err
+	}
+
+	if _, err := srv.ParseDataset(r.Dataset); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
 // getQuery - utility method to...
 func (r *genericGeocodeRequest) getQuery() string {
 	switch r.Method {
-	case pb.Method_FWD_FUZZY.String():
+	case pb.Method_FWD_FUZZY.String(), pb.Method_FWD_INTERPOLATED.String():
+		if r.QueryStructured != nil {
+			return r.structuredQueryStr()
+		}
 		return r.QueryAddress
 	case pb.Method_REV_NEAREST.String():
 		return fmt.Sprintf("(%.8f, %.8f)", r.QueryLatitude, r.QueryLongitude)
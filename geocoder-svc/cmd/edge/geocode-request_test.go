@@ -0,0 +1,145 @@
+package main
+
+import (
+	// standard lib
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+func TestGenericGeocodeRequestStructured(t *testing.T) {
+	req := &genericGeocodeRequest{
+		Method:     "FWD_FUZZY",
+		MaxResults: 1,
+		QueryStructured: &pb.StructuredAddress{
+			HouseNumber: "2111", Street: "Atlantic Avenue", Locality: "Brooklyn", PostalCode: "11233",
+		},
+	}
+	if ok, err := req.isValid(); !ok {
+		t.Fatalf("isValid() = %v, %v, want true", ok, err)
+	}
+	if got, want := req.getQuery(), "2111 ATLANTIC AVE BROOKLYN 11233"; got != want {
+		t.Errorf("getQuery() = %q, want %q", got, want)
+	}
+
+	// structured queries w. equivalent components share a cache key
+	other := *req
+	other.QueryStructured = &pb.StructuredAddress{HouseNumber: "2111", Street: "ATLANTIC AVE", Locality: "bklyn", PostalCode: "11233"}
+	if req.generateReqCompositeStr() != other.generateReqCompositeStr() {
+		t.Errorf("generateReqCompositeStr() = %q, %q, want equal", req.generateReqCompositeStr(), other.generateReqCompositeStr())
+	}
+
+	// a structured query w. no components isn't an address
+	req.QueryStructured = &pb.StructuredAddress{}
+	if ok, _ := req.isValid(); ok {
+		t.Errorf("isValid(empty structured query) = true, want false")
+	}
+}
+
+func TestGenericGeocodeRequestMaxDistance(t *testing.T) {
+	var tests = []struct {
+		maxDistance float32
+		want        bool
+	}{
+		{0, true}, // unset -> the geocoder's default radius
+		{250, true},
+		{1000, true},
+		{1000.5, false},
+		{-1, false},
+	}
+
+	for _, tt := range tests {
+		req := &genericGeocodeRequest{
+			Method: "REV_NEAREST", MaxResults: 1, QueryLatitude: 40.677, QueryLongitude: -73.932, MaxDistance: tt.maxDistance,
+		}
+		if got, _ := req.isValid(); got != tt.want {
+			t.Errorf("isValid(max_distance_meters=%v) = %v, want %v", tt.maxDistance, got, tt.want)
+		}
+	}
+
+	// reverse queries w. a different radius don't share a cache key
+	near := &genericGeocodeRequest{Method: "REV_NEAREST", MaxResults: 1, QueryLatitude: 40.677, QueryLongitude: -73.932}
+	far := *near
+	far.MaxDistance = 500
+	if near.generateReqCompositeStr() == far.generateReqCompositeStr() {
+		t.Errorf("generateReqCompositeStr() = %q for both radii, want distinct keys", near.generateReqCompositeStr())
+	}
+}
+
+func TestBoundariesRequestIsValid(t *testing.T) {
+	var tests = []struct {
+		lat, lng float32
+		want     bool
+	}{
+		{40.68, -73.95, true},
+		{0, 0, true}, // null island && points on the equator || prime meridian are valid
+		{0, -73.95, true},
+		{90, 180, true},
+		{90.1, 0, false},
+		{0, -180.1, false},
+	}
+
+	for _, tt := range tests {
+		req := &boundariesRequest{QueryLatitude: tt.lat, QueryLongitude: tt.lng}
+		if got, _ := req.isValid(); got != tt.want {
+			t.Errorf("boundariesRequest{%v, %v}.isValid() = %v, want %v", tt.lat, tt.lng, got, tt.want)
+		}
+	}
+}
+
+func TestBatchOwner(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/batch/", nil)
+	if owner := batchOwner(r); owner != "" {
+		t.Errorf("batchOwner() w.o. a key = %q, want \"\"", owner)
+	}
+
+	r.Header.Set("X-API-Key", " key-1 ")
+	owner := batchOwner(r)
+	if (len(owner) != 16) || strings.Contains(owner, "key-1") {
+		t.Errorf("batchOwner() = %q, want a 16 char fingerprint of the key", owner)
+	}
+
+	r.Header.Set("X-API-Key", "key-2")
+	if other := batchOwner(r); other == owner {
+		t.Errorf("batchOwner() = %q for different keys", other)
+	}
+}
+
+func TestBatchListRequestFromQuery(t *testing.T) {
+	params := url.Values{
+		"status":        {"failed, CANCELED"},
+		"created_after": {"2022-08-27T00:00:00Z"},
+		"limit":         {"20"},
+		"cursor":        {"c1"},
+		"owner":         {"owner-2"}, // checked by the handler
+	}
+
+	req, err := batchListRequestFromQuery(params, "owner-1")
+	if err != nil {
+		t.Fatalf("batchListRequestFromQuery() = %v", err)
+	}
+	if (req.Owner != "owner-1") || (req.Cursor != "c1") || (req.Limit != 20) || (req.CreatedBefore != nil) ||
+		(req.CreatedAfter.AsTime() != time.Date(2022, 8, 27, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("batchListRequestFromQuery() = %v", req)
+	}
+	if want := []pb.BatchGeocodeStatus{pb.BatchGeocodeStatus_FAILED, pb.BatchGeocodeStatus_CANCELED}; (len(req.Statuses) != 2) ||
+		(req.Statuses[0] != want[0]) || (req.Statuses[1] != want[1]) {
+		t.Errorf("batchListRequestFromQuery() statuses = %v, want %v", req.Statuses, want)
+	}
+
+	for _, params := range []url.Values{
+		{"status": {"DONE"}},
+		{"created_before": {"2022-08-27"}},
+		{"limit": {"ten"}},
+	} {
+		if _, err := batchListRequestFromQuery(params, "owner-1"); err == nil {
+			t.Errorf("batchListRequestFromQuery(%v) = nil, want an error", params)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	// external
@@ -16,6 +18,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	// internal
@@ -40,6 +44,9 @@ var (
 	// rpc service options
 	batchServerHost = flag.String("batch-server-host", "gcaas-batch", "host addresss of the gcaas batch server to forward batch requests")
 	batchServerPort = flag.Int("batch-server-port", 50053, "port of the gcaas batch server to forward batch requests")
+
+	// batch listing options
+	operatorOwners = flag.String("operator-owners", "", "(optional) comma-separated owners (fingerprints of API keys, see the `owner` of a batch) allowed to list other owners' batches w. `GET /batch/?owner=`")
 )
 
 const (
@@ -52,6 +59,9 @@ const (
 	// edgeServiceRequestTimeout - context deadline set on all responses to /geocode/;
 	edgeServiceRequestTimeout = 1 * time.Second
 
+	// edgeServiceDefaultSuggestions - number of suggestions returned by `/autocomplete/` when `max_results` is unset
+	edgeServiceDefaultSuggestions = 5
+
 	// edgeServiceCacheDurationSeconds - TTL (in seconds!) to set on all successful responses from `/geocoder.Geocoder/Geocode`
 	edgeServiceCacheDurationSeconds = 90
 )
@@ -64,11 +74,26 @@ type EdgeErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// httpStatusFromRPCError - HTTP status for an error returned by an RPC, e.g. an unknown dataset -> 404
+func httpStatusFromRPCError(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusUnprocessableEntity
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // GeocoderServerHandler - main handler for the edge service - attaches cache and RPC clients
 type GeocoderServerHandler struct {
 	geocoderClient pb.GeocoderClient
 	batchClient    pb.BatchClient
 	redisClient    *redis.Client
+	operatorOwners map[string]bool // owners allowed to list any owner's batches
 }
 
 // Health - healthcheck - that's all...
@@ -100,6 +125,7 @@ func (gh *GeocoderServerHandler) CreateBatch(w http.ResponseWriter, r *http.Requ
 	respLogger := log.WithFields(log.Fields{
 		"gcaas-request-id": ctx.Value("gcaas-request-id"),
 		"request.Method":   req.Method,
+		"request.Dataset":  req.Dataset,
 	})
 
 	// check valid - domain level checks - can we easily tell that this req will fail ?
@@ -133,12 +159,14 @@ func (gh *GeocoderServerHandler) CreateBatch(w http.ResponseWriter, r *http.Requ
 		Method:    pb.Method(method),
 		Addresses: req.QueryAddresses,
 		Points:    pts,
+		Dataset:   req.Dataset,
+		Owner:     batchOwner(r),
 	})
 
 	// on falure ...
 	if err != nil {
 		respLogger.Error("/geocoder.Batch/CreateBatch call failed")
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(httpStatusFromRPCError(err))
 		json.NewEncoder(w).Encode(&EdgeErrorResponse{
 			Error: err.Error(),
 		})
@@ -187,13 +215,8 @@ func (gh *GeocoderServerHandler) BatchGetStatus(w http.ResponseWriter, r *http.R
 
 	// on falure ...
 	if err != nil {
-		if err == redis.Nil {
-			respLogger.Warn("/geocoder.Batch/BatchStatus call successful; no result")
-			w.WriteHeader(http.StatusNotFound)
-		} else {
-			respLogger.Warn("/geocoder.Batch/BatchStatus call failed")
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		respLogger.Warn("/geocoder.Batch/BatchStatus call failed")
+		w.WriteHeader(httpStatusFromRPCError(err))
 		json.NewEncoder(w).Encode(&EdgeErrorResponse{
 			Error: err.Error(),
 		})
@@ -212,6 +235,252 @@ func (gh *GeocoderServerHandler) BatchGetStatus(w http.ResponseWriter, r *http.R
 	}
 }
 
+// ListBatches - proxies a call to `/geocoder.Batch/ListBatches`, lists the batches of the request's API key newest
+// first, see `batchListRequestFromQuery` for filters; only operators (`--operator-owners`) may list another owner's
+// batches w. `owner`
+func (gh *GeocoderServerHandler) ListBatches(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), edgeServiceRequestTimeout)
+	defer cancel()
+
+	owner := batchOwner(r)
+	if owner == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.New("invalid request; expect an `X-API-Key` header").Error(),
+		})
+		return
+	}
+
+	if v := r.URL.Query().Get("owner"); (v != "") && (v != owner) {
+		if !gh.operatorOwners[owner] {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(&EdgeErrorResponse{
+				Error: errors.New("only operators can list another owner's batches").Error(),
+			})
+			return
+		}
+		owner = v
+	}
+
+	req, err := batchListRequestFromQuery(r.URL.Query(), owner)
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.Wrap(err, "invalid request").Error(),
+		})
+		return
+	}
+
+	respLogger := log.WithFields(log.Fields{
+		"gcaas-request-id": ctx.Value("gcaas-request-id"),
+		"request.Owner":    req.Owner,
+		"request.Statuses": req.Statuses,
+	})
+
+	listBatchesResponse, err := gh.batchClient.ListBatches(ctx, req)
+
+	// on falure ...
+	if err != nil {
+		respLogger.Warn("/geocoder.Batch/ListBatches call failed")
+		w.WriteHeader(httpStatusFromRPCError(err))
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(listBatchesResponse)
+	if err != nil {
+		respLogger.Error("failed parsing /geocoder.Batch/ListBatches response")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.Wrap(err, "failed parsing /geocoder.Batch/ListBatches response").Error(),
+		})
+		return
+	}
+}
+
+// CancelBatch - proxies a call to `/geocoder.Batch/CancelBatch`, a batch that already finished can't be canceled (409);
+// only the batch's owner (i.e. the same `X-API-Key`) && operators can cancel it, other requests get a 404
+func (gh *GeocoderServerHandler) CancelBatch(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), edgeServiceRequestTimeout)
+	defer cancel()
+
+	// parse vars...
+	vars := mux.Vars(r)
+	id, _ := vars["id"]
+
+	respLogger := log.WithFields(log.Fields{
+		"gcaas-request-id": ctx.Value("gcaas-request-id"),
+		"request.BatchId":  id,
+	})
+
+	if _, err := uuid.Parse(id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		respLogger.Error("batch uuid (`id`) not a valid uuid")
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.New("invalid request url; expect DELETE request to `/batch/${batch-uuid}`").Error(),
+		})
+		return
+	}
+
+	batchStatusResponse, err := gh.batchClient.CancelBatch(ctx, &pb.CancelBatchRequest{
+		Id:    id,
+		Owner: batchOwner(r),
+	})
+
+	// on falure ...
+	if err != nil {
+		respLogger.Warn("/geocoder.Batch/CancelBatch call failed")
+		w.WriteHeader(httpStatusFromRPCError(err))
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(batchStatusResponse)
+	if err != nil {
+		respLogger.Error("failed parsing /geocoder.Batch/CancelBatch response")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.Wrap(err, "failed parsing /geocoder.Batch/CancelBatch response").Error(),
+		})
+		return
+	}
+}
+
+// Boundaries - proxies a call to `/geocoder.Geocoder/Boundaries` and returns the boundaries containing a point
+func (gh *GeocoderServerHandler) Boundaries(w http.ResponseWriter, r *http.Request) {
+
+	var req = &boundariesRequest{}
+
+	ctx, cancel := context.WithTimeout(r.Context(), edgeServiceRequestTimeout)
+	defer cancel()
+
+	// parse req into `boundariesRequest`; throwing error and exiting if fails
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.New("invalid request body").Error(),
+		})
+		return
+	}
+
+	respLogger := log.WithFields(log.Fields{
+		"gcaas-request-id": ctx.Value("gcaas-request-id"),
+		"request.Layers":   req.Layers,
+	})
+
+	// check valid - domain level checks - can we easily tell that this req will fail ?
+	ok, err := req.isValid()
+	if err != nil || !ok {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.Wrap(err, "invalid request body").Error(),
+		})
+		return
+	}
+
+	res, err := gh.geocoderClient.Boundaries(ctx, &pb.BoundariesRequest{
+		Point: &pb.Point{
+			Latitude:  req.QueryLatitude,
+			Longitude: req.QueryLongitude,
+		},
+		Layers: req.Layers,
+	})
+
+	// on falure ...
+	if err != nil {
+		respLogger.Error("/geocoder.Geocoder/Boundaries call failed")
+		w.WriteHeader(httpStatusFromRPCError(err))
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// on success -> write back to the user
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		respLogger.Error("failed parsing /geocoder.Geocoder/Boundaries response")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.Wrap(err, "failed parsing /geocoder.Geocoder/Boundaries response").Error(),
+		})
+		return
+	}
+}
+
+// Autocomplete - proxies a call to `/geocoder.Geocoder/Suggest`, e.g. `GET /autocomplete/?q=54%20Macon&max_results=5`;
+// responses are NOT cached, nearly every prefix is only requested once
+func (gh *GeocoderServerHandler) Autocomplete(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(r.Context(), edgeServiceRequestTimeout)
+	defer cancel()
+
+	params := r.URL.Query()
+
+	respLogger := log.WithFields(log.Fields{
+		"gcaas-request-id": ctx.Value("gcaas-request-id"),
+		"request.Prefix":   params.Get("q"),
+		"request.Dataset":  params.Get("dataset"),
+	})
+
+	// check valid - domain level checks - can we easily tell that this req will fail ?
+	var maxResults uint64 = edgeServiceDefaultSuggestions
+	if v := params.Get("max_results"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 32)
+		if (err != nil) || (n < 1) || (n > 1024) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(&EdgeErrorResponse{
+				Error: errors.Wrap(srv.ErrMaxResultsOutofRange, "invalid request").Error(),
+			})
+			return
+		}
+		maxResults = n
+	}
+
+	if strings.TrimSpace(params.Get("q")) == "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.New("invalid request; expect GET request to `/autocomplete/?q=${PARTIAL_ADDRESS}`").Error(),
+		})
+		return
+	}
+
+	res, err := gh.geocoderClient.Suggest(ctx, &pb.SuggestRequest{
+		Prefix:     params.Get("q"),
+		MaxResults: uint32(maxResults),
+		Fuzzy:      params.Get("fuzzy") == "true",
+		Dataset:    params.Get("dataset"),
+	})
+
+	// on falure ...
+	if err != nil {
+		respLogger.Error("/geocoder.Geocoder/Suggest call failed")
+		w.WriteHeader(httpStatusFromRPCError(err))
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// on success -> write back to the user
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		respLogger.Error("failed parsing /geocoder.Geocoder/Suggest response")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(&EdgeErrorResponse{
+			Error: errors.Wrap(err, "failed parsing /geocoder.Geocoder/Suggest response").Error(),
+		})
+		return
+	}
+}
+
 // Query - proxies a call to `/geocoder.Geocoder/Geocode` and returns request to client
 func (gh *GeocoderServerHandler) Query(w http.ResponseWriter, r *http.Request) {
 
@@ -238,6 +507,7 @@ func (gh *GeocoderServerHandler) Query(w http.ResponseWriter, r *http.Request) {
 		"request.MaxResults": req.MaxResults,
 		"request.Method":     req.Method,
 		"request.Query":      req.getQuery(),
+		"request.Dataset":    req.Dataset,
 	})
 
 	// check valid - domain level checks - can we easily tell that this req will fail ?
@@ -250,6 +520,12 @@ func (gh *GeocoderServerHandler) Query(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// normalize the address before caching && fuzzing, equivalent addresses (e.g. `E 76th Street`, `E 76 ST`)
+	// share a cache key
+	if (req.Method != pb.Method_REV_NEAREST.String()) && (req.QueryStructured == nil) {
+		req.QueryAddress = srv.NormalizeAddress(req.QueryAddress)
+	}
+
 	// CACHE SET w. `generateReqCompositeStr` (really a long concat) and defer a call to cache a result
 	var h = req.generateReqCompositeStr()
 	defer func() {
@@ -292,14 +568,48 @@ func (gh *GeocoderServerHandler) Query(w http.ResponseWriter, r *http.Request) {
 	// construct request to `gh.geocoderClient.Geocode` and get geocode results
 	switch req.Method {
 	case pb.Method_FWD_FUZZY.String():
-		res, err = gh.geocoderClient.Geocode(ctx, &pb.GeocodeRequest{
-			Query: &pb.Query{
-				Query: &pb.Query_AddressQuery{
-					AddressQuery: requestRegexp.ReplaceAllString(req.QueryAddress, "%$1%"), // Levenstien distance of 1 on all words...
-				},
+		// structured queries are passed as-is, matching on components is exact (no fuzzing)
+		var query = &pb.Query{
+			Query: &pb.Query_AddressQuery{
+				AddressQuery: requestRegexp.ReplaceAllString(req.QueryAddress, "%$1%"), // Levenstien distance of 1 on all words...
 			},
+		}
+		if req.QueryStructured != nil {
+			query = &pb.Query{
+				Query: &pb.Query_StructuredQuery{
+					StructuredQuery: req.QueryStructured,
+				},
+			}
+		}
+
+		res, err = gh.geocoderClient.Geocode(ctx, &pb.GeocodeRequest{
+			Query:      query,
 			MaxResults: req.MaxResults,
 			Method:     pb.Method_FWD_FUZZY,
+			Filter:     req.Filter,
+			Dataset:    req.Dataset,
+		})
+	case pb.Method_FWD_INTERPOLATED.String():
+		// interpolation matches the house number && street exactly -> no fuzzing
+		var query = &pb.Query{
+			Query: &pb.Query_AddressQuery{
+				AddressQuery: req.QueryAddress,
+			},
+		}
+		if req.QueryStructured != nil {
+			query = &pb.Query{
+				Query: &pb.Query_StructuredQuery{
+					StructuredQuery: req.QueryStructured,
+				},
+			}
+		}
+
+		res, err = gh.geocoderClient.Geocode(ctx, &pb.GeocodeRequest{
+			Query:      query,
+			MaxResults: req.MaxResults,
+			Method:     pb.Method_FWD_INTERPOLATED,
+			Filter:     req.Filter,
+			Dataset:    req.Dataset,
 		})
 	case pb.Method_REV_NEAREST.String():
 		res, err = gh.geocoderClient.Geocode(ctx, &pb.GeocodeRequest{
@@ -311,14 +621,16 @@ func (gh *GeocoderServerHandler) Query(w http.ResponseWriter, r *http.Request) {
 					},
 				},
 			},
-			MaxResults: req.MaxResults,
-			Method:     pb.Method_REV_NEAREST,
+			MaxResults:        req.MaxResults,
+			Method:            pb.Method_REV_NEAREST,
+			Filter:            req.Filter,
+			MaxDistanceMeters: req.MaxDistance,
+			Dataset:           req.Dataset,
 		})
 	}
 
-	// TODO: should be a switch on RPC codes -> HTTP codes; for now, currently all internal errors...
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(httpStatusFromRPCError(err))
 		json.NewEncoder(w).Encode(&EdgeErrorResponse{
 			Error: err.Error(),
 		})
@@ -358,6 +670,7 @@ func main() {
 				Host: *redisCacheHost,
 				Port: *redisCachePort,
 			}),
+		operatorOwners: srv.ParseOperatorOwners(*operatorOwners),
 	}
 
 	// init router
@@ -370,8 +683,12 @@ func main() {
 
 	// init /locations/ route -> returns addresses; call to `/geocoder.Geocoder/Geocode`
 	router.HandleFunc("/geocode/", svcHandler.Query).Methods("POST")
+	router.HandleFunc("/boundaries/", svcHandler.Boundaries).Methods("POST")
+	router.HandleFunc("/autocomplete/", svcHandler.Autocomplete).Methods("GET")
 	router.HandleFunc("/batch/", svcHandler.CreateBatch).Methods("POST")
+	router.HandleFunc("/batch/", svcHandler.ListBatches).Methods("GET")
 	router.HandleFunc("/batch/{id}", svcHandler.BatchGetStatus).Methods("GET")
+	router.HandleFunc("/batch/{id}", svcHandler.CancelBatch).Methods("DELETE")
 	router.HandleFunc("/health/", svcHandler.Health).Methods("GET")
 
 	// start server - TODO: this is not graceful at all - consider some treatment for server exit
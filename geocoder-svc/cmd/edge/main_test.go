@@ -0,0 +1,189 @@
+package main
+
+import (
+	// standard lib
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeBatchClient - returns `resp` && `err` from every call it implements, requests are kept in `cancelRequests`,
+// `listRequests` && `statusRequests`
+type fakeBatchClient struct {
+	pb.BatchClient
+	resp           *pb.BatchStatusResponse
+	err            error
+	cancelRequests []*pb.CancelBatchRequest
+	listRequests   []*pb.ListBatchesRequest
+	statusRequests []*pb.BatchStatusRequest
+}
+
+func (f *fakeBatchClient) GetBatchStatus(ctx context.Context, req *pb.BatchStatusRequest, opts ...grpc.CallOption) (*pb.BatchStatusResponse, error) {
+	f.statusRequests = append(f.statusRequests, req)
+	return f.resp, f.err
+}
+
+func (f *fakeBatchClient) ListBatches(ctx context.Context, req *pb.ListBatchesRequest, opts ...grpc.CallOption) (*pb.ListBatchesResponse, error) {
+	f.listRequests = append(f.listRequests, req)
+	return &pb.ListBatchesResponse{}, f.err
+}
+
+func (f *fakeBatchClient) CancelBatch(ctx context.Context, req *pb.CancelBatchRequest, opts ...grpc.CallOption) (*pb.BatchStatusResponse, error) {
+	f.cancelRequests = append(f.cancelRequests, req)
+	return f.resp, f.err
+}
+
+func TestHTTPStatusFromRPCError(t *testing.T) {
+	var tests = []struct {
+		err  error
+		want int
+	}{
+		{status.Error(codes.NotFound, "not found"), http.StatusNotFound},
+		{status.Error(codes.InvalidArgument, "invalid"), http.StatusUnprocessableEntity},
+		{status.Error(codes.FailedPrecondition, "finished"), http.StatusConflict},
+		{status.Error(codes.Unavailable, "unavailable"), http.StatusInternalServerError},
+		{context.DeadlineExceeded, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := httpStatusFromRPCError(tt.err); got != tt.want {
+			t.Errorf("httpStatusFromRPCError(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCancelBatch(t *testing.T) {
+	const id = "0b6c3b4e-7f5a-4d8e-9a53-5d9f1f0f6a51"
+
+	var tests = []struct {
+		name       string
+		id         string
+		resp       *pb.BatchStatusResponse
+		err        error
+		wantStatus int
+		wantCalls  int
+	}{
+		{"canceled", id, &pb.BatchStatusResponse{Id: id, Status: pb.BatchGeocodeStatus_CANCELED}, nil, http.StatusOK, 1},
+		{"invalid id", "not-a-uuid", nil, nil, http.StatusBadRequest, 0},
+		{"unknown batch", id, nil, status.Error(codes.NotFound, "batch not found"), http.StatusNotFound, 1}, // || another owner's
+		{"finished batch", id, nil, status.Error(codes.FailedPrecondition, "finished"), http.StatusConflict, 1},
+	}
+
+	for _, tt := range tests {
+		client := &fakeBatchClient{resp: tt.resp, err: tt.err}
+		gh := &GeocoderServerHandler{batchClient: client}
+
+		r := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/batch/"+tt.id, nil), map[string]string{"id": tt.id})
+		r.Header.Set("X-API-Key", "caller-key")
+		w := httptest.NewRecorder()
+		gh.CancelBatch(w, r)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("CancelBatch(%s) = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if len(client.cancelRequests) != tt.wantCalls {
+			t.Errorf("CancelBatch(%s) made %d calls, want %d", tt.name, len(client.cancelRequests), tt.wantCalls)
+			continue
+		}
+		if (tt.wantCalls > 0) && (client.cancelRequests[0].Id != tt.id) {
+			t.Errorf("CancelBatch(%s) canceled %s, want %s", tt.name, client.cancelRequests[0].Id, tt.id)
+		}
+		if (tt.wantCalls > 0) && (client.cancelRequests[0].Owner != batchOwner(r)) {
+			t.Errorf("CancelBatch(%s) canceled as %q, want %q", tt.name, client.cancelRequests[0].Owner, batchOwner(r))
+		}
+
+		if tt.resp != nil {
+			var got pb.BatchStatusResponse
+			if err := json.NewDecoder(w.Body).Decode(&got); (err != nil) || (got.Status != tt.resp.Status) {
+				t.Errorf("CancelBatch(%s) = %v, %v, want status %v", tt.name, got.Status, err, tt.resp.Status)
+			}
+		}
+	}
+}
+
+func TestBatchGetStatus(t *testing.T) {
+	const id = "0b6c3b4e-7f5a-4d8e-9a53-5d9f1f0f6a51"
+
+	var tests = []struct {
+		name       string
+		resp       *pb.BatchStatusResponse
+		err        error
+		wantStatus int
+	}{
+		{"found", &pb.BatchStatusResponse{Id: id, Status: pb.BatchGeocodeStatus_IN_QUEUE}, nil, http.StatusOK},
+		{"unknown batch", nil, status.Error(codes.NotFound, "batch not found"), http.StatusNotFound},
+		{"cache unavailable", nil, status.Error(codes.Unavailable, "unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		client := &fakeBatchClient{resp: tt.resp, err: tt.err}
+		gh := &GeocoderServerHandler{batchClient: client}
+
+		r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/batch/"+id, nil), map[string]string{"id": id})
+		w := httptest.NewRecorder()
+		gh.BatchGetStatus(w, r)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("BatchGetStatus(%s) = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if (len(client.statusRequests) != 1) || (client.statusRequests[0].Id != id) {
+			t.Errorf("BatchGetStatus(%s) requests = %v, want 1 for %s", tt.name, client.statusRequests, id)
+		}
+	}
+}
+
+func TestListBatchesOwnerScope(t *testing.T) {
+	operator := httptest.NewRequest(http.MethodGet, "/batch/", nil)
+	operator.Header.Set("X-API-Key", "operator-key")
+
+	caller := httptest.NewRequest(http.MethodGet, "/batch/", nil)
+	caller.Header.Set("X-API-Key", "caller-key")
+	callerOwner, operatorOwner := batchOwner(caller), batchOwner(operator)
+
+	var tests = []struct {
+		name       string
+		key        string
+		owner      string // `owner` query param
+		wantStatus int
+		wantOwner  string // owner listed, "" if the call isn't made
+	}{
+		{"no key", "", "", http.StatusUnauthorized, ""},
+		{"own batches", "caller-key", "", http.StatusOK, callerOwner},
+		{"own batches by owner", "caller-key", callerOwner, http.StatusOK, callerOwner},
+		{"other owner", "caller-key", operatorOwner, http.StatusForbidden, ""},
+		{"operator lists other owner", "operator-key", callerOwner, http.StatusOK, callerOwner},
+	}
+
+	for _, tt := range tests {
+		client := &fakeBatchClient{}
+		gh := &GeocoderServerHandler{batchClient: client, operatorOwners: map[string]bool{operatorOwner: true}}
+
+		r := httptest.NewRequest(http.MethodGet, "/batch/?owner="+tt.owner, nil)
+		if tt.key != "" {
+			r.Header.Set("X-API-Key", tt.key)
+		}
+		w := httptest.NewRecorder()
+		gh.ListBatches(w, r)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("ListBatches(%s) = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		switch {
+		case (tt.wantOwner == "") && (len(client.listRequests) != 0):
+			t.Errorf("ListBatches(%s) listed %s, want no call", tt.name, client.listRequests[0].Owner)
+		case (tt.wantOwner != "") && ((len(client.listRequests) != 1) || (client.listRequests[0].Owner != tt.wantOwner)):
+			t.Errorf("ListBatches(%s) = %v, want a call listing %s", tt.name, client.listRequests, tt.wantOwner)
+		}
+	}
+}
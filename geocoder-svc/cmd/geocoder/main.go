@@ -4,10 +4,8 @@ import (
 	// standard lib
 	"context"
 	"flag"
-	"fmt"
 	"io"
 	"math"
-	"strconv"
 	"strings"
 	"time"
 
@@ -16,7 +14,6 @@ import (
 	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
 
 	// external
-	redis "github.com/go-redis/redis/v8"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -32,21 +29,21 @@ var (
 	redisHost = flag.String("redis-host", "search", "host of the redis server to use as a FT engine")
 	redisPort = flag.Int("redis-port", 6379, "host of the redis server to use as a FT engine")
 	redisDB   = flag.Int("redis-db", 0, "db of the redis server to use as a FT engine")
+
+	// search options
+	searchBackend  = flag.String("search-backend", srv.SearchBackendRedis, "search backend, one of (`redis`, `memory`); `memory` holds all addresses in-process")
+	memorySnapshot = flag.String("memory-snapshot", "", "(optional) w. `--search-backend memory`, serve the snapshot file written by the management service && reload it when it changes")
 )
 
 // GeocoderServer Specific Constants //
 const (
 
-	// serverMaxQueuedTransactions - during `InsertorReplaceAddressData`, maximum number of pipelined transactions to
-	// allow before calling Pipe.Exec()
-	serverMaxQueuedTransactions = 1024
-
-	// serverReverseToleranceMeters - maximum error for reverse geocoding, only results within `serverReverseToleranceMeters`
-	// meters of the query point are considred
+	// serverReverseToleranceMeters - default maximum error for reverse geocoding, only results within `serverReverseToleranceMeters`
+	// meters of the query point are considred; overridden by `max_distance_meters` up to `srv.ReverseMaxDistanceMeters`
 	serverReverseToleranceMeters = 64
 
-	// serverNFieldsForwardResponse - number of expected fields in forward response - assumes fixed across multiple methods
-	serverNFieldsForwardResponse = 3
+	// serverDefaultSuggestions - number of suggestions returned by `Suggest` when `max_results` is unset
+	serverDefaultSuggestions = 5
 
 	// batchJobMaxDuration - ...
 	batchJobMaxDuration = time.Second * 180
@@ -55,107 +52,147 @@ const (
 // GeocoderServer - server API for Geocoder service
 type GeocoderServer struct {
 	pb.UnimplementedGeocoderServer
-	client *redis.Client
+	backend srv.SearchBackend
 }
 
-// handleGeocoderError
+// handleGeocoderError - sets the response code for an error returned by `Forward` or `Reverse`
 func handleGeocoderError(err error, rc *codes.Code) {
 	switch err {
-	case srv.ErrMalformedRedisQuery:
-		c := codes.InvalidArgument
-		rc = &c
+	case srv.ErrMalformedRedisQuery, srv.ErrInvalidGeocodeFilter, srv.ErrMaxDistanceOutofRange, srv.ErrInvalidDataset:
+		*rc = codes.InvalidArgument
+	case srv.ErrDatasetNotFound:
+		*rc = codes.NotFound
 	case srv.ErrRedisClient:
-		c := codes.Internal
-		rc = &c
+		*rc = codes.Internal
 	default:
-		c := codes.Unknown
-		rc = &c
+		*rc = codes.Unknown
 	}
 }
 
-// handleServerResponse - handles a *very specific* format of server response from both
-// forward and reverse geocoding, returns []*pb.scoredAddress
-//
-// WARN: makes extensive use of `srv.SafeCast[T](someInterface)` to convert the []interface{}
-// we get back from the server to structs. For brevity -> no error checking here
-func handleServerResponse(res interface{}, maxResults int64) ([]*pb.ScoredAddress, error) {
-
-	resultSet, _ := srv.SafeCast[[]interface{}](res)
-	nResults, _ := srv.SafeCast[int64](resultSet[0])
+// resolveDataset - validates a request's dataset && checks it exists, an empty dataset is the default dataset
+func (s *GeocoderServer) resolveDataset(ctx context.Context, dataset string) (string, error) {
+	dataset, err := srv.ParseDataset(dataset)
+	if err != nil {
+		return "", err
+	}
 
-	// parse the result array into []*pb.ScoredAddress -> limit to smaller of n results
-	// and max results to pre-allocate size...
-	if maxResults < nResults {
-		nResults = maxResults
+	ok, err := s.backend.DatasetExists(ctx, dataset)
+	if err != nil {
+		return "", err
 	}
+	if !ok {
+		return "", srv.ErrDatasetNotFound
+	}
+	return dataset, nil
+}
 
-	var addressResults = make([]*pb.ScoredAddress, nResults)
+// Forward - run forward geocoding via call to `/geocoder.Geocoder/Geocode`
+func (s *GeocoderServer) Forward(ctx context.Context, req *pb.GeocodeRequest) ([]*pb.ScoredAddress, error) {
 
-	if cap(addressResults) == 0 {
-		return addressResults, status.Error(codes.OK, "ok")
+	dataset, err := s.resolveDataset(ctx, req.Dataset)
+	if err != nil {
+		return nil, err
 	}
 
-	// Grab the `maxConfidence` from the first result -> produce a normalized confidence for each
-	// result in the set...
-	//
-	// NOTE: assumes results sorted in order of best -> worst match
-	confidenceStr, _ := srv.SafeCast[string](resultSet[2])
-	maxConfidence, _ := strconv.ParseFloat(confidenceStr, 32)
-
-	for i := int64(0); i < nResults; i++ {
+	filter, err := srv.ParseGeocodeFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
 
-		resultStartPosition := (i * serverNFieldsForwardResponse) + 1
+	// structured queries -> one fielded clause per component, all components are normalized the same as
+	// free text queries
+	if sq := req.Query.GetStructuredQuery(); sq != nil {
+		parsedQuery := srv.ParseStructuredAddress(sq.HouseNumber, sq.Street, sq.Locality, sq.Region, sq.PostalCode)
+		if parsedQuery.String() == "" {
+			return nil, srv.ErrMalformedRedisQuery
+		}
 
-		// extract ID
-		Id, _ := srv.SafeCast[string](resultSet[resultStartPosition])
+		return s.backend.SearchText(ctx, &srv.TextQuery{
+			Dataset: dataset,
+			Address: parsedQuery,
+			Filter:  filter,
+			Limit:   int(req.MaxResults),
+		})
+	}
 
-		// extract confidence score
-		confScoreStr, _ := srv.SafeCast[string](resultSet[resultStartPosition+1])
-		confScore, _ := strconv.ParseFloat(confScoreStr, 32)
+	addrQuery := req.Query.GetAddressQuery()
 
-		// extract details (e.g. lat, lng, address...)
-		addressDetails, _ := srv.SafeCast[[]interface{}](resultSet[resultStartPosition+2])
+	// check conditions we KNON the db server would fail (e.g. addrQuery is `null`) or `req.MaxResults < 0`
+	if strings.Trim(addrQuery, "") == "" {
+		return nil, srv.ErrMalformedRedisQuery
+	}
 
-		locationStr, _ := srv.SafeCast[string](addressDetails[1])
+	// normalize w. the same rules applied at ingest, e.g. `E 76th Street` -> `E 76 ST`
+	return s.backend.SearchText(ctx, &srv.TextQuery{
+		Dataset: dataset,
+		Text:    srv.NormalizeAddressQuery(addrQuery),
+		Filter:  filter,
+		Limit:   int(req.MaxResults),
+	})
+}
 
-		// TODO: WARN: NOTE: THIS IS A VERY SCARY FLAG
-		pt := srv.PointFromLocationString(locationStr, false)
-		fullStreetAddress, _ := srv.SafeCast[string](addressDetails[3])
+// Interpolate - run forward geocoding for an exact address via call to `/geocoder.Geocoder/Geocode`, addresses
+// missing from the address points are interpolated along the street segments containing the house number
+func (s *GeocoderServer) Interpolate(ctx context.Context, req *pb.GeocodeRequest) ([]*pb.ScoredAddress, error) {
 
-		// append all results to addressresults...
-		addressResults[i] = &pb.ScoredAddress{
-			Address: &pb.Address{
-				Location:               pt,
-				Id:                     Id,
-				CompositeStreetAddress: fullStreetAddress,
-			},
-			NormedConfidence: float32(confScore / maxConfidence),
-		}
+	dataset, err := s.resolveDataset(ctx, req.Dataset)
+	if err != nil {
+		return nil, err
 	}
-	return addressResults, nil
-}
 
-// Forward - run forward geocoding via call to `/geocoder.Geocoder/Geocode`
-func (s *GeocoderServer) Forward(ctx context.Context, req *pb.GeocodeRequest) ([]*pb.ScoredAddress, error) {
+	filter, err := srv.ParseGeocodeFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
 
-	addrQuery := req.Query.GetAddressQuery()
+	// interpolation requires (at least) a house number && a street
+	var parsedQuery *srv.ParsedAddress
+	if sq := req.Query.GetStructuredQuery(); sq != nil {
+		parsedQuery = srv.ParseStructuredAddress(sq.HouseNumber, sq.Street, sq.Locality, sq.Region, sq.PostalCode)
+	} else {
+		parsedQuery = srv.ParseAddress(req.Query.GetAddressQuery())
+	}
 
-	// check conditions we KNON the db server would fail (e.g. addrQuery is `null`) or `req.MaxResults < 0`
-	if strings.Trim(addrQuery, "") == "" {
+	if _, ok := srv.HouseNumberValue(parsedQuery.HouseNumber); !ok || (parsedQuery.FullStreet() == "") {
 		return nil, srv.ErrMalformedRedisQuery
 	}
 
-	res, err := s.client.Do(
-		ctx, "FT.SEARCH", "addr-idx", fmt.Sprintf("@composite_street_address:%s", addrQuery),
-		"WITHSCORES", "LANGUAGE", "english", "SCORER", "TFIDF.DOCNORM", "LIMIT", "0", req.MaxResults,
-	).Result()
+	// prefer address points (rooftop) when the address exists
+	addressResults, err := s.backend.SearchText(ctx, &srv.TextQuery{
+		Dataset: dataset,
+		Address: parsedQuery,
+		Filter:  filter,
+		Limit:   int(req.MaxResults),
+	})
+	if (err != nil) || (len(addressResults) > 0) {
+		return addressResults, err
+	}
 
-	// some unknown error preventing results -> raise as internal :(
+	segments, err := s.backend.SearchSegments(ctx, &srv.SegmentQuery{
+		Dataset: dataset,
+		Address: parsedQuery,
+		Limit:   int(req.MaxResults),
+	})
 	if err != nil {
-		return nil, srv.ErrRedisClient
+		return nil, err
 	}
 
-	return handleServerResponse(res, int64(req.MaxResults))
+	for _, seg := range segments {
+		pt, ok := srv.InterpolateStreetSegment(seg.Segment, parsedQuery.HouseNumber)
+		if !ok {
+			continue
+		}
+
+		address := srv.InterpolatedAddress(dataset, seg.Segment, parsedQuery, pt)
+		if filter.Matches(address) {
+			addressResults = append(addressResults, &pb.ScoredAddress{
+				Address:          address,
+				NormedConfidence: seg.NormedConfidence,
+				LocationType:     pb.LocationType_INTERPOLATED,
+			})
+		}
+	}
+	return addressResults, nil
 }
 
 // Reverse - run reverse geocoding via call to `/geocoder.Geocoder/Geocode`
@@ -163,25 +200,36 @@ func (s *GeocoderServer) Reverse(ctx context.Context, req *pb.GeocodeRequest) ([
 
 	ptQuery := req.Query.GetPointQuery()
 
+	dataset, err := s.resolveDataset(ctx, req.Dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := srv.ParseGeocodeFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
 	// check conditions we KNON the db server would fail (e.g. addrQuery is `null`) or `req.MaxResults < 0`
 	// here, check query contains invalid coordinates -> throw malformed reqquest
 	if math.Abs(float64(ptQuery.Latitude)) > 90 || math.Abs(float64(ptQuery.Longitude)) > 180 {
 		return nil, srv.ErrMalformedRedisQuery
 	}
 
-	res, err := s.client.Do(
-		ctx, "FT.SEARCH", "addr-idx",
-		fmt.Sprintf("@location:[%.8f %.8f %d m]", ptQuery.Latitude, ptQuery.Longitude, serverReverseToleranceMeters),
-		"WITHSCORES", "LIMIT", "0", req.MaxResults,
-	).Result()
-
-	// some uncaught error preventing results -> raise as internal :(
-	if err != nil {
-		return nil, srv.ErrRedisClient
+	var radius float64 = serverReverseToleranceMeters
+	if req.MaxDistanceMeters < 0 || req.MaxDistanceMeters > srv.ReverseMaxDistanceMeters {
+		return nil, srv.ErrMaxDistanceOutofRange
+	} else if req.MaxDistanceMeters > 0 {
+		radius = float64(req.MaxDistanceMeters)
 	}
 
-	// parse response && return to client
-	return handleServerResponse(res, int64(req.MaxResults))
+	return s.backend.SearchRadius(ctx, &srv.RadiusQuery{
+		Dataset:      dataset,
+		Center:       ptQuery,
+		RadiusMeters: radius,
+		Filter:       filter,
+		Limit:        int(req.MaxResults),
+	})
 }
 
 // Geocode - call the GRPC server `/geocoder.Geocoder/Geocode` method
@@ -194,12 +242,15 @@ func (s *GeocoderServer) Geocode(ctx context.Context, req *pb.GeocodeRequest) (*
 
 	defer func() {
 		reqLogger := log.WithFields(log.Fields{
-			"request.max_results": req.MaxResults,
-			"request.method":      req.Method,
-			"request.Query":       req.GetQuery(),
-			"duration":            -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
-			"method":              "/geocoder.Geocoder/Geocode",
-			"status":              respCode.String(),
+			"request.max_results":  req.MaxResults,
+			"request.method":       req.Method,
+			"request.Query":        req.GetQuery(),
+			"request.Filter":       req.GetFilter(),
+			"request.max_distance": req.MaxDistanceMeters,
+			"request.dataset":      req.Dataset,
+			"duration":             -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":               "/geocoder.Geocoder/Geocode",
+			"status":               respCode.String(),
 		})
 
 		if respCode == codes.OK {
@@ -220,6 +271,13 @@ func (s *GeocoderServer) Geocode(ctx context.Context, req *pb.GeocodeRequest) (*
 			return nil, status.Errorf(respCode, err.Error())
 		}
 
+	case pb.Method_FWD_INTERPOLATED:
+		addressResults, err = s.Interpolate(ctx, req)
+		if err != nil {
+			handleGeocoderError(err, &respCode)
+			return nil, status.Errorf(respCode, err.Error())
+		}
+
 	case pb.Method_REV_NEAREST:
 		addressResults, err = s.Reverse(ctx, req)
 		if err != nil {
@@ -235,6 +293,131 @@ func (s *GeocoderServer) Geocode(ctx context.Context, req *pb.GeocodeRequest) (*
 	}, nil
 }
 
+// Boundaries - call the GRPC server `/geocoder.Geocoder/Boundaries` method, returns all boundaries (e.g. borough,
+// census tract) containing the request's point
+func (s *GeocoderServer) Boundaries(ctx context.Context, req *pb.BoundariesRequest) (*pb.BoundariesResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var boundaries []*pb.Boundary
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.point":  req.GetPoint(),
+			"request.layers": req.GetLayers(),
+			"duration":       -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":         "/geocoder.Geocoder/Boundaries",
+			"status":         respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("boundaries request successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("boundaries request failed")
+	}()
+
+	pt := req.GetPoint()
+	if (pt == nil) || math.Abs(float64(pt.Latitude)) > 90 || math.Abs(float64(pt.Longitude)) > 180 {
+		err = srv.ErrMalformedRedisQuery
+		respCode = codes.InvalidArgument
+		return nil, status.Errorf(respCode, err.Error())
+	}
+
+	layers := make([]string, len(req.Layers))
+	for i, l := range req.Layers {
+		layers[i] = srv.NormalizeBoundaryLayer(l)
+	}
+
+	boundaries, err = s.backend.SearchBoundaries(ctx, &srv.BoundaryQuery{
+		Point:  pt,
+		Layers: layers,
+	})
+	if err != nil {
+		handleGeocoderError(err, &respCode)
+		return nil, status.Errorf(respCode, err.Error())
+	}
+
+	return &pb.BoundariesResponse{
+		Boundaries: boundaries,
+		Point:      pt,
+	}, nil
+}
+
+// Suggest - call the GRPC server `/geocoder.Geocoder/Suggest` method, returns completions of a partial address
+func (s *GeocoderServer) Suggest(ctx context.Context, req *pb.SuggestRequest) (*pb.SuggestResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var suggestions []*pb.Suggestion
+
+	// normalize w. the same rules applied at ingest, except for the (possibly partial) last word
+	prefix := srv.NormalizeAddressPrefix(req.Prefix)
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.prefix":      prefix,
+			"request.max_results": req.MaxResults,
+			"request.fuzzy":       req.Fuzzy,
+			"request.dataset":     req.Dataset,
+			"duration":            -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":              "/geocoder.Geocoder/Suggest",
+			"status":              respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("suggest request successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("suggest request failed")
+	}()
+
+	if strings.TrimSpace(prefix) == "" {
+		err = srv.ErrMalformedRedisQuery
+		respCode = codes.InvalidArgument
+		return nil, status.Errorf(respCode, err.Error())
+	}
+
+	var limit = int(req.MaxResults)
+	if limit == 0 {
+		limit = serverDefaultSuggestions
+	} else if limit > 1024 {
+		err = srv.ErrMaxResultsOutofRange
+		respCode = codes.InvalidArgument
+		return nil, status.Errorf(respCode, err.Error())
+	}
+
+	dataset, err := s.resolveDataset(ctx, req.Dataset)
+	if err != nil {
+		handleGeocoderError(err, &respCode)
+		return nil, status.Errorf(respCode, err.Error())
+	}
+
+	suggestions, err = s.backend.Suggest(ctx, &srv.SuggestQuery{
+		Dataset: dataset,
+		Prefix:  prefix,
+		Fuzzy:   req.Fuzzy,
+		Limit:   limit,
+	})
+	if err != nil {
+		handleGeocoderError(err, &respCode)
+		return nil, status.Errorf(respCode, err.Error())
+	}
+
+	return &pb.SuggestResponse{
+		Suggestions: suggestions,
+		Prefix:      prefix,
+	}, nil
+}
+
 // RouteChat receives a stream of message/location pairs, and responds with a stream of all
 // previous messages at each of those locations.
 func (s *GeocoderServer) GeocodeBatch(stream pb.Geocoder_GeocodeBatchServer) error {
@@ -272,7 +455,7 @@ func (s *GeocoderServer) GeocodeBatch(stream pb.Geocoder_GeocodeBatchServer) err
 		// read stream in...
 		req, err := stream.Recv()
 
-		log.Debug("bidi Alive %+v", req)
+		log.Debugf("bidi Alive %+v", req)
 
 		if err == io.EOF {
 			log.Errorf("bidi exit on EOF +%v", err)
@@ -286,6 +469,11 @@ func (s *GeocoderServer) GeocodeBatch(stream pb.Geocoder_GeocodeBatchServer) err
 
 		resp, err := s.Geocode(ctx, req)
 
+		// an unknown dataset fails every request in the stream the same way -> end the stream
+		if status.Code(err) == codes.NotFound {
+			return err
+		}
+
 		// send responses back
 		if err := stream.Send(resp); err != nil {
 			log.Errorf("bidi failed on send +%v", err)
@@ -294,27 +482,13 @@ func (s *GeocoderServer) GeocodeBatch(stream pb.Geocoder_GeocodeBatchServer) err
 	}
 }
 
-// indexesReady - creates || checks the existence an index required for the application
-// Indexes the 'location' and `composite_street_address` fields of all hashes starting w. `address:*`
-// and elects for LOW MEMORY options where possible.
+// indexesReady - creates || checks the existence of the indexes required for the application, see
+// `SearchBackend.IndexesReady` for the details of each backend
 func (s *GeocoderServer) indexesReady() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	_, err := s.client.Do(
-		ctx, "FT.CREATE", "addr-idx", "ON", "HASH", "PREFIX", "1", "address:", "NOHL", "NOOFFSETS",
-		"LANGUAGE", "english", "SCHEMA", "location", "GEO", "composite_street_address", "TEXT", "SORTABLE",
-	).Result()
-
-	if err != nil {
-		// expected error -> will throw on all server starts after the first unless db wiped
-		if err.Error() == "Index already exists" {
-			log.WithFields(log.Fields{
-				"err": err.Error(),
-			}).Warn("failed to create FT index on server init")
-			return nil
-		}
-
+	if err := s.backend.IndexesReady(ctx); err != nil {
 		// on uncrecoverable error - e.g. failed connection to DB; throw error
 		log.WithFields(log.Fields{
 			"err": err.Error(),
@@ -334,13 +508,18 @@ func main() {
 
 	// init geocoder server object
 	geocoderServer := &GeocoderServer{
-		client: srv.MustRedisClient(
+		backend: srv.MustSearchBackend(
 			context.Background(),
+			*searchBackend,
 			&srv.RedisClientOptions{
 				DB:   *redisDB,
 				Host: *redisHost,
 				Port: *redisPort,
 			},
+			&srv.MemorySnapshotOptions{
+				Path:   *memorySnapshot,
+				Writer: false,
+			},
 		),
 	}
 
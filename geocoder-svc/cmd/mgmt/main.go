@@ -5,7 +5,6 @@ import (
 	// standard lib
 	"context"
 	"flag"
-	"fmt"
 	"io"
 	"time"
 
@@ -14,11 +13,12 @@ import (
 	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
 
 	// external
-	redis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -30,34 +30,1144 @@ var (
 	redisHost = flag.String("redis-host", "search", "host of the redis server to use as a FT engine")
 	redisPort = flag.Int("redis-port", 6379, "host of the redis server to use as a FT engine")
 	redisDB   = flag.Int("redis-db", 0, "db of the redis server to use as a FT engine")
+
+	// search options
+	searchBackend  = flag.String("search-backend", srv.SearchBackendRedis, "search backend, one of (`redis`, `memory`); `memory` holds all addresses in-process")
+	memorySnapshot = flag.String("memory-snapshot", "", "(optional) w. `--search-backend memory`, save all data to this snapshot file after each change so the geocoder (w. the same `--memory-snapshot`) can serve it")
+
+	// ingest options
+	datasetBounds = flag.String("dataset-bounds", "", "(optional) reject addresses outside of a dataset's bounding box, as `dataset=min_lat,min_lng,max_lat,max_lng;...`")
 )
 
-// ManagementServer Specific Constants //
-const (
+// ManagementServer Specific Constants //
+const (
+
+	// serverMaxQueuedTransactions - during `InsertorReplaceAddressData`, maximum number of queued addresses to
+	// allow before calling SearchBackend.Upsert()
+	serverMaxQueuedTransactions = 1024
+
+	// serverMaxQueuedBoundaries - during `InsertorReplaceBoundaryData`, maximum number of queued boundaries to
+	// allow before calling SearchBackend.UpsertBoundaries()
+	serverMaxQueuedBoundaries = 64
+
+	// serverMinPromotedCountRatio - during `PromoteDatasetVersion`, the min ratio of the new version's address count
+	// to the served version's count; a reload that lost most of its rows is almost always a bad input file
+	serverMinPromotedCountRatio = 0.9
+
+	// serverMaxRejectedSamples - during `InsertorReplaceAddressData`, max number of rejected addresses (w. the
+	// reason) returned in the response; all rejected addresses are counted
+	serverMaxRejectedSamples = 100
+
+	// serverVersionJobMaxDuration - max duration of `CreateDatasetVersion`, `PromoteDatasetVersion` &&
+	// `DropDatasetVersion`, dropping a version deletes each of its addresses
+	serverVersionJobMaxDuration = time.Second * 60
+
+	// serverInsertionJobMaxDuration - during `InsertorReplaceAddressData`, the max duration the server will allow a
+	// connection to insert data to redis; loads that may take longer should use an ingest job (see `IngestAddresses`)
+	serverInsertionJobMaxDuration = time.Second * 180
+
+	// serverIngestChunkMaxDuration - during `IngestAddresses`, the max duration of writing a single chunk; the stream
+	// itself has no deadline
+	serverIngestChunkMaxDuration = time.Second * 30
+)
+
+// ManagementServer - server for management api
+type ManagementServer struct {
+	pb.UnimplementedManagementServer
+	backend       srv.SearchBackend
+	datasetBounds map[string]*pb.BoundingBox // dataset name -> bounding box all of its addresses must be within
+}
+
+// streamDataset - resolves the dataset of a message in an ingest stream; the first message names the dataset of
+// the whole stream (created on demand) && all later messages must name the same dataset
+func (s *ManagementServer) streamDataset(ctx context.Context, current *string, requested string) error {
+	first, err := parseStreamDataset(current, requested)
+	if (err != nil) || !first {
+		return err
+	}
+
+	// creating a dataset is idempotent && also upgrades the indexes of an existing dataset
+	return s.backend.CreateDataset(ctx, *current)
+}
+
+// liveStreamDataset - same as `streamDataset`, for streams that write to the version of a dataset being served;
+// the first message fails while a new version is loading. Writes to the live version are lost once the new version
+// is promoted, && writes to the new version could be dropped by its source || overwritten as it's loaded, so they're
+// rejected until it's promoted
+func (s *ManagementServer) liveStreamDataset(ctx context.Context, current *string, requested string) error {
+	first := *current == ""
+	if err := s.streamDataset(ctx, current, requested); (err != nil) || !first {
+		return err
+	}
+
+	pending, err := s.backend.HasPendingVersion(ctx, *current)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return srv.ErrDatasetVersionPending
+	}
+	return nil
+}
+
+// parseStreamDataset - same as `streamDataset` w.o. creating the dataset, returns true for the first message
+func parseStreamDataset(current *string, requested string) (bool, error) {
+	dataset, err := srv.ParseDataset(requested)
+	if err != nil {
+		return false, err
+	}
+
+	if *current != "" {
+		if dataset != *current {
+			return false, srv.ErrDatasetMismatch
+		}
+		return false, nil
+	}
+
+	*current = dataset
+	return true, nil
+}
+
+// handleDatasetError - sets the response code for an error returned by `streamDataset` or a dataset version
+func handleDatasetError(err error, rc *codes.Code) {
+	switch err {
+	case srv.ErrInvalidDataset, srv.ErrDatasetMismatch, srv.ErrInvalidGeocodeFilter, srv.ErrIngestJobMismatch:
+		*rc = codes.InvalidArgument
+	case srv.ErrDatasetNotFound, srv.ErrDatasetVersionNotFound, srv.ErrAddressNotFound, srv.ErrIngestJobNotFound:
+		*rc = codes.NotFound
+	case srv.ErrDatasetVersionEmpty, srv.ErrDatasetVersionCountMismatch, srv.ErrDatasetVersionShrunk,
+		srv.ErrDatasetVersionPending, srv.ErrDatasetVersionLive, srv.ErrIngestChunkOutOfOrder:
+		*rc = codes.FailedPrecondition
+	default:
+		*rc = codes.Internal
+	}
+}
+
+// CreateDatasetVersion - creates an empty version of a dataset's addresses (&& the dataset, if needed) for a
+// reload; load it w. `InsertorReplaceAddressData` (w. `Address.version` set) && serve it w. `PromoteDatasetVersion`
+func (s *ManagementServer) CreateDatasetVersion(ctx context.Context, req *pb.CreateDatasetVersionRequest) (*pb.DatasetVersionResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var dataset string
+	var version int64
+
+	ctx, cancel := context.WithTimeout(ctx, serverVersionJobMaxDuration)
+	defer cancel()
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.dataset": req.Dataset,
+			"version":         version,
+			"duration":        -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":          "/geocoder.Management/CreateDatasetVersion",
+			"status":          respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("create dataset version successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("create dataset version failed")
+	}()
+
+	if err = s.streamDataset(ctx, &dataset, req.Dataset); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	if version, err = s.backend.CreateVersion(ctx, dataset); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	return &pb.DatasetVersionResponse{
+		Dataset: dataset,
+		Version: version,
+	}, nil
+}
+
+// PromoteDatasetVersion - validates the address count of a version && atomically serves it in place of the current
+// version, then deletes every other version of the dataset; a failed validation leaves the served version as-is
+func (s *ManagementServer) PromoteDatasetVersion(ctx context.Context, req *pb.PromoteDatasetVersionRequest) (*pb.DatasetVersionResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var resp = &pb.DatasetVersionResponse{Version: req.Version}
+
+	ctx, cancel := context.WithTimeout(ctx, serverVersionJobMaxDuration)
+	defer cancel()
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.dataset":        req.Dataset,
+			"request.version":        req.Version,
+			"request.expected_count": req.ExpectedCount,
+			"num_addresses":          resp.NumAddresses,
+			"previous_num_addresses": resp.PreviousNumAddresses,
+			"num_versions_dropped":   resp.NumVersionsDropped,
+			"duration":               -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":                 "/geocoder.Management/PromoteDatasetVersion",
+			"status":                 respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("promote dataset version successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("promote dataset version failed")
+	}()
+
+	if resp.Dataset, err = srv.ParseDataset(req.Dataset); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	// validate - the version must exist && have a plausible number of addresses
+	if req.Version == srv.LiveVersion {
+		err = srv.ErrDatasetVersionNotFound
+	} else {
+		err = s.validateVersion(ctx, req, resp)
+	}
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	if err = s.backend.PromoteVersion(ctx, resp.Dataset, req.Version); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	// the new version is already served -> a failure to clean up is logged, not returned
+	n, gcErr := s.backend.DropStaleVersions(ctx, resp.Dataset)
+	if gcErr != nil {
+		log.WithFields(log.Fields{
+			"dataset": resp.Dataset,
+			"err":     gcErr.Error(),
+		}).Warn("failed to drop stale dataset versions")
+	}
+	resp.NumVersionsDropped = int32(n)
+	return resp, nil
+}
+
+// DropDatasetVersion - deletes an unserved version of a dataset's addresses, e.g. one left by a reload that failed
+// before it was promoted; live changes are refused while it exists (see `liveStreamDataset`)
+func (s *ManagementServer) DropDatasetVersion(ctx context.Context, req *pb.DropDatasetVersionRequest) (*pb.DatasetVersionResponse, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var resp = &pb.DatasetVersionResponse{Version: req.Version}
+
+	ctx, cancel := context.WithTimeout(ctx, serverVersionJobMaxDuration)
+	defer cancel()
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.dataset": req.Dataset,
+			"request.version": req.Version,
+			"num_addresses":   resp.NumAddresses,
+			"duration":        -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":          "/geocoder.Management/DropDatasetVersion",
+			"status":          respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("drop dataset version successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("drop dataset version failed")
+	}()
+
+	if resp.Dataset, err = srv.ParseDataset(req.Dataset); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	// the version being served is never dropped, even when named by its number
+	if req.Version == srv.LiveVersion {
+		err = srv.ErrDatasetVersionLive
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	n, err := s.backend.CountAddresses(ctx, resp.Dataset, req.Version)
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+	resp.NumAddresses = int32(n)
+
+	if err = s.backend.DropVersion(ctx, resp.Dataset, req.Version); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+	resp.NumVersionsDropped = 1
+	return resp, nil
+}
+
+// validateVersion - checks a version isn't empty, has `expected_count` addresses (if set) && hasn't shrunk
+// relative to the version being served (unless allowed); sets the counts on `resp`
+func (s *ManagementServer) validateVersion(ctx context.Context, req *pb.PromoteDatasetVersionRequest, resp *pb.DatasetVersionResponse) error {
+	n, err := s.backend.CountAddresses(ctx, resp.Dataset, req.Version)
+	if err != nil {
+		return err
+	}
+	prev, err := s.backend.CountAddresses(ctx, resp.Dataset, srv.LiveVersion)
+	if err != nil {
+		return err
+	}
+	resp.NumAddresses, resp.PreviousNumAddresses = int32(n), int32(prev)
+
+	switch {
+	case n == 0:
+		return srv.ErrDatasetVersionEmpty
+	case (req.ExpectedCount > 0) && (n != int(req.ExpectedCount)):
+		return srv.ErrDatasetVersionCountMismatch
+	case !req.AllowShrink && (float64(n) < serverMinPromotedCountRatio*float64(prev)):
+		return srv.ErrDatasetVersionShrunk
+	}
+	return nil
+}
+
+// lookupDataset - parses the dataset of a read-only request, unlike `streamDataset` a missing dataset isn't created
+func (s *ManagementServer) lookupDataset(ctx context.Context, requested string) (string, error) {
+	dataset, err := srv.ParseDataset(requested)
+	if err != nil {
+		return "", err
+	}
+
+	ok, err := s.backend.DatasetExists(ctx, dataset)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", srv.ErrDatasetNotFound
+	}
+	return dataset, nil
+}
+
+// GetDatasetStats - size, index memory, last ingest time && extent of the version of a dataset being served
+func (s *ManagementServer) GetDatasetStats(ctx context.Context, req *pb.DatasetStatsRequest) (*pb.DatasetStats, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var stats *pb.DatasetStats
+
+	ctx, cancel := context.WithTimeout(ctx, serverVersionJobMaxDuration)
+	defer cancel()
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.dataset": req.Dataset,
+			"version":         stats.GetVersion(),
+			"num_addresses":   stats.GetNumAddresses(),
+			"duration":        -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":          "/geocoder.Management/GetDatasetStats",
+			"status":          respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("get dataset stats successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("get dataset stats failed")
+	}()
+
+	dataset, err := s.lookupDataset(ctx, req.Dataset)
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	if stats, err = s.backend.Stats(ctx, dataset); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+	return stats, nil
+}
+
+// GetAddress - a single address from the version of a dataset being served
+func (s *ManagementServer) GetAddress(ctx context.Context, req *pb.GetAddressRequest) (*pb.Address, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.dataset": req.Dataset,
+			"request.id":      req.Id,
+			"duration":        -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":          "/geocoder.Management/GetAddress",
+			"status":          respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("get address successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("get address failed")
+	}()
+
+	dataset, err := s.lookupDataset(ctx, req.Dataset)
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	address, err := s.backend.GetAddress(ctx, dataset, srv.ParseAddressID(dataset, req.Id))
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+	return address, nil
+}
+
+// ExportAddresses - streams every address of the version of a dataset being served that matches the request's
+// filters, e.g. to audit an environment || copy a dataset to another environment; addresses are sent at-least-once
+// (see `SearchBackend.Export`)
+func (s *ManagementServer) ExportAddresses(req *pb.ExportAddressesRequest, stream pb.Management_ExportAddressesServer) (err error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var totalObjectsSent int   // total addresses sent on the stream
+
+	ctx := stream.Context()
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.dataset":         req.Dataset,
+			"request.id_prefix":       req.IdPrefix,
+			"stream.totalObjectsSent": totalObjectsSent,
+			"duration":                -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":                  "/geocoder.Management/ExportAddresses",
+			"status":                  respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("export job successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("export job failed")
+	}()
+
+	dataset, err := s.lookupDataset(ctx, req.Dataset)
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return status.Error(respCode, err.Error())
+	}
+
+	// only the bounding box of a filter applies to exports
+	filter, err := srv.ParseGeocodeFilter(&pb.GeocodeFilter{BoundingBox: req.BoundingBox})
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return status.Error(respCode, err.Error())
+	}
+
+	err = s.backend.Export(ctx, &srv.ExportQuery{
+		Dataset:  dataset,
+		IDPrefix: req.IdPrefix,
+		Filter:   filter,
+	}, func(address *pb.Address) error {
+		if err := stream.Send(address); err != nil {
+			return err
+		}
+		totalObjectsSent++
+		return nil
+	})
+
+	if err != nil {
+		if ctx.Err() != nil {
+			respCode = status.FromContextError(ctx.Err()).Code()
+			return status.Error(respCode, ctx.Err().Error())
+		}
+		handleDatasetError(err, &respCode)
+		return status.Error(respCode, err.Error())
+	}
+	return nil
+}
+
+// CreateIngestJob - creates a resumable load of addresses into a dataset (created if needed) && version, the
+// addresses are sent w. `IngestAddresses`
+func (s *ManagementServer) CreateIngestJob(ctx context.Context, req *pb.CreateIngestJobRequest) (*pb.IngestJob, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var job = &pb.IngestJob{
+		JobId:      uuid.New().String(),
+		Version:    req.Version,
+		Source:     req.Source,
+		CreateTime: timestamppb.New(startTime),
+		UpdateTime: timestamppb.New(startTime),
+	}
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.dataset": req.Dataset,
+			"request.version": req.Version,
+			"request.source":  req.Source,
+			"job_id":          job.JobId,
+			"duration":        -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":          "/geocoder.Management/CreateIngestJob",
+			"status":          respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("create ingest job successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("create ingest job failed")
+	}()
+
+	if job.Version == srv.LiveVersion {
+		err = s.liveStreamDataset(ctx, &job.Dataset, req.Dataset)
+	} else {
+		err = s.streamDataset(ctx, &job.Dataset, req.Dataset)
+	}
+	if err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	// fail early on a version that doesn't exist rather than on the first chunk
+	if _, err = s.backend.CountAddresses(ctx, job.Dataset, job.Version); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+
+	if err = s.backend.SaveIngestJob(ctx, job); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+	return job, nil
+}
+
+// GetIngestJob - the progress of an ingest job, a restarted client resumes from `committed_offset`
+func (s *ManagementServer) GetIngestJob(ctx context.Context, req *pb.GetIngestJobRequest) (*pb.IngestJob, error) {
+
+	var startTime = time.Now() // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK    // status code
+	var err error
+	var job *pb.IngestJob
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"request.job_id":   req.JobId,
+			"committed_offset": job.GetCommittedOffset(),
+			"duration":         -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":           "/geocoder.Management/GetIngestJob",
+			"status":           respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("get ingest job successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("get ingest job failed")
+	}()
+
+	if job, err = s.backend.GetIngestJob(ctx, req.JobId); err != nil {
+		handleDatasetError(err, &respCode)
+		return nil, status.Error(respCode, err.Error())
+	}
+	return job, nil
+}
+
+// IngestAddresses - writes the chunks of an ingest job in order && acknowledges each chunk once it's committed; a
+// chunk that overlaps the committed offset (e.g. resent after a dropped connection) only writes the addresses past
+// it, so a job is loaded at-least-once && each address is written once per chunk it's committed in
+//
+// WARN: duplicate ids are only rejected within a single stream, && only one stream should write to a job at a time
+func (s *ManagementServer) IngestAddresses(stream pb.Management_IngestAddressesServer) (err error) {
+
+	var startTime = time.Now()          // call on entry as proxy for use w. cobbled-together request logger
+	var respCode = codes.OK             // status code
+	var job *pb.IngestJob               // job of all chunks in the stream, set by the first chunk
+	var validator *srv.AddressValidator // validates each address, created once the job is known
+	var numChunks int                   // total chunks acknowledged
+	var totalObjectsWritten int         // total addresses written in this stream
+
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"stream.jobId":               job.GetJobId(),
+			"stream.dataset":             job.GetDataset(),
+			"stream.version":             job.GetVersion(),
+			"stream.committedOffset":     job.GetCommittedOffset(),
+			"stream.numChunks":           numChunks,
+			"stream.totalObjectsWritten": totalObjectsWritten,
+			"duration":                   -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":                     "/geocoder.Management/IngestAddresses",
+			"status":                     respCode.String(),
+		})
+
+		if respCode == codes.OK {
+			reqLogger.Info("ingest job stream successful")
+			return
+		}
+
+		reqLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("ingest job stream failed")
+	}()
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			respCode = codes.Internal
+			return status.Error(respCode, err.Error())
+		}
+
+		if job == nil {
+			if job, err = s.backend.GetIngestJob(stream.Context(), chunk.JobId); err != nil {
+				handleDatasetError(err, &respCode)
+				return status.Error(respCode, err.Error())
+			}
+			validator = srv.NewAddressValidator(s.datasetBounds[job.Dataset], s.backend.IndexableBounds())
+		} else if chunk.JobId != job.JobId {
+			respCode = codes.InvalidArgument
+			return status.Error(respCode, srv.ErrIngestJobMismatch.Error())
+		}
+
+		ack, err := s.ingestChunk(stream.Context(), job, validator, chunk)
+		if err != nil {
+			if stream.Context().Err() != nil {
+				respCode = status.FromContextError(stream.Context().Err()).Code()
+				return status.Error(respCode, stream.Context().Err().Error())
+			}
+			handleDatasetError(err, &respCode)
+			return status.Error(respCode, err.Error())
+		}
+		totalObjectsWritten += int(ack.TotalObjectsWritten)
+
+		if err := stream.Send(ack); err != nil {
+			respCode = codes.Internal
+			return status.Error(respCode, err.Error())
+		}
+		numChunks++
+	}
+}
+
+// ingestChunk - validates && writes the uncommitted addresses of a chunk, then advances the job's committed offset
+func (s *ManagementServer) ingestChunk(ctx context.Context, job *pb.IngestJob, validator *srv.AddressValidator, chunk *pb.IngestChunk) (*pb.IngestAck, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, serverIngestChunkMaxDuration)
+	defer cancel()
+
+	if chunk.Offset > job.CommittedOffset {
+		return nil, srv.ErrIngestChunkOutOfOrder
+	}
+
+	// a job on the live version can outlive the start of a reload, check each chunk rather than only the first
+	if job.Version == srv.LiveVersion {
+		pending, err := s.backend.HasPendingVersion(ctx, job.Dataset)
+		if err != nil {
+			return nil, err
+		}
+		if pending {
+			return nil, srv.ErrDatasetVersionPending
+		}
+	}
+
+	var ack = &pb.IngestAck{JobId: job.JobId}
+
+	// skip the addresses already committed by an earlier chunk
+	skip := job.CommittedOffset - chunk.Offset
+	if skip >= int64(len(chunk.Addresses)) {
+		ack.CommittedOffset = job.CommittedOffset
+		return ack, nil
+	}
+
+	addresses := make([]*pb.Address, 0, len(chunk.Addresses))
+	for _, address := range chunk.Addresses[skip:] {
+		address.Dataset, address.Version = job.Dataset, job.Version
+
+		// normalize at ingest w. the same rules applied to forward queries, fills in any missing components
+		srv.NormalizeAddressComponents(address)
+
+		if verr := validator.Validate(address); verr != nil {
+			ack.TotalObjectsRejected++
+			if len(ack.RejectedSamples) < serverMaxRejectedSamples {
+				ack.RejectedSamples = append(ack.RejectedSamples, &pb.RejectedObject{Id: address.Id, Reason: verr.Error()})
+			}
+			continue
+		}
+		addresses = append(addresses, address)
+	}
+
+	if len(addresses) > 0 {
+		if err := s.backend.Upsert(ctx, job.Dataset, job.Version, addresses); err != nil {
+			return nil, err
+		}
+	}
+
+	job.CommittedOffset = chunk.Offset + int64(len(chunk.Addresses))
+	job.TotalObjectsRejected += int64(ack.TotalObjectsRejected)
+	job.UpdateTime = timestamppb.Now()
+	if err := s.backend.SaveIngestJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	ack.CommittedOffset = job.CommittedOffset
+	ack.TotalObjectsWritten = int32(len(addresses))
+	return ack, nil
+}
+
+// InsertorReplaceAddressData - call is only used internally for managing the data of an index, all addresses
+// in a stream are written to the dataset && version named by the first address
+func (s *ManagementServer) InsertorReplaceAddressData(stream pb.Management_InsertorReplaceAddressDataServer) (err error) {
+
+	var startTime = time.Now()          // call on entry as proxy for use w. cobbled-together request logger
+	var numQueuedTransactions int       // current number of un-submitted addresses in `queuedAddresses`
+	var jobSuccess bool                 // success flag for insertion request; returned as part of pb.IOResponse
+	var totalObjectsWritten int         // total addresses "committed" to redis; returned as part of pb.IOResponse
+	var respCode = codes.OK             // status code; returned as part of pb.IOResponse
+	var dataset string                  // dataset of all addresses in the stream, set by the first address
+	var version int64                   // version of `dataset` all addresses in the stream are written to
+	var dryRun bool                     // validate w.o. writing, set by the first address
+	var numReceived int                 // total addresses read from the stream, incl. rejected addresses
+	var validator *srv.AddressValidator // validates each address, created once the dataset is known
+	var rejected []*pb.RejectedObject   // the first `serverMaxRejectedSamples` rejected addresses
+	var totalObjectsRejected int        // total addresses that failed validation; returned as part of pb.IOResponse
+
+	ctx, cancel := context.WithTimeout(context.Background(), serverInsertionJobMaxDuration)
+	defer cancel()
+
+	// defer calling a log command w. the request details, blegh...
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"stream.totalObjectsWritten":  totalObjectsWritten,
+			"stream.totalObjectsRejected": totalObjectsRejected,
+			"stream.jobSuccess":           jobSuccess,
+			"stream.dataset":              dataset,
+			"stream.version":              version,
+			"stream.dryRun":               dryRun,
+			"duration":                    -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":                      "/geocoder.Geocoder/InsertorReplaceAddressData",
+			"status":                      respCode.String(),
+		})
+
+		if (err == nil) || (err == io.EOF) {
+			reqLogger.Info("data ingest job successful")
+		} else {
+			reqLogger.WithFields(log.Fields{
+				"err": err,
+			}).Error("data ingest job failed")
+		}
+	}()
+
+	queuedAddresses := make([]*pb.Address, 0, serverMaxQueuedTransactions)
+
+	for {
+		// any non-EOF error from stream processing should throw && exit
+		address, err := stream.Recv()
+
+		if (err != nil) && (err != io.EOF) {
+			log.WithFields(log.Fields{
+				"numTransactions": numQueuedTransactions,
+				"err":             err.Error(),
+			}).Error("failed to read addresses from stream")
+			respCode = codes.Internal
+			return status.Error(respCode, err.Error())
+		}
+
+		// if the buffer is sufficiently full; then reset the buffer && execute the pipe commands
+		if (numQueuedTransactions >= serverMaxQueuedTransactions) || (err == io.EOF) {
+
+			// write all queued addresses to the backend, a dry run only counts them
+			var rerr error
+			if !dryRun {
+				rerr = s.backend.Upsert(ctx, dataset, version, queuedAddresses)
+			}
+			if rerr != nil {
+				log.WithFields(log.Fields{
+					"numTransactions": numQueuedTransactions,
+					"err":             rerr.Error(),
+				}).Error("failed to write transaction pipe")
+
+				if ctx.Err() != nil {
+					respCode = codes.DeadlineExceeded
+					return status.Error(respCode, ctx.Err().Error())
+				}
 
-	// serverMaxQueuedTransactions - during `InsertorReplaceAddressData`, maximum number of pipelined transactions to
-	// allow before calling Pipe.Exec()
-	serverMaxQueuedTransactions = 1024
+				handleDatasetError(rerr, &respCode)
+				return status.Error(respCode, rerr.Error())
+			}
 
-	// serverInsertionJobMaxDuration - during `InsertorReplaceAddressData`, the max duration the server will allow a
-	// connection to insert data to redis
-	serverInsertionJobMaxDuration = time.Second * 180
-)
+			// increment `totalObjectsWritten` counter && reset `numQueuedTransactions`
+			totalObjectsWritten += numQueuedTransactions
+			numQueuedTransactions = 0
+			queuedAddresses = queuedAddresses[:0]
 
-// ManagementServer - server for management api
-type ManagementServer struct {
-	pb.UnimplementedManagementServer
-	client *redis.Client
+			// on successful exit (io.EOF w. no prevailing errors), send an OK back to client
+			if err == io.EOF {
+				jobSuccess = true
+				return stream.SendAndClose(
+					&pb.IOResponse{
+						Success:              jobSuccess,
+						TotalObjectsWritten:  int32(totalObjectsWritten),
+						TotalObjectsUpserted: int32(totalObjectsWritten),
+						TotalObjectsRejected: int32(totalObjectsRejected),
+						RejectedSamples:      rejected,
+						DryRun:               dryRun,
+					})
+			}
+		}
+
+		if numReceived == 0 {
+			version, dryRun = address.Version, address.DryRun
+		} else if address.Version != version {
+			respCode = codes.InvalidArgument
+			return status.Error(respCode, srv.ErrDatasetMismatch.Error())
+		}
+		numReceived++
+
+		// a dry run never creates the dataset, && live writes are refused while a new version is loading
+		var derr error
+		switch {
+		case dryRun:
+			_, derr = parseStreamDataset(&dataset, address.Dataset)
+		case version == srv.LiveVersion:
+			derr = s.liveStreamDataset(ctx, &dataset, address.Dataset)
+		default:
+			derr = s.streamDataset(ctx, &dataset, address.Dataset)
+		}
+		if derr != nil {
+			handleDatasetError(derr, &respCode)
+			return status.Error(respCode, derr.Error())
+		}
+
+		if validator == nil {
+			validator = srv.NewAddressValidator(s.datasetBounds[dataset], s.backend.IndexableBounds())
+		}
+
+		// normalize at ingest w. the same rules applied to forward queries, fills in any missing components
+		srv.NormalizeAddressComponents(address)
+
+		// invalid addresses are skipped && reported in the response rather than failing the stream
+		if verr := validator.Validate(address); verr != nil {
+			totalObjectsRejected++
+			if len(rejected) < serverMaxRejectedSamples {
+				rejected = append(rejected, &pb.RejectedObject{Id: address.Id, Reason: verr.Error()})
+			}
+			continue
+		}
+
+		// while below not full; add address to the buffer
+		if numQueuedTransactions < serverMaxQueuedTransactions {
+			numQueuedTransactions++
+			queuedAddresses = append(queuedAddresses, address)
+		}
+	}
+	return status.Error(codes.Unknown, "unknown code path")
 }
 
-// InsertorReplaceAddressData - call is only used internally for managing the data of an index
-func (s *ManagementServer) InsertorReplaceAddressData(stream pb.Management_InsertorReplaceAddressDataServer) (err error) {
+// DeleteAddresses - call is only used internally for removing addresses (e.g. a demolished building) by `Address.id`
+// from the version of a dataset being served, same buffering && dataset rules as `InsertorReplaceAddressData`; fails
+// w. FailedPrecondition while a new version of the dataset is loading (see `liveStreamDataset`)
+func (s *ManagementServer) DeleteAddresses(stream pb.Management_DeleteAddressesServer) (err error) {
+
+	var startTime = time.Now()   // call on entry as proxy for use w. cobbled-together request logger
+	var jobSuccess bool          // success flag for deletion request; returned as part of pb.IOResponse
+	var totalObjectsDeleted int  // total addresses removed; returned as part of pb.IOResponse
+	var totalObjectsNotFound int // total ids w.o. an address; returned as part of pb.IOResponse
+	var respCode = codes.OK      // status code; returned as part of pb.IOResponse
+	var dataset string           // dataset of all deletions in the stream, set by the first deletion
+
+	ctx, cancel := context.WithTimeout(context.Background(), serverInsertionJobMaxDuration)
+	defer cancel()
+
+	// defer calling a log command w. the request details, blegh...
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"stream.totalObjectsDeleted":  totalObjectsDeleted,
+			"stream.totalObjectsNotFound": totalObjectsNotFound,
+			"stream.jobSuccess":           jobSuccess,
+			"stream.dataset":              dataset,
+			"duration":                    -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":                      "/geocoder.Management/DeleteAddresses",
+			"status":                      respCode.String(),
+		})
+
+		if (err == nil) || (err == io.EOF) {
+			reqLogger.Info("delete job successful")
+		} else {
+			reqLogger.WithFields(log.Fields{
+				"err": err,
+			}).Error("delete job failed")
+		}
+	}()
+
+	queuedIDs := make([]string, 0, serverMaxQueuedTransactions)
+
+	for {
+		// any non-EOF error from stream processing should throw && exit
+		deletion, err := stream.Recv()
+
+		if (err != nil) && (err != io.EOF) {
+			log.WithFields(log.Fields{
+				"numTransactions": len(queuedIDs),
+				"err":             err.Error(),
+			}).Error("failed to read deletions from stream")
+			respCode = codes.Internal
+			return status.Error(respCode, err.Error())
+		}
+
+		// if the buffer is sufficiently full; then reset the buffer && write to the backend
+		if (len(queuedIDs) >= serverMaxQueuedTransactions) || (err == io.EOF) {
+			n, rerr := s.backend.Delete(ctx, dataset, queuedIDs)
+			if rerr != nil {
+				log.WithFields(log.Fields{
+					"numTransactions": len(queuedIDs),
+					"err":             rerr.Error(),
+				}).Error("failed to write transaction pipe")
+
+				if ctx.Err() != nil {
+					respCode = codes.DeadlineExceeded
+					return status.Error(respCode, ctx.Err().Error())
+				}
+
+				handleDatasetError(rerr, &respCode)
+				return status.Error(respCode, rerr.Error())
+			}
+
+			totalObjectsDeleted += n
+			totalObjectsNotFound += len(queuedIDs) - n
+			queuedIDs = queuedIDs[:0]
+
+			// on successful exit (io.EOF w. no prevailing errors), send an OK back to client
+			if err == io.EOF {
+				jobSuccess = true
+				return stream.SendAndClose(
+					&pb.IOResponse{
+						Success:              jobSuccess,
+						TotalObjectsWritten:  int32(totalObjectsDeleted),
+						TotalObjectsDeleted:  int32(totalObjectsDeleted),
+						TotalObjectsNotFound: int32(totalObjectsNotFound),
+					})
+			}
+		}
+
+		if derr := s.liveStreamDataset(ctx, &dataset, deletion.Dataset); derr != nil {
+			handleDatasetError(derr, &respCode)
+			return status.Error(respCode, derr.Error())
+		}
+		queuedIDs = append(queuedIDs, deletion.Id)
+	}
+}
+
+// ApplyAddressChanges - call is only used internally for applying an incremental diff (upserts && deletes keyed by
+// `Address.id`) to the version of a dataset being served, same buffering && dataset rules as
+// `InsertorReplaceAddressData`; changes are applied in the order they're received && fail w. FailedPrecondition while
+// a new version of the dataset is loading (see `liveStreamDataset`)
+func (s *ManagementServer) ApplyAddressChanges(stream pb.Management_ApplyAddressChangesServer) (err error) {
+
+	var startTime = time.Now()        // call on entry as proxy for use w. cobbled-together request logger
+	var jobSuccess bool               // success flag for the request; returned as part of pb.IOResponse
+	var totalObjectsUpserted int      // total addresses inserted || replaced; returned as part of pb.IOResponse
+	var totalObjectsDeleted int       // total addresses removed; returned as part of pb.IOResponse
+	var totalObjectsNotFound int      // total deleted ids w.o. an address; returned as part of pb.IOResponse
+	var respCode = codes.OK           // status code; returned as part of pb.IOResponse
+	var dataset string                // dataset of all changes in the stream, set by the first change
+	var rejected []*pb.RejectedObject // the first `serverMaxRejectedSamples` rejected upserts
+	var totalObjectsRejected int      // total upserts that failed validation; returned as part of pb.IOResponse
+
+	ctx, cancel := context.WithTimeout(context.Background(), serverInsertionJobMaxDuration)
+	defer cancel()
+
+	// defer calling a log command w. the request details, blegh...
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"stream.totalObjectsUpserted": totalObjectsUpserted,
+			"stream.totalObjectsDeleted":  totalObjectsDeleted,
+			"stream.totalObjectsNotFound": totalObjectsNotFound,
+			"stream.totalObjectsRejected": totalObjectsRejected,
+			"stream.jobSuccess":           jobSuccess,
+			"stream.dataset":              dataset,
+			"duration":                    -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":                      "/geocoder.Management/ApplyAddressChanges",
+			"status":                      respCode.String(),
+		})
+
+		if (err == nil) || (err == io.EOF) {
+			reqLogger.Info("change job successful")
+		} else {
+			reqLogger.WithFields(log.Fields{
+				"err": err,
+			}).Error("change job failed")
+		}
+	}()
+
+	queuedChanges := make([]*pb.AddressChange, 0, serverMaxQueuedTransactions)
+
+	for {
+		// any non-EOF error from stream processing should throw && exit
+		change, err := stream.Recv()
+
+		if (err != nil) && (err != io.EOF) {
+			log.WithFields(log.Fields{
+				"numTransactions": len(queuedChanges),
+				"err":             err.Error(),
+			}).Error("failed to read changes from stream")
+			respCode = codes.Internal
+			return status.Error(respCode, err.Error())
+		}
+
+		// if the buffer is sufficiently full; then reset the buffer && write to the backend
+		if (len(queuedChanges) >= serverMaxQueuedTransactions) || (err == io.EOF) {
+			upserted, deleted, rerr := s.applyAddressChanges(ctx, dataset, queuedChanges)
+			totalObjectsUpserted += upserted
+			totalObjectsDeleted += deleted
 
-	var startTime = time.Now()    // call on entry as proxy for use w. cobbled-together request logger
-	var numQueuedTransactions int // current number of un-submitted addresses in redis exec pipeline
-	var jobSuccess bool           // success flag for insertion request; returned as part of pb.IOResponse
-	var totalObjectsWritten int   // total addresses "committed" to redis; returned as part of pb.IOResponse
-	var respCode = codes.OK       // status code; returned as part of pb.IOResponse
+			if rerr != nil {
+				log.WithFields(log.Fields{
+					"numTransactions": len(queuedChanges),
+					"err":             rerr.Error(),
+				}).Error("failed to write transaction pipe")
+
+				if ctx.Err() != nil {
+					respCode = codes.DeadlineExceeded
+					return status.Error(respCode, ctx.Err().Error())
+				}
+
+				handleDatasetError(rerr, &respCode)
+				return status.Error(respCode, rerr.Error())
+			}
+			totalObjectsNotFound += countDeletions(queuedChanges) - deleted
+			queuedChanges = queuedChanges[:0]
+
+			// on successful exit (io.EOF w. no prevailing errors), send an OK back to client
+			if err == io.EOF {
+				jobSuccess = true
+				return stream.SendAndClose(
+					&pb.IOResponse{
+						Success:              jobSuccess,
+						TotalObjectsWritten:  int32(totalObjectsUpserted + totalObjectsDeleted),
+						TotalObjectsUpserted: int32(totalObjectsUpserted),
+						TotalObjectsDeleted:  int32(totalObjectsDeleted),
+						TotalObjectsNotFound: int32(totalObjectsNotFound),
+						TotalObjectsRejected: int32(totalObjectsRejected),
+						RejectedSamples:      rejected,
+					})
+			}
+		}
+
+		var changeDataset string
+		switch {
+		case change.GetUpsert() != nil:
+			// normalize at ingest w. the same rules applied to forward queries, fills in any missing components
+			srv.NormalizeAddressComponents(change.GetUpsert())
+			changeDataset = change.GetUpsert().Dataset
+		case change.GetDelete() != nil:
+			changeDataset = change.GetDelete().Dataset
+		default:
+			respCode = codes.InvalidArgument
+			return status.Error(respCode, srv.ErrInvalidAddressChange.Error())
+		}
+
+		if derr := s.liveStreamDataset(ctx, &dataset, changeDataset); derr != nil {
+			handleDatasetError(derr, &respCode)
+			return status.Error(respCode, derr.Error())
+		}
+
+		// same rules as `InsertorReplaceAddressData`, except an id may be changed more than once in a stream
+		if upsert := change.GetUpsert(); upsert != nil {
+			if verr := srv.ValidateAddress(upsert, s.datasetBounds[dataset], s.backend.IndexableBounds()); verr != nil {
+				totalObjectsRejected++
+				if len(rejected) < serverMaxRejectedSamples {
+					rejected = append(rejected, &pb.RejectedObject{Id: upsert.Id, Reason: verr.Error()})
+				}
+				continue
+			}
+		}
+		queuedChanges = append(queuedChanges, change)
+	}
+}
+
+// applyAddressChanges - applies a batch of changes in order, each run of consecutive upserts (or deletes) is
+// written in a single call to the backend; returns the number of addresses upserted && deleted before any error
+func (s *ManagementServer) applyAddressChanges(ctx context.Context, dataset string, changes []*pb.AddressChange) (int, int, error) {
+	var upserted, deleted int
+	for i := 0; i < len(changes); {
+		j := i
+
+		if changes[i].GetUpsert() != nil {
+			var addresses []*pb.Address
+			for ; (j < len(changes)) && (changes[j].GetUpsert() != nil); j++ {
+				addresses = append(addresses, changes[j].GetUpsert())
+			}
+			if err := s.backend.Upsert(ctx, dataset, srv.LiveVersion, addresses); err != nil {
+				return upserted, deleted, err
+			}
+			upserted += len(addresses)
+		} else {
+			var ids []string
+			for ; (j < len(changes)) && (changes[j].GetDelete() != nil); j++ {
+				ids = append(ids, changes[j].GetDelete().Id)
+			}
+			n, err := s.backend.Delete(ctx, dataset, ids)
+			if err != nil {
+				return upserted, deleted, err
+			}
+			deleted += n
+		}
+		i = j
+	}
+	return upserted, deleted, nil
+}
+
+// countDeletions - the number of deletes in a batch of changes
+func countDeletions(changes []*pb.AddressChange) int {
+	var n int
+	for _, c := range changes {
+		if c.GetDelete() != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// InsertorReplaceStreetSegmentData - call is only used internally for managing the street segments used for
+// interpolation, same buffering && dataset rules as `InsertorReplaceAddressData`
+func (s *ManagementServer) InsertorReplaceStreetSegmentData(stream pb.Management_InsertorReplaceStreetSegmentDataServer) (err error) {
+
+	var startTime = time.Now()  // call on entry as proxy for use w. cobbled-together request logger
+	var jobSuccess bool         // success flag for insertion request; returned as part of pb.IOResponse
+	var totalObjectsWritten int // total segments "committed" to redis; returned as part of pb.IOResponse
+	var respCode = codes.OK     // status code; returned as part of pb.IOResponse
+	var dataset string          // dataset of all segments in the stream, set by the first segment
 
 	ctx, cancel := context.WithTimeout(context.Background(), serverInsertionJobMaxDuration)
 	defer cancel()
@@ -67,43 +1177,41 @@ func (s *ManagementServer) InsertorReplaceAddressData(stream pb.Management_Inser
 		reqLogger := log.WithFields(log.Fields{
 			"stream.totalObjectsWritten": totalObjectsWritten,
 			"stream.jobSuccess":          jobSuccess,
+			"stream.dataset":             dataset,
 			"duration":                   -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
-			"method":                     "/geocoder.Geocoder/InsertorReplaceAddressData",
+			"method":                     "/geocoder.Management/InsertorReplaceStreetSegmentData",
 			"status":                     respCode.String(),
 		})
 
 		if (err == nil) || (err == io.EOF) {
-			reqLogger.Info("data ingest job successful")
+			reqLogger.Info("segment ingest job successful")
 		} else {
 			reqLogger.WithFields(log.Fields{
 				"err": err,
-			}).Error("data ingest job failed")
+			}).Error("segment ingest job failed")
 		}
 	}()
 
-	pipe := s.client.TxPipeline()
+	queuedSegments := make([]*pb.StreetSegment, 0, serverMaxQueuedTransactions)
 
 	for {
 		// any non-EOF error from stream processing should throw && exit
-		address, err := stream.Recv()
+		segment, err := stream.Recv()
 
 		if (err != nil) && (err != io.EOF) {
 			log.WithFields(log.Fields{
-				"numTransactions": numQueuedTransactions,
+				"numTransactions": len(queuedSegments),
 				"err":             err.Error(),
-			}).Error("failed to read addresses from stream")
+			}).Error("failed to read segments from stream")
 			respCode = codes.Internal
 			return status.Error(respCode, err.Error())
 		}
 
-		// if the buffer is sufficiently full; then reset the buffer && execute the pipe commands
-		if (numQueuedTransactions >= serverMaxQueuedTransactions) || (err == io.EOF) {
-
-			// execute pipeline
-			_, rerr := pipe.Exec(ctx)
-			if rerr != nil {
+		// if the buffer is sufficiently full; then reset the buffer && write to the backend
+		if (len(queuedSegments) >= serverMaxQueuedTransactions) || (err == io.EOF) {
+			if rerr := s.backend.UpsertSegments(ctx, dataset, queuedSegments); rerr != nil {
 				log.WithFields(log.Fields{
-					"numTransactions": numQueuedTransactions,
+					"numTransactions": len(queuedSegments),
 					"err":             rerr.Error(),
 				}).Error("failed to write transaction pipe")
 
@@ -116,9 +1224,8 @@ func (s *ManagementServer) InsertorReplaceAddressData(stream pb.Management_Inser
 				return status.Error(respCode, rerr.Error())
 			}
 
-			// increment `totalObjectsWritten` counter && reset `numQueuedTransactions`
-			totalObjectsWritten += numQueuedTransactions
-			numQueuedTransactions = 0
+			totalObjectsWritten += len(queuedSegments)
+			queuedSegments = make([]*pb.StreetSegment, 0, serverMaxQueuedTransactions)
 
 			// on successful exit (io.EOF w. no prevailing errors), send an OK back to client
 			if err == io.EOF {
@@ -131,18 +1238,107 @@ func (s *ManagementServer) InsertorReplaceAddressData(stream pb.Management_Inser
 			}
 		}
 
-		// while below not full; add cmd to transaction pipeline buffer
-		if numQueuedTransactions < serverMaxQueuedTransactions {
-			numQueuedTransactions++
+		if derr := s.streamDataset(ctx, &dataset, segment.Dataset); derr != nil {
+			handleDatasetError(derr, &respCode)
+			return status.Error(respCode, derr.Error())
+		}
+
+		// normalize at ingest w. the same rules applied to addresses
+		srv.NormalizeStreetSegment(segment)
+		queuedSegments = append(queuedSegments, segment)
+	}
+}
+
+// InsertorReplaceBoundaryData - call is only used internally for managing the boundaries used by
+// `/geocoder.Geocoder/Boundaries`; a boundary w. an invalid geometry fails the whole stream
+func (s *ManagementServer) InsertorReplaceBoundaryData(stream pb.Management_InsertorReplaceBoundaryDataServer) (err error) {
+
+	var startTime = time.Now()  // call on entry as proxy for use w. cobbled-together request logger
+	var jobSuccess bool         // success flag for insertion request; returned as part of pb.IOResponse
+	var totalObjectsWritten int // total boundaries "committed" to redis; returned as part of pb.IOResponse
+	var respCode = codes.OK     // status code; returned as part of pb.IOResponse
+
+	ctx, cancel := context.WithTimeout(context.Background(), serverInsertionJobMaxDuration)
+	defer cancel()
+
+	// defer calling a log command w. the request details, blegh...
+	defer func() {
+		reqLogger := log.WithFields(log.Fields{
+			"stream.totalObjectsWritten": totalObjectsWritten,
+			"stream.jobSuccess":          jobSuccess,
+			"duration":                   -1 * float64(startTime.Sub(time.Now()).Microseconds()) / float64(1000),
+			"method":                     "/geocoder.Management/InsertorReplaceBoundaryData",
+			"status":                     respCode.String(),
+		})
+
+		if (err == nil) || (err == io.EOF) {
+			reqLogger.Info("boundary ingest job successful")
+		} else {
+			reqLogger.WithFields(log.Fields{
+				"err": err,
+			}).Error("boundary ingest job failed")
+		}
+	}()
+
+	// note: boundaries are large (10s - 100s of KB) && few, use a smaller buffer than addresses
+	queuedBoundaries := make([]*pb.Boundary, 0, serverMaxQueuedBoundaries)
+
+	for {
+		// any non-EOF error from stream processing should throw && exit
+		boundary, err := stream.Recv()
+
+		if (err != nil) && (err != io.EOF) {
+			log.WithFields(log.Fields{
+				"numTransactions": len(queuedBoundaries),
+				"err":             err.Error(),
+			}).Error("failed to read boundaries from stream")
+			respCode = codes.Internal
+			return status.Error(respCode, err.Error())
+		}
+
+		// if the buffer is sufficiently full; then reset the buffer && write to the backend
+		if (len(queuedBoundaries) >= serverMaxQueuedBoundaries) || (err == io.EOF) {
+			if rerr := s.backend.UpsertBoundaries(ctx, queuedBoundaries); rerr != nil {
+				log.WithFields(log.Fields{
+					"numTransactions": len(queuedBoundaries),
+					"err":             rerr.Error(),
+				}).Error("failed to write transaction pipe")
+
+				if ctx.Err() != nil {
+					respCode = codes.DeadlineExceeded
+					return status.Error(respCode, ctx.Err().Error())
+				}
+
+				respCode = codes.Internal
+				return status.Error(respCode, rerr.Error())
+			}
+
+			totalObjectsWritten += len(queuedBoundaries)
+			queuedBoundaries = make([]*pb.Boundary, 0, serverMaxQueuedBoundaries)
 
-			// note: Redis uses Long, Lat...
-			pipe.Do(ctx, "HSET", fmt.Sprintf("address:%s", address.Id),
-				"location", fmt.Sprintf("%.6f, %.6f", address.Location.Latitude, address.Location.Longitude),
-				"composite_street_address", address.CompositeStreetAddress,
-			)
+			// on successful exit (io.EOF w. no prevailing errors), send an OK back to client
+			if err == io.EOF {
+				jobSuccess = true
+				return stream.SendAndClose(
+					&pb.IOResponse{
+						Success:             jobSuccess,
+						TotalObjectsWritten: int32(totalObjectsWritten),
+					})
+			}
+		}
+
+		boundary.Layer = srv.NormalizeBoundaryLayer(boundary.Layer)
+		if verr := srv.ValidateBoundary(boundary); verr != nil {
+			log.WithFields(log.Fields{
+				"boundary.id":    boundary.Id,
+				"boundary.layer": boundary.Layer,
+				"err":            verr.Error(),
+			}).Error("invalid boundary")
+			respCode = codes.InvalidArgument
+			return status.Error(respCode, verr.Error())
 		}
+		queuedBoundaries = append(queuedBoundaries, boundary)
 	}
-	return status.Error(codes.Unknown, "unknown code path")
 }
 
 func init() {
@@ -153,15 +1349,28 @@ func init() {
 func main() {
 	flag.Parse()
 
+	bounds, err := srv.ParseDatasetBounds(*datasetBounds)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Panic("failed parsing `--dataset-bounds`")
+	}
+
 	grpcServer := grpc.NewServer([]grpc.ServerOption{}...)
 	managementServer := &ManagementServer{
-		client: srv.MustRedisClient(
+		datasetBounds: bounds,
+		backend: srv.MustSearchBackend(
 			context.Background(),
+			*searchBackend,
 			&srv.RedisClientOptions{
 				DB:   *redisDB,
 				Host: *redisHost,
 				Port: *redisPort,
 			},
+			&srv.MemorySnapshotOptions{
+				Path:   *memorySnapshot,
+				Writer: true,
+			},
 		),
 	}
 
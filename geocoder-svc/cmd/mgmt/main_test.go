@@ -0,0 +1,552 @@
+package main
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	// internal
+	srv "github.com/dmw2151/geocoder/geocoder-svc/internal"
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStream - a client-streaming server stream that replays `msgs` then returns io.EOF, the response is kept
+// in `resp`
+type fakeStream[T any] struct {
+	grpc.ServerStream
+	msgs []T
+	resp *pb.IOResponse
+}
+
+func (f *fakeStream[T]) Recv() (T, error) {
+	var msg T
+	if len(f.msgs) == 0 {
+		return msg, io.EOF
+	}
+	msg, f.msgs = f.msgs[0], f.msgs[1:]
+	return msg, nil
+}
+
+func (f *fakeStream[T]) SendAndClose(resp *pb.IOResponse) error {
+	f.resp = resp
+	return nil
+}
+
+// testAddresses - `n` distinct addresses along a single street
+func testAddresses(n int) []*pb.Address {
+	addresses := make([]*pb.Address, n)
+	for i := range addresses {
+		addresses[i] = &pb.Address{
+			Id:                     fmt.Sprintf("%d", i+1),
+			CompositeStreetAddress: fmt.Sprintf("%d MACON ST BROOKLYN NEW YORK 11216", i+1),
+			Location:               &pb.Point{Latitude: 40.6812, Longitude: -73.9479 + float32(i)*0.0001},
+		}
+	}
+	return addresses
+}
+
+// newTestManagementServer - a server on a memory backend w. `n` addresses served in the default dataset
+func newTestManagementServer(t *testing.T, n int) *ManagementServer {
+	t.Helper()
+	s := &ManagementServer{backend: srv.NewMemorySearchBackend()}
+	if err := s.backend.Upsert(context.Background(), srv.DefaultDataset, srv.LiveVersion, testAddresses(n)); err != nil {
+		t.Fatalf("Upsert() = %v", err)
+	}
+	return s
+}
+
+func TestPromoteDatasetVersionValidation(t *testing.T) {
+	s := newTestManagementServer(t, 10)
+	ctx := context.Background()
+
+	created, err := s.CreateDatasetVersion(ctx, &pb.CreateDatasetVersionRequest{})
+	if err != nil {
+		t.Fatalf("CreateDatasetVersion() = %v", err)
+	}
+	promote := &pb.PromoteDatasetVersionRequest{Version: created.Version}
+
+	// an empty version is never served
+	if _, err := s.PromoteDatasetVersion(ctx, promote); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("PromoteDatasetVersion(empty) = %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	if err := s.backend.Upsert(ctx, srv.DefaultDataset, created.Version, testAddresses(5)); err != nil {
+		t.Fatalf("Upsert(version) = %v", err)
+	}
+
+	var tests = []struct {
+		req  *pb.PromoteDatasetVersionRequest
+		want codes.Code
+	}{
+		{&pb.PromoteDatasetVersionRequest{Version: created.Version, ExpectedCount: 6, AllowShrink: true}, codes.FailedPrecondition},
+		{&pb.PromoteDatasetVersionRequest{Version: created.Version}, codes.FailedPrecondition}, // 5 of 10 -> shrunk
+		{&pb.PromoteDatasetVersionRequest{Version: srv.LiveVersion}, codes.NotFound},
+		{&pb.PromoteDatasetVersionRequest{Version: created.Version + 1}, codes.NotFound},
+		{&pb.PromoteDatasetVersionRequest{Dataset: "NJ", Version: created.Version}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if _, err := s.PromoteDatasetVersion(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("PromoteDatasetVersion(%v) = %v, want %v", tt.req, err, tt.want)
+		}
+	}
+
+	// a failed validation leaves the served version as-is
+	if n, _ := s.backend.CountAddresses(ctx, srv.DefaultDataset, srv.LiveVersion); n != 10 {
+		t.Errorf("CountAddresses() after failed promotions = %d, want 10", n)
+	}
+}
+
+func TestPromoteDatasetVersion(t *testing.T) {
+	s := newTestManagementServer(t, 10)
+	ctx := context.Background()
+
+	// an abandoned reload is dropped when another version is promoted
+	if _, err := s.CreateDatasetVersion(ctx, &pb.CreateDatasetVersionRequest{}); err != nil {
+		t.Fatalf("CreateDatasetVersion(abandoned) = %v", err)
+	}
+	created, err := s.CreateDatasetVersion(ctx, &pb.CreateDatasetVersionRequest{})
+	if err != nil {
+		t.Fatalf("CreateDatasetVersion() = %v", err)
+	}
+	if err := s.backend.Upsert(ctx, srv.DefaultDataset, created.Version, testAddresses(5)); err != nil {
+		t.Fatalf("Upsert(version) = %v", err)
+	}
+
+	resp, err := s.PromoteDatasetVersion(ctx, &pb.PromoteDatasetVersionRequest{Version: created.Version, ExpectedCount: 5, AllowShrink: true})
+	if err != nil {
+		t.Fatalf("PromoteDatasetVersion() = %v", err)
+	}
+	if (resp.NumAddresses != 5) || (resp.PreviousNumAddresses != 10) || (resp.NumVersionsDropped != 1) {
+		t.Errorf("PromoteDatasetVersion() = %v, want 5 addresses, 10 previous && 1 version dropped", resp)
+	}
+	if n, _ := s.backend.CountAddresses(ctx, srv.DefaultDataset, srv.LiveVersion); n != 5 {
+		t.Errorf("CountAddresses() after promotion = %d, want 5", n)
+	}
+}
+
+func TestDeleteAddresses(t *testing.T) {
+	s := newTestManagementServer(t, 3)
+	ctx := context.Background()
+
+	stream := &fakeStream[*pb.AddressDeletion]{msgs: []*pb.AddressDeletion{{Id: "1"}, {Id: "3"}, {Id: "missing"}}}
+	if err := s.DeleteAddresses(stream); err != nil {
+		t.Fatalf("DeleteAddresses() = %v", err)
+	}
+	if (stream.resp.TotalObjectsDeleted != 2) || (stream.resp.TotalObjectsNotFound != 1) {
+		t.Errorf("DeleteAddresses() = %v, want 2 deleted && 1 not found", stream.resp)
+	}
+	if n, _ := s.backend.CountAddresses(ctx, srv.DefaultDataset, srv.LiveVersion); n != 1 {
+		t.Errorf("CountAddresses() after delete = %d, want 1", n)
+	}
+
+	// deletions from a dataset that was never loaded
+	stream = &fakeStream[*pb.AddressDeletion]{msgs: []*pb.AddressDeletion{{Id: "1", Dataset: "NJ"}}}
+	if err := s.DeleteAddresses(stream); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("DeleteAddresses(invalid dataset) = %v, want %v", err, codes.InvalidArgument)
+	}
+}
+
+func TestApplyAddressChanges(t *testing.T) {
+	s := newTestManagementServer(t, 3)
+	ctx := context.Background()
+
+	moved := testAddresses(4)[3]
+	stream := &fakeStream[*pb.AddressChange]{msgs: []*pb.AddressChange{
+		{Change: &pb.AddressChange_Delete{Delete: &pb.AddressDeletion{Id: "1"}}},
+		{Change: &pb.AddressChange_Upsert{Upsert: moved}},
+		{Change: &pb.AddressChange_Delete{Delete: &pb.AddressDeletion{Id: moved.Id}}}, // changes apply in order
+		{Change: &pb.AddressChange_Upsert{Upsert: &pb.Address{Id: "1", CompositeStreetAddress: "1 HALSEY ST BROOKLYN NEW YORK 11216", Location: &pb.Point{Latitude: 40.682, Longitude: -73.948}}}},
+		{Change: &pb.AddressChange_Delete{Delete: &pb.AddressDeletion{Id: "missing"}}},
+	}}
+	if err := s.ApplyAddressChanges(stream); err != nil {
+		t.Fatalf("ApplyAddressChanges() = %v", err)
+	}
+	if r := stream.resp; (r.TotalObjectsUpserted != 2) || (r.TotalObjectsDeleted != 2) || (r.TotalObjectsNotFound != 1) {
+		t.Errorf("ApplyAddressChanges() = %v, want 2 upserted, 2 deleted && 1 not found", r)
+	}
+
+	results, _ := s.backend.SearchText(ctx, &srv.TextQuery{Text: "HALSEY", Limit: 5})
+	if len(results) != 1 {
+		t.Errorf("SearchText(HALSEY) = %v, want the re-inserted address", results)
+	}
+	if n, _ := s.backend.CountAddresses(ctx, srv.DefaultDataset, srv.LiveVersion); n != 3 {
+		t.Errorf("CountAddresses() after changes = %d, want 3", n)
+	}
+
+	stream = &fakeStream[*pb.AddressChange]{msgs: []*pb.AddressChange{{}}}
+	if err := s.ApplyAddressChanges(stream); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ApplyAddressChanges(empty change) = %v, want %v", err, codes.InvalidArgument)
+	}
+}
+
+func TestAddressChangesPendingVersion(t *testing.T) {
+	s := newTestManagementServer(t, 3)
+	ctx := context.Background()
+
+	created, err := s.CreateDatasetVersion(ctx, &pb.CreateDatasetVersionRequest{})
+	if err != nil {
+		t.Fatalf("CreateDatasetVersion() = %v", err)
+	}
+
+	// changes to the version being served are rejected while a reload is loading, it'd drop || overwrite them
+	deletions := &fakeStream[*pb.AddressDeletion]{msgs: []*pb.AddressDeletion{{Id: "1"}}}
+	if err := s.DeleteAddresses(deletions); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteAddresses(pending version) = %v, want %v", err, codes.FailedPrecondition)
+	}
+	changes := &fakeStream[*pb.AddressChange]{msgs: []*pb.AddressChange{
+		{Change: &pb.AddressChange_Delete{Delete: &pb.AddressDeletion{Id: "1"}}},
+	}}
+	if err := s.ApplyAddressChanges(changes); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ApplyAddressChanges(pending version) = %v, want %v", err, codes.FailedPrecondition)
+	}
+	if n, _ := s.backend.CountAddresses(ctx, srv.DefaultDataset, srv.LiveVersion); n != 3 {
+		t.Errorf("CountAddresses() = %d, want 3", n)
+	}
+
+	// && accepted once it's promoted
+	if err := s.backend.Upsert(ctx, srv.DefaultDataset, created.Version, testAddresses(3)); err != nil {
+		t.Fatalf("Upsert() = %v", err)
+	}
+	if _, err := s.PromoteDatasetVersion(ctx, &pb.PromoteDatasetVersionRequest{Version: created.Version, ExpectedCount: 3}); err != nil {
+		t.Fatalf("PromoteDatasetVersion() = %v", err)
+	}
+	deletions = &fakeStream[*pb.AddressDeletion]{msgs: []*pb.AddressDeletion{{Id: "1"}}}
+	if err := s.DeleteAddresses(deletions); (err != nil) || (deletions.resp.TotalObjectsDeleted != 1) {
+		t.Errorf("DeleteAddresses() = %v, %v, want 1 deleted", deletions.resp, err)
+	}
+}
+
+func TestDropDatasetVersion(t *testing.T) {
+	s := newTestManagementServer(t, 3)
+	ctx := context.Background()
+
+	created, err := s.CreateDatasetVersion(ctx, &pb.CreateDatasetVersionRequest{})
+	if err != nil {
+		t.Fatalf("CreateDatasetVersion() = %v", err)
+	}
+	if err := s.backend.Upsert(ctx, srv.DefaultDataset, created.Version, testAddresses(2)); err != nil {
+		t.Fatalf("Upsert() = %v", err)
+	}
+
+	// a reload that's abandoned is dropped, && live changes are accepted again
+	resp, err := s.DropDatasetVersion(ctx, &pb.DropDatasetVersionRequest{Version: created.Version})
+	if (err != nil) || (resp.NumAddresses != 2) || (resp.NumVersionsDropped != 1) {
+		t.Fatalf("DropDatasetVersion() = %v, %v, want 2 addresses && 1 version dropped", resp, err)
+	}
+	deletions := &fakeStream[*pb.AddressDeletion]{msgs: []*pb.AddressDeletion{{Id: "1"}}}
+	if err := s.DeleteAddresses(deletions); (err != nil) || (deletions.resp.TotalObjectsDeleted != 1) {
+		t.Errorf("DeleteAddresses() after drop = %v, %v, want 1 deleted", deletions.resp, err)
+	}
+
+	var tests = []struct {
+		req  *pb.DropDatasetVersionRequest
+		want codes.Code
+	}{
+		{&pb.DropDatasetVersionRequest{Version: created.Version}, codes.NotFound},
+		{&pb.DropDatasetVersionRequest{Version: srv.LiveVersion}, codes.FailedPrecondition},
+		{&pb.DropDatasetVersionRequest{Version: created.Version, Dataset: "NJ"}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if _, err := s.DropDatasetVersion(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("DropDatasetVersion(%v) = %v, want %v", tt.req, err, tt.want)
+		}
+	}
+}
+
+func TestLiveUpsertsPendingVersion(t *testing.T) {
+	s := newTestManagementServer(t, 3)
+	ctx := context.Background()
+
+	job, err := s.CreateIngestJob(ctx, &pb.CreateIngestJobRequest{Source: "addresses.csv"})
+	if err != nil {
+		t.Fatalf("CreateIngestJob() = %v", err)
+	}
+	created, err := s.CreateDatasetVersion(ctx, &pb.CreateDatasetVersionRequest{})
+	if err != nil {
+		t.Fatalf("CreateDatasetVersion() = %v", err)
+	}
+
+	// upserts to the version being served are rejected while a reload is loading, promoting it would drop them
+	stream := &fakeStream[*pb.Address]{msgs: testAddresses(4)[3:]}
+	if err := s.InsertorReplaceAddressData(stream); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("InsertorReplaceAddressData(pending version) = %v, want %v", err, codes.FailedPrecondition)
+	}
+	if _, err := s.CreateIngestJob(ctx, &pb.CreateIngestJobRequest{Source: "addresses.csv"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateIngestJob(pending version) = %v, want %v", err, codes.FailedPrecondition)
+	}
+	chunk := &pb.IngestChunk{JobId: job.JobId, Addresses: testAddresses(4)[3:]}
+	if _, err := s.ingestChunk(ctx, job, srv.NewAddressValidator(nil, nil), chunk); err != srv.ErrDatasetVersionPending {
+		t.Errorf("ingestChunk(pending version) = %v, want %v", err, srv.ErrDatasetVersionPending)
+	}
+
+	// writes to the pending version itself are accepted
+	stream = &fakeStream[*pb.Address]{msgs: testAddresses(3)}
+	for _, address := range stream.msgs {
+		address.Version = created.Version
+	}
+	if err := s.InsertorReplaceAddressData(stream); (err != nil) || (stream.resp.TotalObjectsUpserted != 3) {
+		t.Fatalf("InsertorReplaceAddressData(version %d) = %v, %v, want 3 upserted", created.Version, stream.resp, err)
+	}
+	if _, err := s.PromoteDatasetVersion(ctx, &pb.PromoteDatasetVersionRequest{Version: created.Version, ExpectedCount: 3}); err != nil {
+		t.Fatalf("PromoteDatasetVersion() = %v", err)
+	}
+
+	// && once it's promoted, a live upsert is served by the promoted version
+	stream = &fakeStream[*pb.Address]{msgs: testAddresses(4)[3:]}
+	if err := s.InsertorReplaceAddressData(stream); (err != nil) || (stream.resp.TotalObjectsUpserted != 1) {
+		t.Fatalf("InsertorReplaceAddressData() = %v, %v, want 1 upserted", stream.resp, err)
+	}
+	if _, err := s.GetAddress(ctx, &pb.GetAddressRequest{Id: "4"}); err != nil {
+		t.Errorf("GetAddress(4) after promote = %v", err)
+	}
+}
+
+func TestGetAddress(t *testing.T) {
+	s := newTestManagementServer(t, 3)
+	ctx := context.Background()
+
+	// either the `Address.Id` sent at ingest || the `Id` returned in results
+	for _, id := range []string{"2", "address:2"} {
+		address, err := s.GetAddress(ctx, &pb.GetAddressRequest{Id: id})
+		if err != nil {
+			t.Fatalf("GetAddress(%q) = %v", id, err)
+		}
+		if address.CompositeStreetAddress != "2 MACON ST BROOKLYN NEW YORK 11216" {
+			t.Errorf("GetAddress(%q) = %v", id, address)
+		}
+	}
+
+	var tests = []struct {
+		req  *pb.GetAddressRequest
+		want codes.Code
+	}{
+		{&pb.GetAddressRequest{Id: "missing"}, codes.NotFound},
+		{&pb.GetAddressRequest{Id: "2", Dataset: "nj"}, codes.NotFound},
+		{&pb.GetAddressRequest{Id: "2", Dataset: "NJ"}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if _, err := s.GetAddress(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("GetAddress(%v) = %v, want %v", tt.req, err, tt.want)
+		}
+	}
+
+	// a read doesn't create the dataset it names
+	if ok, _ := s.backend.DatasetExists(ctx, "nj"); ok {
+		t.Errorf("DatasetExists(nj) after GetAddress = true, want false")
+	}
+}
+
+func TestGetDatasetStats(t *testing.T) {
+	s := newTestManagementServer(t, 3)
+	ctx := context.Background()
+
+	stats, err := s.GetDatasetStats(ctx, &pb.DatasetStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetDatasetStats() = %v", err)
+	}
+	if (stats.Dataset != srv.DefaultDataset) || (stats.NumAddresses != 3) {
+		t.Errorf("GetDatasetStats() = %v, want 3 addresses in %s", stats, srv.DefaultDataset)
+	}
+
+	if _, err := s.GetDatasetStats(ctx, &pb.DatasetStatsRequest{Dataset: "nj"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetDatasetStats(nj) = %v, want %v", err, codes.NotFound)
+	}
+}
+
+// fakeExportStream - a server stream that keeps every address sent
+type fakeExportStream struct {
+	grpc.ServerStream
+	sent []*pb.Address
+}
+
+func (f *fakeExportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeExportStream) Send(address *pb.Address) error {
+	f.sent = append(f.sent, address)
+	return nil
+}
+
+func TestExportAddresses(t *testing.T) {
+	s := newTestManagementServer(t, 12)
+
+	stream := &fakeExportStream{}
+	if err := s.ExportAddresses(&pb.ExportAddressesRequest{IdPrefix: "1"}, stream); err != nil {
+		t.Fatalf("ExportAddresses() = %v", err)
+	}
+	if len(stream.sent) != 4 { // 1, 10, 11, 12
+		t.Errorf("ExportAddresses(id_prefix=1) sent %d addresses, want 4", len(stream.sent))
+	}
+
+	var tests = []struct {
+		req  *pb.ExportAddressesRequest
+		want codes.Code
+	}{
+		{&pb.ExportAddressesRequest{Dataset: "nj"}, codes.NotFound},
+		{&pb.ExportAddressesRequest{BoundingBox: &pb.BoundingBox{Min: &pb.Point{Latitude: 41}, Max: &pb.Point{Latitude: 40}}}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if err := s.ExportAddresses(tt.req, &fakeExportStream{}); status.Code(err) != tt.want {
+			t.Errorf("ExportAddresses(%v) = %v, want %v", tt.req, err, tt.want)
+		}
+	}
+}
+
+func TestInsertorReplaceAddressDataRejects(t *testing.T) {
+	s := newTestManagementServer(t, 0)
+	s.datasetBounds = map[string]*pb.BoundingBox{
+		srv.DefaultDataset: {
+			Min: &pb.Point{Latitude: 40.4, Longitude: -74.3},
+			Max: &pb.Point{Latitude: 41.0, Longitude: -73.7},
+		},
+	}
+
+	addresses := testAddresses(4)
+	addresses[1].Location = &pb.Point{}                            // invalid location
+	addresses[2].Location = &pb.Point{Latitude: 0.5, Longitude: 1} // out of bounds
+	addresses = append(addresses, testAddresses(1)...)             // duplicate id
+
+	stream := &fakeStream[*pb.Address]{msgs: addresses}
+	if err := s.InsertorReplaceAddressData(stream); err != nil {
+		t.Fatalf("InsertorReplaceAddressData() = %v", err)
+	}
+
+	if (stream.resp.TotalObjectsUpserted != 2) || (stream.resp.TotalObjectsRejected != 3) || (len(stream.resp.RejectedSamples) != 3) {
+		t.Errorf("InsertorReplaceAddressData() = %v, want 2 upserted && 3 rejected", stream.resp)
+	}
+
+	var want = []struct{ id, reason string }{
+		{"2", srv.ErrAddressInvalidLocation.Error()},
+		{"3", srv.ErrAddressOutOfBounds.Error()},
+		{"1", srv.ErrAddressDuplicateID.Error()},
+	}
+	for i, r := range stream.resp.RejectedSamples {
+		if (r.Id != want[i].id) || (r.Reason != want[i].reason) {
+			t.Errorf("RejectedSamples[%d] = %v, want %v", i, r, want[i])
+		}
+	}
+}
+
+func TestInsertorReplaceAddressDataRejectedSamplesCapped(t *testing.T) {
+	s := newTestManagementServer(t, 0)
+
+	addresses := testAddresses(serverMaxRejectedSamples + 50)
+	for _, a := range addresses {
+		a.Id = ""
+	}
+
+	stream := &fakeStream[*pb.Address]{msgs: addresses}
+	if err := s.InsertorReplaceAddressData(stream); err != nil {
+		t.Fatalf("InsertorReplaceAddressData() = %v", err)
+	}
+
+	if got := stream.resp.TotalObjectsRejected; got != int32(len(addresses)) {
+		t.Errorf("TotalObjectsRejected = %d, want %d", got, len(addresses))
+	}
+	if got := len(stream.resp.RejectedSamples); got != serverMaxRejectedSamples {
+		t.Errorf("len(RejectedSamples) = %d, want %d", got, serverMaxRejectedSamples)
+	}
+}
+
+func TestInsertorReplaceAddressDataDryRun(t *testing.T) {
+	s := newTestManagementServer(t, 0)
+	ctx := context.Background()
+
+	var tests = []struct {
+		name    string
+		dataset string
+	}{
+		{"existing dataset", ""},
+		{"new dataset", "nj"},
+	}
+
+	for _, tt := range tests {
+		addresses := testAddresses(5)
+		for _, a := range addresses {
+			a.Dataset = tt.dataset
+		}
+		addresses[0].DryRun = true
+		addresses[3].Id = ""
+
+		stream := &fakeStream[*pb.Address]{msgs: addresses}
+		if err := s.InsertorReplaceAddressData(stream); err != nil {
+			t.Fatalf("InsertorReplaceAddressData(%s) = %v", tt.name, err)
+		}
+
+		resp := stream.resp
+		if !resp.DryRun || (resp.TotalObjectsUpserted != 4) || (resp.TotalObjectsRejected != 1) {
+			t.Errorf("InsertorReplaceAddressData(%s) = %v, want a dry run w. 4 valid && 1 rejected", tt.name, resp)
+		}
+	}
+
+	// a dry run writes nothing && never creates the dataset
+	if n, err := s.backend.CountAddresses(ctx, srv.DefaultDataset, srv.LiveVersion); (err != nil) || (n != 0) {
+		t.Errorf("CountAddresses() = %d, %v, want 0", n, err)
+	}
+	if ok, err := s.backend.DatasetExists(ctx, "nj"); (err != nil) || ok {
+		t.Errorf("DatasetExists(nj) = %v, %v, want false", ok, err)
+	}
+}
+
+func TestIngestChunk(t *testing.T) {
+	s := newTestManagementServer(t, 0)
+	ctx := context.Background()
+
+	job, err := s.CreateIngestJob(ctx, &pb.CreateIngestJobRequest{Source: "addresses.csv"})
+	if err != nil {
+		t.Fatalf("CreateIngestJob() = %v", err)
+	}
+	validator := srv.NewAddressValidator(nil, nil)
+	addresses := testAddresses(10)
+
+	var tests = []struct {
+		name        string
+		offset      int64
+		addresses   []*pb.Address
+		wantErr     error
+		wantWritten int32
+		wantOffset  int64
+	}{
+		{"first chunk", 0, addresses[:4], nil, 4, 4},
+		{"out of order chunk", 6, addresses[6:8], srv.ErrIngestChunkOutOfOrder, 0, 4},
+		{"resent chunk", 0, addresses[:4], nil, 0, 4},
+		{"overlapping chunk", 2, addresses[2:7], nil, 3, 7},
+		{"next chunk", 7, addresses[7:], nil, 3, 10},
+	}
+
+	for _, tt := range tests {
+		chunk := &pb.IngestChunk{JobId: job.JobId, Offset: tt.offset, Addresses: tt.addresses}
+		ack, err := s.ingestChunk(ctx, job, validator, chunk)
+		if err != tt.wantErr {
+			t.Fatalf("ingestChunk(%s) = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if (err == nil) && ((ack.TotalObjectsWritten != tt.wantWritten) || (ack.CommittedOffset != tt.wantOffset)) {
+			t.Errorf("ingestChunk(%s) = (%d written, offset %d), want (%d written, offset %d)",
+				tt.name, ack.TotalObjectsWritten, ack.CommittedOffset, tt.wantWritten, tt.wantOffset)
+		}
+		if job.CommittedOffset != tt.wantOffset {
+			t.Errorf("ingestChunk(%s) committed offset = %d, want %d", tt.name, job.CommittedOffset, tt.wantOffset)
+		}
+	}
+
+	// each address is written once && the committed offset is saved for a resumed client
+	if n, err := s.backend.CountAddresses(ctx, srv.DefaultDataset, srv.LiveVersion); (err != nil) || (n != 10) {
+		t.Errorf("CountAddresses() = %d, %v, want 10", n, err)
+	}
+	saved, err := s.GetIngestJob(ctx, &pb.GetIngestJobRequest{JobId: job.JobId})
+	if (err != nil) || (saved.CommittedOffset != 10) {
+		t.Errorf("GetIngestJob() = %v, %v, want committed offset 10", saved, err)
+	}
+
+	if _, err := s.GetIngestJob(ctx, &pb.GetIngestJobRequest{JobId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetIngestJob(missing) = %v, want %v", err, codes.NotFound)
+	}
+}
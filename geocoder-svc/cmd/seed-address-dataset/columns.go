@@ -0,0 +1,257 @@
+package main
+
+import (
+
+	// standard lib
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+var (
+	// errRowGeometry - a row w. a geometry that isn't a WKT point
+	errRowGeometry = errors.New("geometry must be a WKT `POINT (x y)`")
+
+	// errRowCoordinates - a row w. a latitude || longitude that isn't a number
+	errRowCoordinates = errors.New("latitude && longitude must be numbers")
+)
+
+// columnMapping - names the header columns (or GeoJSON properties) of an input file used for each field of an
+// address, set w. the `--*-column` flags; a CSV file w.o. a mapping must use the NYC layout (see `recordToAddress`)
+type columnMapping struct {
+	ID             []string // required, the first non-empty value is used
+	Address        []string // joined w. spaces to form the composite address, optional if any component is set
+	Latitude       string   // set both `Latitude` && `Longitude`, || `Geometry`; unused for GeoJSON
+	Longitude      string   //
+	Geometry       string   // a WKT point
+	GeometryLatLng bool     // the WKT point is `POINT (lat lng)` rather than `POINT (lng lat)`
+	HouseNumber    string   // optional components
+	Street         string   //
+	Locality       string   //
+	PostalCode     string   //
+	Region         string   //
+	DefaultRegion  string   // region of addresses w.o. a `Region` column (or w. an empty value)
+}
+
+// openAddressesColumns - the OpenAddresses schema (https://openaddresses.io), `ID` is often empty so the row's
+// `HASH` is used instead
+var openAddressesColumns = &columnMapping{
+	ID:          []string{"ID", "HASH"},
+	Address:     []string{"NUMBER", "STREET", "UNIT", "CITY", "REGION", "POSTCODE"},
+	Latitude:    "LAT",
+	Longitude:   "LON",
+	HouseNumber: "NUMBER",
+	Street:      "STREET",
+	Locality:    "CITY",
+	PostalCode:  "POSTCODE",
+	Region:      "REGION",
+}
+
+// geoJSONColumns - the properties written by `geoJSONAddressWriter`, used for GeoJSON files w.o. a mapping so
+// exports can be loaded w. `--file`
+var geoJSONColumns = &columnMapping{
+	ID:          []string{"id"},
+	Address:     []string{"composite_street_address"},
+	HouseNumber: "house_number",
+	Street:      "street",
+	Locality:    "locality",
+	PostalCode:  "postal_code",
+	Region:      "region",
+}
+
+// inputColumns - the mapping of `--file`, set from flags on startup; nil if `--file` uses the default layout of its
+// format
+var inputColumns *columnMapping
+
+// columnMappingFromFlags - the mapping named by `--openaddresses` || the `--*-column` flags, nil w.o. either
+func columnMappingFromFlags() (*columnMapping, error) {
+	if *openAddresses {
+		if *idColumn != "" {
+			return nil, errors.New("`--openaddresses` can't be used w. `--id-column`")
+		}
+		m := *openAddressesColumns
+		m.DefaultRegion = *defaultRegion
+		return &m, nil
+	}
+
+	if *idColumn == "" {
+		return nil, nil
+	}
+
+	if (*geometryOrder != "lnglat") && (*geometryOrder != "latlng") {
+		return nil, fmt.Errorf("unknown geometry order `%s`, must be one of `lnglat`, `latlng`", *geometryOrder)
+	}
+
+	return &columnMapping{
+		ID:             splitColumns(*idColumn),
+		Address:        splitColumns(*addressColumns),
+		Latitude:       *latColumn,
+		Longitude:      *lngColumn,
+		Geometry:       *geometryColumn,
+		GeometryLatLng: *geometryOrder == "latlng",
+		HouseNumber:    *houseNumberColumn,
+		Street:         *streetColumn,
+		Locality:       *localityColumn,
+		PostalCode:     *postalCodeColumn,
+		Region:         *regionColumn,
+		DefaultRegion:  *defaultRegion,
+	}, nil
+}
+
+// splitColumns - splits a comma-separated list of columns, ignoring empty names
+func splitColumns(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// validate - checks the mapping names an id, an address && (unless read from a geometry, e.g. GeoJSON) a location
+func (m *columnMapping) validate(requireLocation bool) error {
+	switch {
+	case len(m.ID) == 0:
+		return errors.New("`--id-column` is required")
+	case requireLocation && (m.Geometry == "") && ((m.Latitude == "") || (m.Longitude == "")):
+		return errors.New("one of `--geometry-column` || both `--lat-column` && `--lng-column` is required")
+	case (len(m.Address) == 0) && (m.Street == ""):
+		return errors.New("one of `--address-columns` || `--street-column` is required")
+	}
+	return nil
+}
+
+// names - every column named by the mapping
+func (m *columnMapping) names() []string {
+	names := append(append([]string{}, m.ID...), m.Address...)
+	for _, name := range []string{
+		m.Latitude, m.Longitude, m.Geometry, m.HouseNumber, m.Street, m.Locality, m.PostalCode, m.Region,
+	} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// resolve - finds the position of each mapped column in a CSV header, names are matched case-insensitively
+func (m *columnMapping) resolve(header []string) (map[string]int, error) {
+	if err := m.validate(true); err != nil {
+		return nil, err
+	}
+
+	// a UTF-8 BOM is common in files exported from spreadsheets
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	var positions = make(map[string]int)
+	for i := len(header) - 1; i >= 0; i-- {
+		positions[strings.ToLower(strings.TrimSpace(header[i]))] = i
+	}
+
+	for _, name := range m.names() {
+		if _, ok := positions[strings.ToLower(name)]; !ok {
+			return nil, fmt.Errorf("column `%s` not in header", name)
+		}
+	}
+	return positions, nil
+}
+
+// address - an address w.o. a location from the mapped columns, `get` returns a column's value (|| "" if missing);
+// w.o. `Address` columns, the management service composes the composite address from the components
+func (m *columnMapping) address(get func(name string) string) *pb.Address {
+
+	var address = &pb.Address{
+		HouseNumber: get(m.HouseNumber),
+		Street:      get(m.Street),
+		Locality:    get(m.Locality),
+		PostalCode:  get(m.PostalCode),
+		Region:      get(m.Region),
+	}
+
+	for _, name := range m.ID {
+		if address.Id = get(name); address.Id != "" {
+			break
+		}
+	}
+
+	if address.Region == "" {
+		address.Region = m.DefaultRegion
+	}
+
+	var parts []string
+	for _, name := range m.Address {
+		if v := get(name); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	address.CompositeStreetAddress = strings.Join(parts, " ")
+	return address
+}
+
+// location - the location from the mapped geometry || latitude && longitude columns
+func (m *columnMapping) location(get func(name string) string) (*pb.Point, error) {
+	if m.Geometry != "" {
+		x, y, err := parseWKTPoint(get(m.Geometry))
+		if err != nil {
+			return nil, err
+		}
+		if m.GeometryLatLng {
+			return &pb.Point{Latitude: float32(x), Longitude: float32(y)}, nil
+		}
+		return &pb.Point{Latitude: float32(y), Longitude: float32(x)}, nil
+	}
+
+	lat, xerr := strconv.ParseFloat(get(m.Latitude), 32)
+	lng, yerr := strconv.ParseFloat(get(m.Longitude), 32)
+	if (xerr != nil) || (yerr != nil) {
+		return nil, errRowCoordinates
+	}
+	return &pb.Point{Latitude: float32(lat), Longitude: float32(lng)}, nil
+}
+
+// recordGetter - looks up mapped columns in a CSV row by their resolved positions, rows may be short so a missing
+// value is empty
+func recordGetter(positions map[string]int, record []string) func(name string) string {
+	return func(name string) string {
+		if name == "" {
+			return ""
+		}
+		i, ok := positions[strings.ToLower(name)]
+		if !ok || (i >= len(record)) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+}
+
+// parseWKTPoint - parses the x && y of a WKT point, e.g. `POINT (-73.94 40.68)` || `POINT Z (-73.94 40.68 12)`
+func parseWKTPoint(s string) (float64, float64, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(strings.ToUpper(s), "POINT") {
+		return 0, 0, errRowGeometry
+	}
+
+	open, close := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if (open < 0) || (close < open) {
+		return 0, 0, errRowGeometry
+	}
+
+	coords := strings.Fields(s[open+1 : close])
+	if len(coords) < 2 {
+		return 0, 0, errRowGeometry
+	}
+
+	x, xerr := strconv.ParseFloat(coords[0], 64)
+	y, yerr := strconv.ParseFloat(coords[1], 64)
+	if (xerr != nil) || (yerr != nil) {
+		return 0, 0, errRowGeometry
+	}
+	return x, y, nil
+}
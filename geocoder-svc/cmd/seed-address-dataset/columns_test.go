@@ -0,0 +1,159 @@
+package main
+
+import (
+	// standard lib
+	"testing"
+)
+
+func TestParseWKTPoint(t *testing.T) {
+	var tests = []struct {
+		in   string
+		x, y float64
+		ok   bool
+	}{
+		{"POINT (-73.94 40.68)", -73.94, 40.68, true},
+		{"point(-73.94 40.68)", -73.94, 40.68, true},
+		{"POINT Z (-73.94 40.68 12)", -73.94, 40.68, true},
+		{"  POINT ( -73.94   40.68 )  ", -73.94, 40.68, true},
+		{"POINT (-73.94)", 0, 0, false},
+		{"POINT -73.94 40.68", 0, 0, false},
+		{"LINESTRING (0 0, 1 1)", 0, 0, false},
+		{"POINT (a b)", 0, 0, false},
+		{"", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		x, y, err := parseWKTPoint(tt.in)
+		if (err == nil) != tt.ok || (x != tt.x) || (y != tt.y) {
+			t.Errorf("parseWKTPoint(%q) = %v, %v, %v, want %v, %v, ok: %v", tt.in, x, y, err, tt.x, tt.y, tt.ok)
+		}
+	}
+}
+
+func TestColumnMappingCSV(t *testing.T) {
+	const data = "\ufeffAddr_ID,Alt_ID,Num,Street,City,Zip,Y,X,Geom\n" +
+		"1,,54,Macon St,Brooklyn,11216,40.6812,-73.9479,POINT (40.6812 -73.9479)\n" +
+		",alt-2,23,Wall St,New York,10005,40.706,-74.0088,POINT (40.706 -74.0088)\n" +
+		"3,,1,Main St,Springfield,62701,not a number,-89.65,POINT (39.8 -89.65)\n" +
+		"4,,2,Elm St\n"
+
+	var tests = []struct {
+		name      string
+		mapping   *columnMapping
+		wantIDs   []string
+		rejected  int
+		wantFirst [2]float32 // lat, lng of the first address
+	}{
+		{
+			name: "lat && lng columns",
+			mapping: &columnMapping{
+				ID: []string{"addr_id", "alt_id"}, Address: []string{"Num", "Street", "City", "Zip"},
+				Latitude: "Y", Longitude: "X", HouseNumber: "Num", Street: "Street", Locality: "City", PostalCode: "Zip",
+				DefaultRegion: "NEW YORK",
+			},
+			wantIDs:   []string{"1", "alt-2"},
+			rejected:  2,
+			wantFirst: [2]float32{40.6812, -73.9479},
+		},
+		{
+			name: "lat lng ordered WKT",
+			mapping: &columnMapping{
+				ID: []string{"addr_id", "alt_id"}, Street: "Street", Geometry: "Geom", GeometryLatLng: true,
+			},
+			wantIDs:   []string{"1", "alt-2", "3"},
+			rejected:  1,
+			wantFirst: [2]float32{40.6812, -73.9479},
+		},
+	}
+
+	for _, tt := range tests {
+		path := writeTestFile(t, "mapped.csv", []byte(data))
+		r, err := openAddressCSV(path, rejectFilePath(path), tt.mapping)
+		if err != nil {
+			t.Fatalf("%s: openAddressCSV() = %v", tt.name, err)
+		}
+		addresses := readAllAddresses(t, r)
+		r.Close()
+
+		var ids []string
+		for _, a := range addresses {
+			ids = append(ids, a.Id)
+		}
+		if !equalStrings(ids, tt.wantIDs) {
+			t.Errorf("%s: read ids = %v, want %v", tt.name, ids, tt.wantIDs)
+			continue
+		}
+		if r.NumRejected() != tt.rejected {
+			t.Errorf("%s: NumRejected() = %d, want %d", tt.name, r.NumRejected(), tt.rejected)
+		}
+		if loc := addresses[0].Location; (loc.Latitude != tt.wantFirst[0]) || (loc.Longitude != tt.wantFirst[1]) {
+			t.Errorf("%s: location = %v, want %v", tt.name, loc, tt.wantFirst)
+		}
+	}
+
+	// components, the composite address && the default region
+	path := writeTestFile(t, "mapped.csv", []byte(data))
+	r, _ := openAddressCSV(path, rejectFilePath(path), tests[0].mapping)
+	defer r.Close()
+
+	a, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+	if (a.CompositeStreetAddress != "54 Macon St Brooklyn 11216") || (a.HouseNumber != "54") || (a.Street != "Macon St") ||
+		(a.Locality != "Brooklyn") || (a.PostalCode != "11216") || (a.Region != "NEW YORK") {
+		t.Errorf("Read() = %+v", a)
+	}
+}
+
+func TestColumnMappingResolve(t *testing.T) {
+	m := &columnMapping{ID: []string{"id"}, Street: "street", Latitude: "lat", Longitude: "lng"}
+	if _, err := m.resolve([]string{"ID", "Street", "LAT"}); err == nil {
+		t.Errorf("resolve() w. a missing column = nil, want an error")
+	}
+
+	positions, err := m.resolve([]string{"\ufeffid", " Street ", "lat", "lng", "street"})
+	if err != nil {
+		t.Fatalf("resolve() = %v", err)
+	}
+	if (positions["id"] != 0) || (positions["street"] != 1) {
+		t.Errorf("resolve() = %v, want the BOM trimmed && the first of duplicate columns", positions)
+	}
+
+	for _, m := range []*columnMapping{
+		{Street: "street", Latitude: "lat", Longitude: "lng"},
+		{ID: []string{"id"}, Street: "street", Latitude: "lat"},
+		{ID: []string{"id"}, Latitude: "lat", Longitude: "lng"},
+	} {
+		if err := m.validate(true); err == nil {
+			t.Errorf("validate(%+v) = nil, want an error", m)
+		}
+	}
+}
+
+func TestOpenAddressesColumns(t *testing.T) {
+	const data = "LON,LAT,NUMBER,STREET,UNIT,CITY,DISTRICT,REGION,POSTCODE,ID,HASH\n" +
+		"-73.9479,40.6812,54,MACON ST,2F,BROOKLYN,,NY,11216,,3f2a\n"
+
+	m := *openAddressesColumns
+	m.DefaultRegion = "NEW YORK"
+
+	path := writeTestFile(t, "oa.csv", []byte(data))
+	r, err := openAddressCSV(path, rejectFilePath(path), &m)
+	if err != nil {
+		t.Fatalf("openAddressCSV() = %v", err)
+	}
+	defer r.Close()
+
+	addresses := readAllAddresses(t, r)
+	if len(addresses) != 1 {
+		t.Fatalf("read %d addresses, want 1", len(addresses))
+	}
+	a := addresses[0]
+	if (a.Id != "3f2a") || (a.CompositeStreetAddress != "54 MACON ST 2F BROOKLYN NY 11216") || (a.Region != "NY") {
+		t.Errorf("Read() = %+v", a)
+	}
+	if (a.Location.Latitude != 40.6812) || (a.Location.Longitude != -73.9479) {
+		t.Errorf("location = %v, want (40.6812, -73.9479)", a.Location)
+	}
+}
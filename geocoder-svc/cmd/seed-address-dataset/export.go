@@ -0,0 +1,228 @@
+package main
+
+import (
+
+	// standard lib
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	// internal
+	srv "github.com/dmw2151/geocoder/geocoder-svc/internal"
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	log "github.com/sirupsen/logrus"
+)
+
+// exportCSVHeader - same columns as the files read by `addressCSVReader`, so an export can be loaded w. `--file`
+var exportCSVHeader = []string{
+	"id", "location", "composite_street_address", "house_number", "street", "locality", "postal_code", "region",
+}
+
+// addressWriter - writes a stream of addresses to a file
+type addressWriter interface {
+	Write(address *pb.Address) error
+	Close() error
+}
+
+// csvAddressWriter - writes addresses as CSV, locations are written as `POINT (lng lat)` like the NYC source data
+type csvAddressWriter struct {
+	w *csv.Writer
+}
+
+// newCSVAddressWriter - creates a CSV writer && writes the header
+func newCSVAddressWriter(w io.Writer) (*csvAddressWriter, error) {
+	cw := &csvAddressWriter{w: csv.NewWriter(w)}
+	return cw, cw.w.Write(exportCSVHeader)
+}
+
+// Write - writes a single address as a row
+func (cw *csvAddressWriter) Write(address *pb.Address) error {
+	return cw.w.Write([]string{
+		address.Id,
+		fmt.Sprintf("POINT (%.6f %.6f)", address.GetLocation().GetLongitude(), address.GetLocation().GetLatitude()),
+		address.CompositeStreetAddress,
+		address.HouseNumber,
+		address.Street,
+		address.Locality,
+		address.PostalCode,
+		address.Region,
+	})
+}
+
+// Close - flushes all buffered rows
+func (cw *csvAddressWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// geoJSONAddressFeature - an address as a GeoJSON Point feature
+type geoJSONAddressFeature struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Geometry struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float32 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+// geoJSONAddressWriter - writes addresses as a GeoJSON FeatureCollection, one feature per line so large exports
+// are never held in memory
+type geoJSONAddressWriter struct {
+	w          io.Writer
+	numWritten int
+}
+
+// newGeoJSONAddressWriter - creates a GeoJSON writer && opens the FeatureCollection
+func newGeoJSONAddressWriter(w io.Writer) (*geoJSONAddressWriter, error) {
+	_, err := io.WriteString(w, "{\"type\": \"FeatureCollection\", \"features\": [\n")
+	return &geoJSONAddressWriter{w: w}, err
+}
+
+// Write - writes a single address as a feature
+func (gw *geoJSONAddressWriter) Write(address *pb.Address) error {
+	f := geoJSONAddressFeature{Type: "Feature", ID: address.Id}
+	f.Geometry.Type = "Point"
+	f.Geometry.Coordinates = [2]float32{address.GetLocation().GetLongitude(), address.GetLocation().GetLatitude()}
+	f.Properties = map[string]string{
+		"id":                       address.Id,
+		"composite_street_address": address.CompositeStreetAddress,
+		"house_number":             address.HouseNumber,
+		"street":                   address.Street,
+		"locality":                 address.Locality,
+		"postal_code":              address.PostalCode,
+		"region":                   address.Region,
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	if gw.numWritten > 0 {
+		if _, err := io.WriteString(gw.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	gw.numWritten++
+
+	_, err = gw.w.Write(b)
+	return err
+}
+
+// Close - closes the FeatureCollection
+func (gw *geoJSONAddressWriter) Close() error {
+	_, err := io.WriteString(gw.w, "\n]}\n")
+	return err
+}
+
+// newAddressWriter - creates a writer for `--export-format`
+func newAddressWriter(w io.Writer, format string) (addressWriter, error) {
+	switch format {
+	case "csv":
+		return newCSVAddressWriter(w)
+	case "geojson":
+		return newGeoJSONAddressWriter(w)
+	}
+	return nil, fmt.Errorf("unknown export format `%s`, must be one of `csv`, `geojson`", format)
+}
+
+// exportAddressData - streams the addresses of the dataset being served to a file, the file is left partially
+// written if the export fails
+func exportAddressData(client pb.ManagementClient, path string) {
+
+	// exports are bounded by the size of the dataset rather than a fixed timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var bbox *pb.BoundingBox
+	if *exportBBox != "" {
+		var err error
+		if bbox, err = srv.ParseBoundingBox(*exportBBox); err != nil {
+			log.WithFields(log.Fields{
+				"err": err,
+			}).Error("failed parsing `--export-bbox`")
+			return
+		}
+	}
+
+	fi, err := os.Create(path)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"filepath": path,
+		}).Error("failed creating export file")
+		return
+	}
+	defer fi.Close()
+
+	buf := bufio.NewWriter(fi)
+	defer buf.Flush()
+
+	w, err := newAddressWriter(buf, *exportFormat)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("failed initializing export")
+		return
+	}
+
+	stream, err := client.ExportAddresses(ctx, &pb.ExportAddressesRequest{
+		Dataset:     *dataset,
+		BoundingBox: bbox,
+		IdPrefix:    *exportIDPrefix,
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("/geocoder.Management/ExportAddresses; failed initializing stream")
+		return
+	}
+
+	var numExported int
+	for {
+		address, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":                 err,
+				"export.num_exported": numExported,
+			}).Error("/geocoder.Management/ExportAddresses; failed recv")
+			return
+		}
+
+		if err := w.Write(address); err != nil {
+			log.WithFields(log.Fields{
+				"err":      err,
+				"filepath": path,
+			}).Error("failed writing export file")
+			return
+		}
+		numExported++
+	}
+
+	if err = w.Close(); err == nil {
+		err = buf.Flush()
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"filepath": path,
+		}).Error("failed writing export file")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"export.num_exported": numExported,
+		"export.format":       *exportFormat,
+		"filepath":            path,
+	}).Info("/geocoder.Management/ExportAddresses; success")
+}
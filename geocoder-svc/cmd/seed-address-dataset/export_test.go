@@ -0,0 +1,94 @@
+package main
+
+import (
+	// standard lib
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// testExportAddresses - addresses w. all components set, as returned by `ExportAddresses`
+func testExportAddresses() []*pb.Address {
+	return []*pb.Address{
+		{Id: "a1", CompositeStreetAddress: "54 MACON ST BROOKLYN NEW YORK 11216", Location: &pb.Point{Latitude: 40.6812, Longitude: -73.9479},
+			HouseNumber: "54", Street: "MACON ST", Locality: "BROOKLYN", PostalCode: "11216", Region: "NEW YORK"},
+		{Id: "a2", CompositeStreetAddress: "1 MAIN ST, SPRINGFIELD, IL", Location: &pb.Point{Latitude: 39.8, Longitude: -89.65},
+			HouseNumber: "1", Street: "MAIN ST", Locality: "SPRINGFIELD", PostalCode: "62701", Region: "IL"},
+	}
+}
+
+// exportAll - writes addresses w. a `--export-format` writer
+func exportAll(t *testing.T, format string, addresses []*pb.Address) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := newAddressWriter(&buf, format)
+	if err != nil {
+		t.Fatalf("newAddressWriter(%s) = %v", format, err)
+	}
+	for _, a := range addresses {
+		if err := w.Write(a); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestCSVExportRoundTrip - files written by `--export-format csv` can be loaded w. `--file`, including the region
+func TestCSVExportRoundTrip(t *testing.T) {
+	addresses := testExportAddresses()
+
+	path := writeTestFile(t, "export.csv", exportAll(t, "csv", addresses))
+	r, err := openAddressCSV(path, rejectFilePath(path), nil)
+	if err != nil {
+		t.Fatalf("openAddressCSV() = %v", err)
+	}
+	defer r.Close()
+
+	got := readAllAddresses(t, r)
+	if len(got) != len(addresses) {
+		t.Fatalf("read %d addresses, want %d", len(got), len(addresses))
+	}
+	for i := range addresses {
+		want := addresses[i]
+		if (got[i].Id != want.Id) || (got[i].CompositeStreetAddress != want.CompositeStreetAddress) ||
+			(got[i].HouseNumber != want.HouseNumber) || (got[i].Street != want.Street) || (got[i].Locality != want.Locality) ||
+			(got[i].PostalCode != want.PostalCode) || (got[i].Region != want.Region) ||
+			(got[i].Location.Latitude != want.Location.Latitude) || (got[i].Location.Longitude != want.Location.Longitude) {
+			t.Errorf("address %d = %+v, want %+v", i, got[i], want)
+		}
+	}
+}
+
+func TestGeoJSONExport(t *testing.T) {
+	var fc struct {
+		Type     string                  `json:"type"`
+		Features []geoJSONAddressFeature `json:"features"`
+	}
+	if err := json.Unmarshal(exportAll(t, "geojson", testExportAddresses()), &fc); err != nil {
+		t.Fatalf("export isn't valid JSON: %v", err)
+	}
+
+	if (fc.Type != "FeatureCollection") || (len(fc.Features) != 2) {
+		t.Fatalf("export = %s w. %d features, want a FeatureCollection w. 2 features", fc.Type, len(fc.Features))
+	}
+	f := fc.Features[0]
+	if (f.ID != "a1") || (f.Geometry.Coordinates != [2]float32{-73.9479, 40.6812}) || (f.Properties["street"] != "MACON ST") {
+		t.Errorf("feature = %+v", f)
+	}
+
+	// an empty export is still a valid collection
+	if err := json.Unmarshal(exportAll(t, "geojson", nil), &fc); (err != nil) || (len(fc.Features) != 0) {
+		t.Errorf("empty export = %v w. %d features, want an empty collection", err, len(fc.Features))
+	}
+
+	if _, err := newAddressWriter(&bytes.Buffer{}, "xml"); err == nil {
+		t.Errorf("newAddressWriter(xml) = nil, want an error")
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+
+	// standard lib
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+var (
+	// errFeatureType - a GeoJSON object that isn't a feature
+	errFeatureType = errors.New("expected a GeoJSON `Feature`")
+
+	// errFeatureGeometry - a feature w. a geometry that isn't a point
+	errFeatureGeometry = errors.New("geometry must be a GeoJSON `Point`")
+
+	// errGeoJSONObject - a file w. a top-level value that isn't an object
+	errGeoJSONObject = errors.New("expected a GeoJSON `FeatureCollection` || `Feature` object")
+)
+
+// geoJSONInputFeature - a feature as read from a file, properties are kept raw so numeric values (e.g. ZIP codes)
+// are read as written
+type geoJSONInputFeature struct {
+	Type     string          `json:"type"`
+	ID       json.RawMessage `json:"id"`
+	Geometry *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// addressGeoJSONReader - streams addresses from the Point features of a GeoJSON file, either a FeatureCollection ||
+// one feature per line (e.g. OpenAddresses); features are decoded one at a time so memory stays flat regardless of
+// the size of the file. Features that aren't points || fail the mapping are rejected && skipped, invalid JSON stops
+// the read
+type addressGeoJSONReader struct {
+	src        io.Closer
+	dec        *json.Decoder
+	mapping    *columnMapping
+	rejects    *rejectWriter
+	top        map[string]json.RawMessage // members of the top-level object being read, nil between objects
+	inFeatures bool                       // reading the `features` of a FeatureCollection
+	numRead    int                        // features read so far, the "line" of rejected features
+}
+
+// openAddressGeoJSON - opens a file, malformed features are written to `rejectPath`; properties are read w. `mapping`
+// if set, else as the properties written by `--export`
+func openAddressGeoJSON(path, rejectPath string, mapping *columnMapping) (*addressGeoJSONReader, error) {
+	if mapping == nil {
+		mapping = geoJSONColumns
+	}
+	if err := mapping.validate(false); err != nil {
+		return nil, errors.Wrap(err, "failed mapping properties")
+	}
+
+	src, br, err := openInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &addressGeoJSONReader{
+		src:     src,
+		dec:     json.NewDecoder(br),
+		mapping: mapping,
+		rejects: &rejectWriter{path: rejectPath},
+	}, nil
+}
+
+// next - the next raw feature, either an element of `features` || a top-level feature
+func (gr *addressGeoJSONReader) next() (json.RawMessage, error) {
+	for {
+		if gr.inFeatures {
+			if gr.dec.More() {
+				var raw json.RawMessage
+				if err := gr.dec.Decode(&raw); err != nil {
+					return nil, err
+				}
+				return raw, nil
+			}
+
+			// the closing `]`, members after `features` are read as members of the collection
+			if _, err := gr.dec.Token(); err != nil {
+				return nil, err
+			}
+			gr.inFeatures = false
+		}
+
+		if gr.top == nil {
+			tok, err := gr.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if d, ok := tok.(json.Delim); !ok || (d != '{') {
+				return nil, errGeoJSONObject
+			}
+			gr.top = make(map[string]json.RawMessage)
+		}
+
+		// the end of a top-level object; a feature is returned, a collection's other members are discarded
+		if !gr.dec.More() {
+			if _, err := gr.dec.Token(); err != nil {
+				return nil, err
+			}
+
+			var typ string
+			top := gr.top
+			gr.top = nil
+			if err := json.Unmarshal(top["type"], &typ); (err == nil) && (typ == "Feature") {
+				return json.Marshal(top)
+			}
+			continue
+		}
+
+		tok, err := gr.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		if key == "features" {
+			if tok, err := gr.dec.Token(); err != nil {
+				return nil, err
+			} else if d, ok := tok.(json.Delim); !ok || (d != '[') {
+				return nil, errors.New("`features` must be an array")
+			}
+			gr.inFeatures = true
+			continue
+		}
+
+		var v json.RawMessage
+		if err := gr.dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		gr.top[key] = v
+	}
+}
+
+// Read - the next well-formed address, io.EOF once the file is exhausted
+func (gr *addressGeoJSONReader) Read() (*pb.Address, error) {
+	for {
+		raw, err := gr.next()
+		if (err == io.EOF) && (gr.top == nil) && !gr.inFeatures {
+			return nil, io.EOF
+		}
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading data file")
+		}
+		gr.numRead++
+
+		address, err := gr.featureToAddress(raw)
+		if err != nil {
+			if rerr := gr.rejects.Reject(gr.numRead, err, []string{string(raw)}); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+		return address, nil
+	}
+}
+
+// featureToAddress - parses a Point feature w. the mapping's properties, property names are matched
+// case-insensitively && the feature's `id` is used for an `id` property that isn't set
+func (gr *addressGeoJSONReader) featureToAddress(raw json.RawMessage) (*pb.Address, error) {
+	var f geoJSONInputFeature
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, err
+	}
+
+	if f.Type != "Feature" {
+		return nil, errFeatureType
+	}
+	if (f.Geometry == nil) || (f.Geometry.Type != "Point") {
+		return nil, errFeatureGeometry
+	}
+
+	var coords []float64
+	if err := json.Unmarshal(f.Geometry.Coordinates, &coords); (err != nil) || (len(coords) < 2) {
+		return nil, errFeatureGeometry
+	}
+
+	var props = make(map[string]string, len(f.Properties)+1)
+	if id := geoJSONValue(f.ID); id != "" {
+		props["id"] = id
+	}
+	for k, v := range f.Properties {
+		if v := geoJSONValue(v); v != "" {
+			props[strings.ToLower(k)] = v
+		}
+	}
+
+	address := gr.mapping.address(func(name string) string {
+		return props[strings.ToLower(name)]
+	})
+
+	// GeoJSON positions are always `[lng, lat]`
+	address.Location = &pb.Point{
+		Latitude:  float32(coords[1]),
+		Longitude: float32(coords[0]),
+	}
+	return address, nil
+}
+
+// geoJSONValue - a property as a string, numbers && booleans as written && null as ""
+func geoJSONValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	if v := strings.TrimSpace(string(raw)); v != "null" {
+		return v
+	}
+	return ""
+}
+
+// NumRejected - the number of malformed features skipped so far
+func (gr *addressGeoJSONReader) NumRejected() int {
+	return gr.rejects.numRejected
+}
+
+// Close - closes the file && the reject file
+func (gr *addressGeoJSONReader) Close() error {
+	rerr := gr.rejects.Close()
+	if err := gr.src.Close(); err != nil {
+		return err
+	}
+	return rerr
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	// standard lib
+	"bytes"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// openTestGeoJSON - writes `data` to a file && opens it w. `mapping`, the reader is closed w. the test
+func openTestGeoJSON(t *testing.T, data string, mapping *columnMapping) *addressGeoJSONReader {
+	t.Helper()
+	path := writeTestFile(t, "addresses.geojson", []byte(data))
+	r, err := openAddressGeoJSON(path, rejectFilePath(path), mapping)
+	if err != nil {
+		t.Fatalf("openAddressGeoJSON() = %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestAddressGeoJSONReaderFeatureCollection(t *testing.T) {
+	const data = `{
+		"type": "FeatureCollection",
+		"name": "addresses",
+		"features": [
+			{"type": "Feature", "id": "a1", "geometry": {"type": "Point", "coordinates": [-73.9479, 40.6812]},
+			 "properties": {"composite_street_address": "54 MACON ST", "house_number": "54", "postal_code": 11216}},
+			{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}, "properties": {"id": "bad"}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-74.0088, 40.706]},
+			 "properties": {"id": "a2", "composite_street_address": "23 WALL ST", "region": null}},
+			{"type": "Point", "coordinates": [0, 0]}
+		],
+		"crs": {"type": "name"}
+	}`
+
+	r := openTestGeoJSON(t, data, nil)
+	addresses := readAllAddresses(t, r)
+	if len(addresses) != 2 {
+		t.Fatalf("read %d addresses, want 2", len(addresses))
+	}
+
+	// the feature's `id` is used for an unset `id` property, numeric properties are read as written
+	a1 := addresses[0]
+	if (a1.Id != "a1") || (a1.HouseNumber != "54") || (a1.PostalCode != "11216") {
+		t.Errorf("a1 = %+v", a1)
+	}
+	if (a1.Location.Latitude != 40.6812) || (a1.Location.Longitude != -73.9479) {
+		t.Errorf("a1 location = %v, want (40.6812, -73.9479)", a1.Location)
+	}
+	if a2 := addresses[1]; (a2.Id != "a2") || (a2.Region != "") {
+		t.Errorf("a2 = %+v", a2)
+	}
+
+	if r.NumRejected() != 2 {
+		t.Errorf("NumRejected() = %d, want 2", r.NumRejected())
+	}
+}
+
+func TestAddressGeoJSONReaderFeaturePerLine(t *testing.T) {
+	const data = `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-73.9479, 40.6812]}, "properties": {"HASH": "h1", "NUMBER": "54", "STREET": "MACON ST", "CITY": "BROOKLYN"}}
+{"type": "Feature", "geometry": null, "properties": {"HASH": "h2", "NUMBER": "1", "STREET": "MAIN ST"}}
+{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-74.0088, 40.706]}, "properties": {"ID": "i3", "HASH": "h3", "NUMBER": "23", "STREET": "WALL ST"}}
+`
+
+	// OpenAddresses GeoJSON uses the same properties as its CSV columns
+	r := openTestGeoJSON(t, data, openAddressesColumns)
+	addresses := readAllAddresses(t, r)
+
+	var ids []string
+	for _, a := range addresses {
+		ids = append(ids, a.Id)
+	}
+	if want := []string{"h1", "i3"}; !equalStrings(ids, want) {
+		t.Errorf("read ids = %v, want %v", ids, want)
+	}
+	if (len(addresses) > 0) && (addresses[0].CompositeStreetAddress != "54 MACON ST BROOKLYN") {
+		t.Errorf("composite address = %q, want `54 MACON ST BROOKLYN`", addresses[0].CompositeStreetAddress)
+	}
+	if r.NumRejected() != 1 {
+		t.Errorf("NumRejected() = %d, want 1", r.NumRejected())
+	}
+}
+
+func TestAddressGeoJSONReaderInvalid(t *testing.T) {
+	for _, data := range []string{
+		`[{"type": "Feature"}]`,
+		`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}`,
+		`{"type": "FeatureCollection", "features": {}}`,
+	} {
+		r := openTestGeoJSON(t, data, nil)
+		if _, err := r.Read(); err == nil {
+			t.Errorf("Read(%.30q) = nil, want an error", data)
+		}
+	}
+}
+
+// TestGeoJSONExportRoundTrip - files written by `--export-format geojson` can be loaded w. `--file`
+func TestGeoJSONExportRoundTrip(t *testing.T) {
+	addresses := []*pb.Address{
+		{Id: "a1", CompositeStreetAddress: "54 MACON ST BROOKLYN NEW YORK 11216", Location: &pb.Point{Latitude: 40.6812, Longitude: -73.9479},
+			HouseNumber: "54", Street: "MACON ST", Locality: "BROOKLYN", PostalCode: "11216", Region: "NEW YORK"},
+		{Id: "a2", CompositeStreetAddress: "1 MAIN ST SPRINGFIELD IL", Location: &pb.Point{Latitude: 39.8, Longitude: -89.65}, Region: "IL"},
+	}
+
+	var buf bytes.Buffer
+	w, err := newAddressWriter(&buf, "geojson")
+	if err != nil {
+		t.Fatalf("newAddressWriter() = %v", err)
+	}
+	for _, a := range addresses {
+		if err := w.Write(a); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	got := readAllAddresses(t, openTestGeoJSON(t, buf.String(), nil))
+	if len(got) != len(addresses) {
+		t.Fatalf("read %d addresses, want %d", len(got), len(addresses))
+	}
+	for i := range addresses {
+		want := addresses[i]
+		if (got[i].Id != want.Id) || (got[i].CompositeStreetAddress != want.CompositeStreetAddress) ||
+			(got[i].HouseNumber != want.HouseNumber) || (got[i].Street != want.Street) || (got[i].Locality != want.Locality) ||
+			(got[i].PostalCode != want.PostalCode) || (got[i].Region != want.Region) ||
+			(got[i].Location.Latitude != want.Location.Latitude) || (got[i].Location.Longitude != want.Location.Longitude) {
+			t.Errorf("address %d = %+v, want %+v", i, got[i], want)
+		}
+	}
+}
@@ -3,13 +3,14 @@ package main
 import (
 
 	// standard lib
-	"bufio"
-	"bytes"
 	"context"
+	"encoding/json"
 	"flag"
+	"fmt"
 	"github.com/pkg/errors"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -27,97 +28,235 @@ var (
 	rpcServerPort = flag.Int("rpc-server-port", 50052, "port of the gcaas grpc server to forward ingesst requests")
 
 	// file processing options
-	targetFile = flag.String("file", "./../misc/data-processing/_data/prepared_nyc.csv", "The file to load for geocoder demo")
+	targetFile   = flag.String("file", "./../misc/data-processing/_data/prepared_nyc.csv", "The file to load for geocoder demo")
+	rejectFile   = flag.String("reject-file", "", "(optional) file to write malformed rows of `--file` (|| `--segments-file`) to w. their line && the reason; the input file w. a `.rejects.csv` suffix if unset")
+	segmentsFile = flag.String("segments-file", "", "(optional) street segments file to load for `FWD_INTERPOLATED` geocoding, loaded instead of `--file`")
+	dataset      = flag.String("dataset", "", "(optional) dataset to load addresses && street segments into, created if it doesn't exist; the default dataset if unset")
+
+	// input format options
+	inputFormatFlag = flag.String("input-format", "", "(optional) format of `--file`, one of `csv`, `geojson` (a FeatureCollection || one feature per line) or `osm-pbf`; inferred from the extension if unset, CSV && GeoJSON files may be gzipped")
+	openAddresses   = flag.Bool("openaddresses", false, "(optional) read `--file` w. the OpenAddresses schema (`LON,LAT,NUMBER,STREET,UNIT,CITY,DISTRICT,REGION,POSTCODE,ID,HASH`)")
+
+	// column mapping options, w.o. `--id-column` CSV files must use the NYC layout && GeoJSON files the properties of `--export`
+	idColumn          = flag.String("id-column", "", "(optional) header of the address id column of `--file`, enables the `--*-column` options")
+	addressColumns    = flag.String("address-columns", "", "(optional) comma-separated headers joined w. spaces to form the composite address, e.g. `number,street,city,zip`")
+	latColumn         = flag.String("lat-column", "", "(optional) header of the latitude column, use w. `--lng-column`")
+	lngColumn         = flag.String("lng-column", "", "(optional) header of the longitude column, use w. `--lat-column`")
+	geometryColumn    = flag.String("geometry-column", "", "(optional) header of a WKT point column, used instead of `--lat-column` && `--lng-column`")
+	geometryOrder     = flag.String("geometry-order", "lnglat", "coordinate order of `--geometry-column`, one of `lnglat` (WKT) or `latlng`")
+	houseNumberColumn = flag.String("house-number-column", "", "(optional) header of the house number column")
+	streetColumn      = flag.String("street-column", "", "(optional) header of the street column")
+	localityColumn    = flag.String("locality-column", "", "(optional) header of the locality column")
+	postalCodeColumn  = flag.String("postal-code-column", "", "(optional) header of the postal code column")
+	regionColumn      = flag.String("region-column", "", "(optional) header of the region column")
+	defaultRegion     = flag.String("region", "", "(optional) region of addresses w.o. a `--region-column` value")
+
+	// reload options
+	reload      = flag.Bool("reload", false, "(optional) load `--file` into a new version of the dataset && swap it in once loaded, addresses missing from `--file` are removed")
+	allowShrink = flag.Bool("allow-shrink", false, "(optional) w. `--reload`, swap in the new version even if it has far fewer addresses than the current version")
+	jobID       = flag.String("job-id", "", "(optional) resume the ingest job w. this id from its last committed address, `--file` must be the same file")
+	chunkSize   = flag.Int("chunk-size", 1024, "number of addresses per chunk of an ingest job, each chunk is acknowledged once committed")
+	dropVersion = flag.Int64("drop-version", 0, "(optional) drop this unserved version of `--dataset` (e.g. left by a `--reload` that failed) instead of loading `--file`, live changes are refused while it exists")
+	dryRun      = flag.Bool("dry-run", false, "(optional) validate `--file` on the server w.o. writing any addresses, reports the addresses that would be rejected")
+
+	// export options
+	exportFile     = flag.String("export", "", "(optional) write the addresses of `--dataset` being served to this file instead of loading `--file`")
+	exportFormat   = flag.String("export-format", "csv", "format of `--export`, one of `csv` (same columns as `--file`) or `geojson`")
+	exportBBox     = flag.String("export-bbox", "", "(optional) w. `--export`, only export addresses within `min_lat,min_lng,max_lat,max_lng`")
+	exportIDPrefix = flag.String("export-id-prefix", "", "(optional) w. `--export`, only export addresses w. an id starting w. this prefix")
+
+	// boundary options
+	boundariesFile       = flag.String("boundaries-file", "", "(optional) GeoJSON FeatureCollection of boundaries to load, loaded instead of `--file`")
+	boundaryLayer        = flag.String("boundary-layer", "", "layer of all boundaries in `--boundaries-file`, e.g. `borough`, `zcta`")
+	boundaryIDProperty   = flag.String("boundary-id-property", "id", "feature property to use as the boundary id")
+	boundaryNameProperty = flag.String("boundary-name-property", "name", "feature property to use as the boundary name")
 )
 
-// nColsDOBReport ...
+// expectedColumnsInputData - id, location, composite address
 const expectedColumnsInputData = 3
 
-// slimLineCounter ...
-// https://stackoverflow.com/questions/24562942/golang-how-do-i-determine-the-number-of-lines-in-a-file-efficiently
-func slimLineCounter(r io.Reader) (numTotalLines int, err error) {
+// expectedColumnsComponentData - id, location, composite address, house number, street, borough, ZIP
+const expectedColumnsComponentData = 7
 
-	buf := make([]byte, bufio.MaxScanTokenSize)
+// expectedColumnsRegionData - the component layout w. a region, as written by `--export`
+const expectedColumnsRegionData = 8
 
-	for {
-		// read through contents of io.Reader
-		var buffPosition int
+// expectedColumnsSegmentData - id, street, borough, ZIP, left from, left to, right from, right to, geometry
+// (as `lat lng;lat lng;...`)
+const expectedColumnsSegmentData = 9
 
-		bufferSize, err := r.Read(buf)
-		if err != nil && err != io.EOF {
-			log.WithFields(log.Fields{
-				"err": err,
-			}).Error("failed reading from buffer")
-			return 0, err
-		}
+// openIngestJob - resumes the job named by `--job-id` or creates a new job for a file; `version` is ignored when
+// resuming
+func openIngestJob(client pb.ManagementClient, path string, version int64) (*pb.IngestJob, error) {
 
-		// read to next newline char - adding to the count of lines and current position
-		for {
-			i := bytes.IndexByte(buf[buffPosition:], '\n')
-			if i == -1 || bufferSize == buffPosition {
-				break
-			}
-			buffPosition += i + 1
-			numTotalLines++
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
 
-		if err == io.EOF {
-			return numTotalLines, nil
-		}
+	if *jobID == "" {
+		return client.CreateIngestJob(ctx, &pb.CreateIngestJobRequest{
+			Dataset: *dataset,
+			Version: version,
+			Source:  filepath.Base(path),
+		})
+	}
+
+	job, err := client.GetIngestJob(ctx, &pb.GetIngestJobRequest{JobId: *jobID})
+	if err != nil {
+		return nil, err
+	}
+
+	// offsets are only meaningful for the same file
+	if job.Source != filepath.Base(path) {
+		return nil, fmt.Errorf("job `%s` is loading `%s`, not `%s`", job.JobId, job.Source, filepath.Base(path))
 	}
+	return job, nil
 }
 
-// fileToAddressProtoArray -
-func fileToAddressProtoArray(fp string) ([]*pb.Address, error) {
+// ingestAddressData - loads a file in chunks w. a resumable ingest job, chunks are sent while earlier chunks are
+// acknowledged; returns the job once every address is committed, nil if the load fails (&& can be resumed)
+func ingestAddressData(client pb.ManagementClient, path string, version int64) *pb.IngestJob {
 
-	// open file && pcount the number of addresses to allocate []*pb.Address with fixed length
-	fi, err := os.Open(fp)
+	job, err := openIngestJob(client, path, version)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed reading data file")
+		log.WithFields(log.Fields{
+			"err":    err,
+			"job_id": *jobID,
+		}).Error("failed opening ingest job")
+		return nil
 	}
 
-	nAddresses, err := slimLineCounter(fi)
+	reader, err := openAddressReader(path, rejectFilePath(path), inputColumns)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"filepath": path,
+		}).Error("failed processing source file")
+		return nil
+	}
+	defer reader.Close()
+
+	log.WithFields(log.Fields{
+		"job_id":           job.JobId,
+		"dataset":          job.Dataset,
+		"version":          job.Version,
+		"committed_offset": job.CommittedOffset,
+	}).Info("starting ingest job")
+
+	// no deadline, each chunk is bounded by the server && a failed load resumes from the last acknowledged chunk
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.IngestAddresses(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed counting")
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("/geocoder.Management/IngestAddresses; failed initializing stream")
+		return nil
 	}
 
-	// init pre-allocated array for addresses - assumes a small-ish number of addresses (10**7)
-	var addresses = make([]*pb.Address, nAddresses-1)
-	var addrIdx = 0
+	// send errors surface from `Recv`, which returns the stream's status; a read error cancels the stream
+	go func() {
+		if err := sendIngestChunks(stream, job, reader); err != nil {
+			log.WithFields(log.Fields{
+				"err":      err,
+				"filepath": path,
+			}).Error("failed processing source file")
+			cancel()
+			return
+		}
+		stream.CloseSend()
+	}()
 
-	fi.Seek(0, 0)
-	scanner := bufio.NewScanner(fi)
+	for {
+		ack, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":              err,
+				"job_id":           job.JobId,
+				"committed_offset": job.CommittedOffset,
+			}).Error("/geocoder.Management/IngestAddresses; failed, resume w. `--job-id`")
+			return nil
+		}
 
-	for scanner.Scan() {
-		if addrIdx > 0 {
-			// Extract Location and Address Data From CSV
-			content := strings.Split(scanner.Text(), ",")
+		for _, r := range ack.RejectedSamples {
+			log.WithFields(log.Fields{
+				"address.id": r.Id,
+				"reason":     r.Reason,
+			}).Warn("/geocoder.Management/IngestAddresses; rejected address")
+		}
 
-			if len(content) != expectedColumnsInputData {
-				log.WithFields(log.Fields{
-					"n_expected_elems": expectedColumnsInputData,
-					"n_observed_elems": len(content),
-				}).Warn("unexpected data struct; skipping")
-			}
+		job.CommittedOffset = ack.CommittedOffset
+		job.TotalObjectsRejected += int64(ack.TotalObjectsRejected)
 
-			// NOTE: DANGEROUS FLAG...
-			addrLocation := srv.PointFromLocationString(content[1], true)
+		log.WithFields(log.Fields{
+			"job_id":           job.JobId,
+			"committed_offset": job.CommittedOffset,
+		}).Debug("/geocoder.Management/IngestAddresses; chunk committed")
+	}
+
+	log.WithFields(log.Fields{
+		"job_id":              job.JobId,
+		"insert.num_objects":  job.CommittedOffset - job.TotalObjectsRejected,
+		"insert.num_rejected": job.TotalObjectsRejected,
+		"read.num_malformed":  reader.NumRejected(),
+	}).Info("/geocoder.Management/IngestAddresses; success")
+
+	return job
+}
+
+// sendIngestChunks - reads the file in chunks of `--chunk-size` addresses && sends each chunk, the addresses before
+// the job's committed offset are read && skipped
+func sendIngestChunks(stream pb.Management_IngestAddressesClient, job *pb.IngestJob, reader addressReader) error {
+
+	var offset int64
+	for ; offset < job.CommittedOffset; offset++ {
+		if _, err := reader.Read(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	chunk := &pb.IngestChunk{JobId: job.JobId, Offset: offset}
+	for {
+		address, err := reader.Read()
+		if (err != nil) && (err != io.EOF) {
+			return err
+		}
 
-			// Compose result and apppend to addresses to send
-			addresses[addrIdx-1] = &pb.Address{
-				Id:                     content[0],
-				Location:               addrLocation,
-				CompositeStreetAddress: content[2],
+		if address != nil {
+			chunk.Addresses = append(chunk.Addresses, address)
+		}
+
+		if (len(chunk.Addresses) >= *chunkSize) || ((err == io.EOF) && (len(chunk.Addresses) > 0)) {
+			if serr := stream.Send(chunk); serr != nil {
+				return nil
 			}
+			offset += int64(len(chunk.Addresses))
+			chunk = &pb.IngestChunk{JobId: job.JobId, Offset: offset}
+		}
+
+		if err == io.EOF {
+			return nil
 		}
-		addrIdx++
 	}
+}
 
-	return addresses, nil
+// rejectFilePath - `--reject-file`, || the input file w. a `.rejects.csv` suffix (replacing its extension && `.gz`)
+func rejectFilePath(path string) string {
+	if *rejectFile != "" {
+		return *rejectFile
+	}
+	path = strings.TrimSuffix(path, ".gz")
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".rejects.csv"
 }
 
-// writeAddressData sends a sequence of points to server and expects to get a RouteSummary from server.
-func writeAddressData(client pb.ManagementClient, path string) {
+// writeAddressData sends a sequence of points to server and expects to get a RouteSummary from server; addresses are
+// written to `version` of the dataset (`srv.LiveVersion` for the version being served), returns the number written
+func writeAddressData(client pb.ManagementClient, path string, version int64) int32 {
 
 	// defer cancellation until done
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*180)
@@ -129,23 +268,44 @@ func writeAddressData(client pb.ManagementClient, path string) {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("/geocoder.Management/InsertorReplaceAddressData; failed initializing stream")
+		return 0
 	}
 
-	// iterate thru the parsed csv data, queuing up to the server
-	addresses, err := fileToAddressProtoArray(path)
+	// iterate thru the csv data, sending each address as it's parsed
+	reader, err := openAddressReader(path, rejectFilePath(path), inputColumns)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err":      err,
 			"filepath": path,
 		}).Error("failed processing source file")
+		return 0
 	}
+	defer reader.Close()
+
+	for {
+		a, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":      err,
+				"filepath": path,
+			}).Error("failed processing source file")
+			return 0
+		}
 
-	for _, a := range addresses {
+		a.Dataset = *dataset
+		a.Version = version
+		a.DryRun = *dryRun
+
+		// the stream's error is returned by `CloseAndRecv`
 		if err := stream.Send(a); err != nil {
 			log.WithFields(log.Fields{
 				"err": err,
 				"msg": a,
 			}).Error("/geocoder.Management/InsertorReplaceAddressData; failed stream.Send()")
+			break
 		}
 	}
 
@@ -155,22 +315,317 @@ func writeAddressData(client pb.ManagementClient, path string) {
 		log.WithFields(log.Fields{
 			"err": err,
 		}).Error("/geocoder.Management/InsertorReplaceAddressData; failed recv")
+		return 0
+	}
+
+	for _, r := range reply.RejectedSamples {
+		log.WithFields(log.Fields{
+			"address.id": r.Id,
+			"reason":     r.Reason,
+		}).Warn("/geocoder.Management/InsertorReplaceAddressData; rejected address")
+	}
+
+	log.WithFields(log.Fields{
+		"insert.success":      reply.Success,
+		"insert.num_objects":  reply.TotalObjectsWritten,
+		"insert.num_rejected": reply.TotalObjectsRejected,
+		"insert.dry_run":      reply.DryRun,
+		"read.num_malformed":  reader.NumRejected(),
+	}).Info("/geocoder.Management/InsertorReplaceAddressData; success")
+
+	return reply.TotalObjectsWritten
+}
+
+// reloadAddressData - loads a file into a new version of the dataset && swaps it in, the version being served is
+// untouched if any step fails && the new version is kept to be resumed w. `--job-id` (|| dropped w. `--drop-version`)
+func reloadAddressData(client pb.ManagementClient, path string) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	// a resumed job already has its version
+	var version int64
+	if *jobID == "" {
+		created, err := client.CreateDatasetVersion(ctx, &pb.CreateDatasetVersionRequest{
+			Dataset: *dataset,
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err": err,
+			}).Error("/geocoder.Management/CreateDatasetVersion; failed")
+			return
+		}
+		version = created.Version
+	}
+
+	job := ingestAddressData(client, path, version)
+	if job == nil {
+		return
+	}
+
+	if job.Version == srv.LiveVersion {
+		log.WithFields(log.Fields{
+			"job_id": job.JobId,
+		}).Error("job wasn't loading a new version; not promoting")
+		return
+	}
+
+	n := job.CommittedOffset - job.TotalObjectsRejected
+	if n == 0 {
+		log.WithFields(log.Fields{
+			"job_id":  job.JobId,
+			"version": job.Version,
+		}).Error("no addresses written to new version; not promoting")
+		return
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	promoted, err := client.PromoteDatasetVersion(ctx, &pb.PromoteDatasetVersionRequest{
+		Dataset:       job.Dataset,
+		Version:       job.Version,
+		ExpectedCount: int32(n),
+		AllowShrink:   *allowShrink,
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"version": job.Version,
+			"err":     err,
+		}).Error("/geocoder.Management/PromoteDatasetVersion; failed")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"dataset":                promoted.Dataset,
+		"version":                promoted.Version,
+		"num_addresses":          promoted.NumAddresses,
+		"previous_num_addresses": promoted.PreviousNumAddresses,
+		"num_versions_dropped":   promoted.NumVersionsDropped,
+	}).Info("/geocoder.Management/PromoteDatasetVersion; success")
+}
+
+// dropAddressVersion - deletes an unserved version of the dataset, e.g. a reload that won't be resumed
+func dropAddressVersion(client pb.ManagementClient, version int64) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	dropped, err := client.DropDatasetVersion(ctx, &pb.DropDatasetVersionRequest{
+		Dataset: *dataset,
+		Version: version,
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"version": version,
+			"err":     err,
+		}).Error("/geocoder.Management/DropDatasetVersion; failed")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"dataset":       dropped.Dataset,
+		"version":       dropped.Version,
+		"num_addresses": dropped.NumAddresses,
+	}).Info("/geocoder.Management/DropDatasetVersion; success")
+}
+
+// writeStreetSegmentData - streams the street segments in a file to the management server as they're read, returns
+// the number written
+func writeStreetSegmentData(client pb.ManagementClient, path string) int32 {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*180)
+	defer cancel()
+
+	reader, err := openSegmentCSV(path, rejectFilePath(path))
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"filepath": path,
+		}).Error("failed processing source file")
+		return 0
+	}
+	defer reader.Close()
+
+	stream, err := client.InsertorReplaceStreetSegmentData(ctx)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("/geocoder.Management/InsertorReplaceStreetSegmentData; failed initializing stream")
+		return 0
+	}
+
+	for {
+		seg, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":      err,
+				"filepath": path,
+			}).Error("failed processing source file")
+			return 0
+		}
+
+		seg.Dataset = *dataset
+
+		// the stream's error is returned by `CloseAndRecv`
+		if err := stream.Send(seg); err != nil {
+			log.WithFields(log.Fields{
+				"err": err,
+				"msg": seg,
+			}).Error("/geocoder.Management/InsertorReplaceStreetSegmentData; failed stream.Send()")
+			break
+		}
+	}
+
+	reply, err := stream.CloseAndRecv()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("/geocoder.Management/InsertorReplaceStreetSegmentData; failed recv")
+		return 0
 	}
 
 	log.WithFields(log.Fields{
 		"insert.success":     reply.Success,
 		"insert.num_objects": reply.TotalObjectsWritten,
-	}).Info("/geocoder.Management/InsertorReplaceAddressData; success")
+		"read.num_malformed": reader.NumRejected(),
+	}).Info("/geocoder.Management/InsertorReplaceStreetSegmentData; success")
+
+	return reply.TotalObjectsWritten
+}
+
+// geoJSONFeatureCollection - the subset of a GeoJSON FeatureCollection used for boundaries
+type geoJSONFeatureCollection struct {
+	Features []struct {
+		Properties map[string]interface{} `json:"properties"`
+		Geometry   json.RawMessage        `json:"geometry"`
+	} `json:"features"`
+}
+
+// fileToBoundaryProtoArray - reads a GeoJSON FeatureCollection, all features are assigned to `layer`
+func fileToBoundaryProtoArray(fp, layer, idProperty, nameProperty string) ([]*pb.Boundary, error) {
+
+	fi, err := os.Open(fp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading data file")
+	}
+	defer fi.Close()
 
+	var fc geoJSONFeatureCollection
+	if err := json.NewDecoder(fi).Decode(&fc); err != nil {
+		return nil, errors.Wrap(err, "failed decoding GeoJSON")
+	}
+
+	boundaries := make([]*pb.Boundary, len(fc.Features))
+	for i, f := range fc.Features {
+		boundaries[i] = &pb.Boundary{
+			Layer:   layer,
+			Geojson: string(f.Geometry),
+		}
+		if v, ok := f.Properties[idProperty]; ok {
+			boundaries[i].Id = fmt.Sprint(v)
+		}
+		if v, ok := f.Properties[nameProperty]; ok {
+			boundaries[i].Name = fmt.Sprint(v)
+		}
+	}
+	return boundaries, nil
+}
+
+// writeBoundaryData - streams all boundaries in a file to the management server
+func writeBoundaryData(client pb.ManagementClient, path string) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*180)
+	defer cancel()
+
+	boundaries, err := fileToBoundaryProtoArray(path, *boundaryLayer, *boundaryIDProperty, *boundaryNameProperty)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"filepath": path,
+		}).Error("failed processing source file")
+		return
+	}
+
+	stream, err := client.InsertorReplaceBoundaryData(ctx)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("/geocoder.Management/InsertorReplaceBoundaryData; failed initializing stream")
+		return
+	}
+
+	for _, bnd := range boundaries {
+		if err := stream.Send(bnd); err != nil {
+			log.WithFields(log.Fields{
+				"err":         err,
+				"boundary.id": bnd.Id,
+			}).Error("/geocoder.Management/InsertorReplaceBoundaryData; failed stream.Send()")
+		}
+	}
+
+	reply, err := stream.CloseAndRecv()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Error("/geocoder.Management/InsertorReplaceBoundaryData; failed recv")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"insert.success":     reply.Success,
+		"insert.num_objects": reply.TotalObjectsWritten,
+	}).Info("/geocoder.Management/InsertorReplaceBoundaryData; success")
 }
 
 func main() {
 	flag.Parse()
 
+	if *chunkSize < 1 {
+		log.WithFields(log.Fields{
+			"chunk_size": *chunkSize,
+		}).Fatal("`--chunk-size` must be at least 1")
+	}
+
+	var err error
+	if inputColumns, err = columnMappingFromFlags(); err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Fatal("invalid column mapping")
+	}
+
 	// Init client and insert test data
 	managementConn := srv.MustRPCClient(*rpcServerHost, *rpcServerPort)
 	managementClient := pb.NewManagementClient(managementConn)
 
+	if *exportFile != "" {
+		exportAddressData(managementClient, *exportFile)
+		return
+	}
+
 	// write the target file to the redis instance
-	writeAddressData(managementClient, *targetFile)
+	if *boundariesFile != "" {
+		writeBoundaryData(managementClient, *boundariesFile)
+		return
+	}
+	if *segmentsFile != "" {
+		writeStreetSegmentData(managementClient, *segmentsFile)
+		return
+	}
+	if *dropVersion != 0 {
+		dropAddressVersion(managementClient, *dropVersion)
+		return
+	}
+	if *dryRun {
+		writeAddressData(managementClient, *targetFile, srv.LiveVersion)
+		return
+	}
+	if *reload {
+		reloadAddressData(managementClient, *targetFile)
+		return
+	}
+	ingestAddressData(managementClient, *targetFile, srv.LiveVersion)
 }
@@ -0,0 +1,207 @@
+package main
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeManagementClient - serves a single ingest job && acknowledges every chunk sent to it, opening any other stream
+// fails w. `streamErr`; all other calls panic
+type fakeManagementClient struct {
+	pb.ManagementClient
+	job       *pb.IngestJob
+	chunks    []*pb.IngestChunk // all chunks sent, in order
+	segments  []*pb.StreetSegment
+	sends     int   // calls to `Send`, including failed calls
+	sendErr   error // returned by `Send` once a segment has been sent, && by `CloseAndRecv`
+	streamErr error
+}
+
+func (f *fakeManagementClient) InsertorReplaceStreetSegmentData(ctx context.Context, opts ...grpc.CallOption) (pb.Management_InsertorReplaceStreetSegmentDataClient, error) {
+	if f.streamErr != nil {
+		return nil, f.streamErr
+	}
+	return &fakeSegmentStream{client: f}, nil
+}
+
+func (f *fakeManagementClient) InsertorReplaceAddressData(ctx context.Context, opts ...grpc.CallOption) (pb.Management_InsertorReplaceAddressDataClient, error) {
+	return nil, f.streamErr
+}
+
+func (f *fakeManagementClient) GetIngestJob(ctx context.Context, req *pb.GetIngestJobRequest, opts ...grpc.CallOption) (*pb.IngestJob, error) {
+	return &pb.IngestJob{
+		JobId:           f.job.JobId,
+		Dataset:         f.job.Dataset,
+		Version:         f.job.Version,
+		Source:          f.job.Source,
+		CommittedOffset: f.job.CommittedOffset,
+	}, nil
+}
+
+func (f *fakeManagementClient) IngestAddresses(ctx context.Context, opts ...grpc.CallOption) (pb.Management_IngestAddressesClient, error) {
+	return &fakeIngestStream{client: f, acks: make(chan *pb.IngestAck, 64)}, nil
+}
+
+// fakeIngestStream - commits each chunk on `Send`, acks are returned by `Recv` until `CloseSend`
+type fakeIngestStream struct {
+	grpc.ClientStream
+	client *fakeManagementClient
+	acks   chan *pb.IngestAck
+}
+
+func (s *fakeIngestStream) Send(chunk *pb.IngestChunk) error {
+	s.client.chunks = append(s.client.chunks, chunk)
+	s.acks <- &pb.IngestAck{JobId: chunk.JobId, CommittedOffset: chunk.Offset + int64(len(chunk.Addresses))}
+	return nil
+}
+
+func (s *fakeIngestStream) CloseSend() error {
+	close(s.acks)
+	return nil
+}
+
+func (s *fakeIngestStream) Recv() (*pb.IngestAck, error) {
+	ack, ok := <-s.acks
+	if !ok {
+		return nil, io.EOF
+	}
+	return ack, nil
+}
+
+// fakeSegmentStream - keeps each segment sent, fails after the first if the client has a `sendErr`
+type fakeSegmentStream struct {
+	grpc.ClientStream
+	client *fakeManagementClient
+}
+
+func (s *fakeSegmentStream) Send(seg *pb.StreetSegment) error {
+	s.client.sends++
+	if (s.client.sendErr != nil) && (len(s.client.segments) > 0) {
+		return s.client.sendErr
+	}
+	s.client.segments = append(s.client.segments, seg)
+	return nil
+}
+
+func (s *fakeSegmentStream) CloseAndRecv() (*pb.IOResponse, error) {
+	if s.client.sendErr != nil {
+		return nil, s.client.sendErr
+	}
+	return &pb.IOResponse{Success: true, TotalObjectsWritten: int32(len(s.client.segments))}, nil
+}
+
+// writeTestAddressFile - writes `n` addresses to `addresses.csv` in a temp. directory, returns its path
+func writeTestAddressFile(t *testing.T, n int) string {
+	t.Helper()
+
+	addresses := make([]*pb.Address, n)
+	for i := range addresses {
+		addresses[i] = &pb.Address{
+			Id:                     fmt.Sprintf("%d", i+1),
+			CompositeStreetAddress: fmt.Sprintf("%d MACON ST BROOKLYN NEW YORK 11216", i+1),
+			Location:               &pb.Point{Latitude: 40.6812, Longitude: -73.9479},
+		}
+	}
+
+	return writeTestFile(t, "addresses.csv", exportAll(t, "csv", addresses))
+}
+
+// setFlag - sets a flag's value for the duration of a test
+func setFlag[T any](t *testing.T, flag *T, value T) {
+	t.Helper()
+	prev := *flag
+	*flag = value
+	t.Cleanup(func() { *flag = prev })
+}
+
+func TestIngestAddressDataResumes(t *testing.T) {
+	path := writeTestAddressFile(t, 10)
+	setFlag(t, jobID, "job-1")
+	setFlag(t, chunkSize, 4)
+
+	client := &fakeManagementClient{
+		job: &pb.IngestJob{JobId: "job-1", Source: "addresses.csv", CommittedOffset: 4},
+	}
+
+	job := ingestAddressData(client, path, 0)
+	if (job == nil) || (job.CommittedOffset != 10) {
+		t.Fatalf("ingestAddressData() = %v, want a job committed through offset 10", job)
+	}
+
+	// only the addresses past the committed offset are sent
+	var want = []struct {
+		offset  int64
+		firstID string
+		n       int
+	}{
+		{4, "5", 4},
+		{8, "9", 2},
+	}
+	if len(client.chunks) != len(want) {
+		t.Fatalf("sent %d chunks, want %d", len(client.chunks), len(want))
+	}
+	for i, c := range client.chunks {
+		if (c.JobId != "job-1") || (c.Offset != want[i].offset) || (len(c.Addresses) != want[i].n) || (c.Addresses[0].Id != want[i].firstID) {
+			t.Errorf("chunk %d = (%d, %d addresses from %s), want (%d, %d addresses from %s)",
+				i, c.Offset, len(c.Addresses), c.Addresses[0].Id, want[i].offset, want[i].n, want[i].firstID)
+		}
+	}
+}
+
+func TestIngestAddressDataResumeSourceMismatch(t *testing.T) {
+	path := writeTestAddressFile(t, 10)
+	setFlag(t, jobID, "job-1")
+
+	client := &fakeManagementClient{
+		job: &pb.IngestJob{JobId: "job-1", Source: "other.csv", CommittedOffset: 4},
+	}
+
+	// offsets of a job are only meaningful for its own file
+	if job := ingestAddressData(client, path, 0); job != nil {
+		t.Errorf("ingestAddressData() = %v, want nil", job)
+	}
+	if len(client.chunks) != 0 {
+		t.Errorf("sent %d chunks, want 0", len(client.chunks))
+	}
+}
+
+func TestWriteAddressDataStreamError(t *testing.T) {
+	path := writeTestAddressFile(t, 2)
+	client := &fakeManagementClient{streamErr: status.Error(codes.Unavailable, "unavailable")}
+
+	if n := writeAddressData(client, path, 0); n != 0 {
+		t.Errorf("writeAddressData() = %d, want 0", n)
+	}
+}
+
+func TestWriteStreetSegmentData(t *testing.T) {
+	path := writeTestFile(t, "segments.csv", []byte(testSegmentsCSV))
+	setFlag(t, dataset, "nj")
+
+	client := &fakeManagementClient{}
+	if n := writeStreetSegmentData(client, path); n != 3 {
+		t.Errorf("writeStreetSegmentData() = %d, want 3", n)
+	}
+	for _, seg := range client.segments {
+		if seg.Dataset != "nj" {
+			t.Errorf("segment %s sent to dataset %q, want nj", seg.Id, seg.Dataset)
+		}
+	}
+
+	// the stream stops at the first failed send, the stream's error is returned by `CloseAndRecv`
+	client = &fakeManagementClient{sendErr: status.Error(codes.InvalidArgument, "invalid segment")}
+	if n := writeStreetSegmentData(client, path); (n != 0) || (client.sends != 2) {
+		t.Errorf("writeStreetSegmentData() = %d (%d sends), want 0 (2 sends)", n, client.sends)
+	}
+}
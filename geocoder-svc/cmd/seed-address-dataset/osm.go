@@ -0,0 +1,619 @@
+package main
+
+import (
+
+	// standard lib
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// osmMaxBlobHeaderSize, osmMaxBlobSize - limits from the PBF spec, larger sizes are a corrupt file
+const (
+	osmMaxBlobHeaderSize = 64 * 1024
+	osmMaxBlobSize       = 32 * 1024 * 1024
+)
+
+// osmSupportedFeatures - required features of a PBF file that the reader can decode
+var osmSupportedFeatures = map[string]bool{
+	"OsmSchema-V0.6": true,
+	"DenseNodes":     true,
+}
+
+// errWayOutsideExtract - a way that references nodes that aren't in the file, common at the edges of an extract
+var errWayOutsideExtract = errors.New("way references nodes outside the extract")
+
+// osmField - a single field of a protobuf message, `v` is set for varints && `b` for length-delimited fields
+type osmField struct {
+	num protowire.Number
+	v   uint64
+	b   []byte
+}
+
+// osmEachField - calls `fn` w. each varint && length-delimited field of a message, other fields are skipped
+func osmEachField(msg []byte, fn func(f osmField) error) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		var f = osmField{num: num}
+		switch typ {
+		case protowire.VarintType:
+			f.v, n = protowire.ConsumeVarint(msg)
+		case protowire.BytesType:
+			f.b, n = protowire.ConsumeBytes(msg)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, msg)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		if (typ == protowire.VarintType) || (typ == protowire.BytesType) {
+			if err := fn(f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// osmPackedVarints - decodes a packed repeated varint field
+func osmPackedVarints(b []byte) ([]uint64, error) {
+	var vs []uint64
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		vs, b = append(vs, v), b[n:]
+	}
+	return vs, nil
+}
+
+// osmPackedDeltas - decodes a packed, delta-coded repeated sint64 field (e.g. dense node ids, way refs)
+func osmPackedDeltas(b []byte) ([]int64, error) {
+	vs, err := osmPackedVarints(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var deltas = make([]int64, len(vs))
+	var acc int64
+	for i, v := range vs {
+		acc += protowire.DecodeZigZag(v)
+		deltas[i] = acc
+	}
+	return deltas, nil
+}
+
+// osmBlobReader - reads the blobs of a PBF file, i.e. a sequence of `length, BlobHeader, Blob`
+type osmBlobReader struct {
+	r io.Reader
+}
+
+// next - the type (`OSMHeader` || `OSMData`) && decompressed data of the next blob, io.EOF once the file is exhausted
+func (br *osmBlobReader) next() (string, []byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(br.r, size[:]); err != nil {
+		return "", nil, err
+	}
+
+	n := binary.BigEndian.Uint32(size[:])
+	if n > osmMaxBlobHeaderSize {
+		return "", nil, fmt.Errorf("blob header of %d bytes exceeds the max. of %d", n, osmMaxBlobHeaderSize)
+	}
+
+	header := make([]byte, n)
+	if _, err := io.ReadFull(br.r, header); err != nil {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+
+	var typ string
+	var dataSize uint64
+	if err := osmEachField(header, func(f osmField) error {
+		switch f.num {
+		case 1:
+			typ = string(f.b)
+		case 3:
+			dataSize = f.v
+		}
+		return nil
+	}); err != nil {
+		return "", nil, errors.Wrap(err, "failed decoding blob header")
+	}
+
+	if dataSize > osmMaxBlobSize {
+		return "", nil, fmt.Errorf("blob of %d bytes exceeds the max. of %d", dataSize, osmMaxBlobSize)
+	}
+
+	blob := make([]byte, dataSize)
+	if _, err := io.ReadFull(br.r, blob); err != nil {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+
+	data, err := osmDecodeBlob(blob)
+	return typ, data, err
+}
+
+// osmDecodeBlob - the data of a raw || zlib-compressed blob, other compressions (e.g. lzma, zstd) aren't supported
+func osmDecodeBlob(blob []byte) ([]byte, error) {
+	var raw, compressed []byte
+	var rawSize uint64
+	var unsupported protowire.Number
+
+	if err := osmEachField(blob, func(f osmField) error {
+		switch f.num {
+		case 1:
+			raw = f.b
+		case 2:
+			rawSize = f.v
+		case 3:
+			compressed = f.b
+		default:
+			unsupported = f.num
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed decoding blob")
+	}
+
+	switch {
+	case raw != nil:
+		return raw, nil
+	case compressed != nil:
+		if rawSize > osmMaxBlobSize {
+			return nil, fmt.Errorf("blob of %d bytes exceeds the max. of %d", rawSize, osmMaxBlobSize)
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing blob")
+		}
+		defer zr.Close()
+
+		data := make([]byte, rawSize)
+		if _, err := io.ReadFull(zr, data); err != nil {
+			return nil, errors.Wrap(err, "failed decompressing blob")
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("blob compression (field %d) not supported, only raw && zlib", unsupported)
+}
+
+// osmCheckHeader - checks the reader supports every required feature of a file
+func osmCheckHeader(data []byte) error {
+	return osmEachField(data, func(f osmField) error {
+		if (f.num == 4) && !osmSupportedFeatures[string(f.b)] {
+			return fmt.Errorf("required feature `%s` not supported", f.b)
+		}
+		return nil
+	})
+}
+
+// osmHandler - called w. the elements of a block, a nil handler skips decoding that type of element; `tags` is nil
+// for untagged elements
+type osmHandler struct {
+	node func(id int64, lat, lng float64, tags map[string]string)
+	way  func(id int64, refs []int64, tags map[string]string)
+}
+
+// osmBlock - the string table && coordinate encoding of a PrimitiveBlock
+type osmBlock struct {
+	strings     [][]byte
+	granularity int64
+	latOffset   int64
+	lngOffset   int64
+}
+
+// coord - decodes a latitude || longitude in degrees
+func (b *osmBlock) coord(offset, v int64) float64 {
+	return 1e-9 * float64(offset+(b.granularity*v))
+}
+
+// tags - resolves a node || way's keys && values in the string table
+func (b *osmBlock) tags(keys, vals []uint64) (map[string]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if len(keys) != len(vals) {
+		return nil, errors.New("mismatched tag keys && values")
+	}
+
+	var tags = make(map[string]string, len(keys))
+	for i := range keys {
+		if (keys[i] >= uint64(len(b.strings))) || (vals[i] >= uint64(len(b.strings))) {
+			return nil, errors.New("tag not in string table")
+		}
+		tags[string(b.strings[keys[i]])] = string(b.strings[vals[i]])
+	}
+	return tags, nil
+}
+
+// osmDecodeBlock - decodes a PrimitiveBlock, calling `h` w. its nodes && ways
+func osmDecodeBlock(data []byte, h *osmHandler) error {
+	var block = &osmBlock{granularity: 100}
+	var groups [][]byte
+
+	if err := osmEachField(data, func(f osmField) error {
+		switch f.num {
+		case 1:
+			return osmEachField(f.b, func(s osmField) error {
+				if s.num == 1 {
+					block.strings = append(block.strings, s.b)
+				}
+				return nil
+			})
+		case 2:
+			groups = append(groups, f.b)
+		case 17:
+			block.granularity = int64(f.v)
+		case 19:
+			block.latOffset = int64(f.v)
+		case 20:
+			block.lngOffset = int64(f.v)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if err := osmEachField(group, func(f osmField) error {
+			switch {
+			case (f.num == 1) && (h.node != nil):
+				return block.decodeNode(f.b, h)
+			case (f.num == 2) && (h.node != nil):
+				return block.decodeDenseNodes(f.b, h)
+			case (f.num == 3) && (h.way != nil):
+				return block.decodeWay(f.b, h)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeNode - decodes a single (non-dense) node
+func (b *osmBlock) decodeNode(msg []byte, h *osmHandler) error {
+	var id, lat, lng int64
+	var keys, vals []uint64
+
+	if err := osmEachField(msg, func(f osmField) (err error) {
+		switch f.num {
+		case 1:
+			id = protowire.DecodeZigZag(f.v)
+		case 2:
+			keys, err = osmPackedVarints(f.b)
+		case 3:
+			vals, err = osmPackedVarints(f.b)
+		case 8:
+			lat = protowire.DecodeZigZag(f.v)
+		case 9:
+			lng = protowire.DecodeZigZag(f.v)
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+
+	tags, err := b.tags(keys, vals)
+	if err != nil {
+		return err
+	}
+	h.node(id, b.coord(b.latOffset, lat), b.coord(b.lngOffset, lng), tags)
+	return nil
+}
+
+// decodeDenseNodes - decodes a group of dense nodes, their tags are packed as `key, val, ..., 0` per node
+func (b *osmBlock) decodeDenseNodes(msg []byte, h *osmHandler) error {
+	var ids, lats, lngs []int64
+	var keysVals []uint64
+
+	if err := osmEachField(msg, func(f osmField) (err error) {
+		switch f.num {
+		case 1:
+			ids, err = osmPackedDeltas(f.b)
+		case 8:
+			lats, err = osmPackedDeltas(f.b)
+		case 9:
+			lngs, err = osmPackedDeltas(f.b)
+		case 10:
+			keysVals, err = osmPackedVarints(f.b)
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if (len(lats) != len(ids)) || (len(lngs) != len(ids)) {
+		return errors.New("mismatched dense node ids && locations")
+	}
+
+	var kv int
+	for i, id := range ids {
+		var keys, vals []uint64
+		for (kv < len(keysVals)) && (keysVals[kv] != 0) {
+			if kv+1 >= len(keysVals) {
+				return errors.New("truncated dense node tags")
+			}
+			keys, vals = append(keys, keysVals[kv]), append(vals, keysVals[kv+1])
+			kv += 2
+		}
+		kv++ // the `0` delimiting each node's tags
+
+		tags, err := b.tags(keys, vals)
+		if err != nil {
+			return err
+		}
+		h.node(id, b.coord(b.latOffset, lats[i]), b.coord(b.lngOffset, lngs[i]), tags)
+	}
+	return nil
+}
+
+// decodeWay - decodes a way && its node refs
+func (b *osmBlock) decodeWay(msg []byte, h *osmHandler) error {
+	var id int64
+	var keys, vals []uint64
+	var refs []int64
+
+	if err := osmEachField(msg, func(f osmField) (err error) {
+		switch f.num {
+		case 1:
+			id = int64(f.v)
+		case 2:
+			keys, err = osmPackedVarints(f.b)
+		case 3:
+			vals, err = osmPackedVarints(f.b)
+		case 8:
+			refs, err = osmPackedDeltas(f.b)
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+
+	tags, err := b.tags(keys, vals)
+	if err != nil {
+		return err
+	}
+	h.way(id, refs, tags)
+	return nil
+}
+
+// osmScan - calls `h` w. every node && way of a PBF file
+func osmScan(r io.Reader, h *osmHandler) error {
+	var blobs = &osmBlobReader{r: r}
+	for {
+		typ, data, err := blobs.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch typ {
+		case "OSMHeader":
+			err = osmCheckHeader(data)
+		case "OSMData":
+			err = osmDecodeBlock(data, h)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed decoding block")
+		}
+	}
+}
+
+// osmTagsToAddress - an address from a node || way's `addr:*` tags, nil if it has no house number || street
+func osmTagsToAddress(kind string, id int64, tags map[string]string) *pb.Address {
+	if (tags["addr:housenumber"] == "") || (tags["addr:street"] == "") {
+		return nil
+	}
+
+	var address = &pb.Address{
+		Id:          kind + "/" + strconv.FormatInt(id, 10),
+		HouseNumber: tags["addr:housenumber"],
+		Street:      tags["addr:street"],
+		Locality:    tags["addr:city"],
+		PostalCode:  tags["addr:postcode"],
+		Region:      tags["addr:state"],
+	}
+
+	if address.Region == "" {
+		address.Region = *defaultRegion
+	}
+
+	var parts []string
+	for _, v := range []string{
+		address.HouseNumber, address.Street, tags["addr:unit"], address.Locality, address.Region, address.PostalCode,
+	} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	address.CompositeStreetAddress = strings.Join(parts, " ")
+	return address
+}
+
+// osmAddressWay - a building way w. an address, located once its nodes are read
+type osmAddressWay struct {
+	address *pb.Address
+	refs    []int64
+}
+
+// addressOSMReader - streams the addresses of an OpenStreetMap PBF extract, from nodes && building ways tagged
+// `addr:housenumber` && `addr:street`. Ways are located at their centroid, so the file is read twice: first for the
+// ways && the nodes they reference, then for the nodes; addressed nodes are streamed block by block, followed by the
+// ways once every node is located
+type addressOSMReader struct {
+	fi      *os.File
+	rejects *rejectWriter
+	pending []*pb.Address        // addressed nodes of the block being read
+	ways    []osmAddressWay      // addressed ways from the first pass, read once `nodes` is exhausted
+	coords  map[int64][2]float64 // locations of the nodes of `ways`, NaN until read
+	nodes   *osmBlobReader       // the second pass, nil once exhausted
+	numRead int                  // ways read so far, the "line" of rejected ways
+}
+
+// openAddressOSM - opens a PBF file && reads its building ways, ways outside the extract are written to `rejectPath`
+func openAddressOSM(path, rejectPath string) (*addressOSMReader, error) {
+	fi, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading data file")
+	}
+
+	var or = &addressOSMReader{
+		fi:      fi,
+		rejects: &rejectWriter{path: rejectPath},
+		coords:  make(map[int64][2]float64),
+	}
+
+	// first pass, collect the addressed building ways && the nodes they reference
+	if err := osmScan(bufio.NewReader(fi), &osmHandler{
+		way: func(id int64, refs []int64, tags map[string]string) {
+			if (tags["building"] == "") || (len(refs) == 0) {
+				return
+			}
+			if address := osmTagsToAddress("way", id, tags); address != nil {
+				or.ways = append(or.ways, osmAddressWay{address: address, refs: refs})
+				for _, ref := range refs {
+					or.coords[ref] = [2]float64{math.NaN(), math.NaN()}
+				}
+			}
+		},
+	}); err != nil {
+		fi.Close()
+		return nil, err
+	}
+
+	if _, err := fi.Seek(0, io.SeekStart); err != nil {
+		fi.Close()
+		return nil, errors.Wrap(err, "failed reading data file")
+	}
+	or.nodes = &osmBlobReader{r: bufio.NewReader(fi)}
+	return or, nil
+}
+
+// Read - the next address, io.EOF once the file is exhausted
+func (or *addressOSMReader) Read() (*pb.Address, error) {
+	for {
+		if len(or.pending) > 0 {
+			address := or.pending[0]
+			or.pending = or.pending[1:]
+			return address, nil
+		}
+
+		// second pass, stream addressed nodes && locate the nodes of ways
+		if or.nodes != nil {
+			typ, data, err := or.nodes.next()
+			if err == io.EOF {
+				or.nodes = nil
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrap(err, "failed reading data file")
+			}
+			if typ != "OSMData" {
+				continue
+			}
+
+			if err := osmDecodeBlock(data, &osmHandler{
+				node: func(id int64, lat, lng float64, tags map[string]string) {
+					if _, ok := or.coords[id]; ok {
+						or.coords[id] = [2]float64{lat, lng}
+					}
+					if address := osmTagsToAddress("node", id, tags); address != nil {
+						address.Location = &pb.Point{Latitude: float32(lat), Longitude: float32(lng)}
+						or.pending = append(or.pending, address)
+					}
+				},
+			}); err != nil {
+				return nil, errors.Wrap(err, "failed decoding block")
+			}
+			continue
+		}
+
+		if len(or.ways) == 0 {
+			return nil, io.EOF
+		}
+
+		w := or.ways[0]
+		or.ways = or.ways[1:]
+		or.numRead++
+
+		location, err := or.centroid(w.refs)
+		if err != nil {
+			if rerr := or.rejects.Reject(or.numRead, err, []string{w.address.Id}); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+		w.address.Location = location
+		return w.address, nil
+	}
+}
+
+// centroid - the centroid of a closed way's polygon, || the mean of its nodes for open (|| degenerate) ways
+func (or *addressOSMReader) centroid(refs []int64) (*pb.Point, error) {
+	var pts = make([][2]float64, len(refs))
+	for i, ref := range refs {
+		pts[i] = or.coords[ref]
+		if math.IsNaN(pts[i][0]) {
+			return nil, errWayOutsideExtract
+		}
+	}
+
+	// shoelace formula, treating lat && lng as planar is accurate enough at the scale of a building
+	if (len(pts) >= 4) && (refs[0] == refs[len(refs)-1]) {
+		var area, lat, lng float64
+		for i := 0; i < len(pts)-1; i++ {
+			cross := (pts[i][1] * pts[i+1][0]) - (pts[i+1][1] * pts[i][0])
+			area += cross
+			lat += (pts[i][0] + pts[i+1][0]) * cross
+			lng += (pts[i][1] + pts[i+1][1]) * cross
+		}
+		if math.Abs(area) > 1e-18 {
+			return &pb.Point{Latitude: float32(lat / (3 * area)), Longitude: float32(lng / (3 * area))}, nil
+		}
+	}
+
+	var lat, lng float64
+	for _, pt := range pts {
+		lat, lng = lat+pt[0], lng+pt[1]
+	}
+	return &pb.Point{Latitude: float32(lat / float64(len(pts))), Longitude: float32(lng / float64(len(pts)))}, nil
+}
+
+// NumRejected - the number of ways skipped so far
+func (or *addressOSMReader) NumRejected() int {
+	return or.rejects.numRejected
+}
+
+// Close - closes the file && the reject file
+func (or *addressOSMReader) Close() error {
+	rerr := or.rejects.Close()
+	if err := or.fi.Close(); err != nil {
+		return err
+	}
+	return rerr
+}
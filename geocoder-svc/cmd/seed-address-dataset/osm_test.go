@@ -0,0 +1,228 @@
+package main
+
+import (
+	// standard lib
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	// external
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// osmTestStrings - the string table of every test block, tags reference strings by their index
+var osmTestStrings = []string{
+	"", "addr:housenumber", "54", "addr:street", "MACON ST", "building", "yes", "10", "WALL ST", "addr:city", "BROOKLYN", "1",
+	"MAIN ST",
+}
+
+// osmTestBlob - a `length, BlobHeader, Blob` sequence, the blob is zlib-compressed if `compress` is set
+func osmTestBlob(typ string, data []byte, compress bool) []byte {
+	var blob []byte
+	if compress {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+		blob = protowire.AppendTag(blob, 2, protowire.VarintType)
+		blob = protowire.AppendVarint(blob, uint64(len(data)))
+		blob = protowire.AppendTag(blob, 3, protowire.BytesType)
+		blob = protowire.AppendBytes(blob, buf.Bytes())
+	} else {
+		blob = protowire.AppendTag(blob, 1, protowire.BytesType)
+		blob = protowire.AppendBytes(blob, data)
+	}
+
+	var header []byte
+	header = protowire.AppendTag(header, 1, protowire.BytesType)
+	header = protowire.AppendString(header, typ)
+	header = protowire.AppendTag(header, 3, protowire.VarintType)
+	header = protowire.AppendVarint(header, uint64(len(blob)))
+
+	out := make([]byte, 4)
+	binary.BigEndian.PutUint32(out, uint32(len(header)))
+	return append(append(out, header...), blob...)
+}
+
+// osmTestHeader - a HeaderBlock w. `features` as its required features
+func osmTestHeader(features ...string) []byte {
+	var b []byte
+	for _, f := range features {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendString(b, f)
+	}
+	return b
+}
+
+// osmTestPacked - a packed repeated varint field, values are zig-zag && delta-coded if `deltas` is set
+func osmTestPacked(num protowire.Number, vs []int64, deltas bool) []byte {
+	var packed []byte
+	var prev int64
+	for _, v := range vs {
+		if deltas {
+			packed = protowire.AppendVarint(packed, protowire.EncodeZigZag(v-prev))
+			prev = v
+		} else {
+			packed = protowire.AppendVarint(packed, uint64(v))
+		}
+	}
+	b := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+// osmTestBlock - a PrimitiveBlock w. the test string table && a single group, default granularity && offsets
+func osmTestBlock(group []byte) []byte {
+	var st []byte
+	for _, s := range osmTestStrings {
+		st = protowire.AppendTag(st, 1, protowire.BytesType)
+		st = protowire.AppendString(st, s)
+	}
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, st)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendBytes(b, group)
+}
+
+// osmTestCoord - a coordinate in units of the default granularity
+func osmTestCoord(deg float64) int64 {
+	return int64(math.Round(deg * 1e7))
+}
+
+// osmTestFile - a PBF extract w. a square building way (10 WALL ST) around (40.001, -73.999), an addressed dense node
+// (54 MACON ST), an addressed non-dense node (1 MAIN ST) in a compressed block, a building way referencing a node
+// outside the extract && an addressed way that isn't a building
+func osmTestFile(features ...string) []byte {
+	var dense []byte
+	dense = append(dense, osmTestPacked(1, []int64{1, 2, 3, 4, 5}, true)...)
+	dense = append(dense, osmTestPacked(8, []int64{
+		osmTestCoord(40.000), osmTestCoord(40.000), osmTestCoord(40.002), osmTestCoord(40.002), osmTestCoord(40.681),
+	}, true)...)
+	dense = append(dense, osmTestPacked(9, []int64{
+		osmTestCoord(-74.000), osmTestCoord(-73.998), osmTestCoord(-73.998), osmTestCoord(-74.000), osmTestCoord(-73.948),
+	}, true)...)
+	dense = append(dense, osmTestPacked(10, []int64{0, 0, 0, 0, 1, 2, 3, 4, 9, 10, 0}, false)...)
+
+	var denseGroup = protowire.AppendTag(nil, 2, protowire.BytesType)
+	denseGroup = protowire.AppendBytes(denseGroup, dense)
+
+	way := func(id int64, refs []int64, keysVals ...int64) []byte {
+		var keys, vals []int64
+		for i := 0; i < len(keysVals); i += 2 {
+			keys, vals = append(keys, keysVals[i]), append(vals, keysVals[i+1])
+		}
+		var w = protowire.AppendTag(nil, 1, protowire.VarintType)
+		w = protowire.AppendVarint(w, uint64(id))
+		w = append(w, osmTestPacked(2, keys, false)...)
+		w = append(w, osmTestPacked(3, vals, false)...)
+		w = append(w, osmTestPacked(8, refs, true)...)
+
+		var g = protowire.AppendTag(nil, 3, protowire.BytesType)
+		return protowire.AppendBytes(g, w)
+	}
+
+	var wayGroup []byte
+	wayGroup = append(wayGroup, way(10, []int64{1, 2, 3, 4, 1}, 5, 6, 1, 7, 3, 8)...)
+	wayGroup = append(wayGroup, way(11, []int64{1, 99}, 5, 6, 1, 7, 3, 8)...)
+	wayGroup = append(wayGroup, way(12, []int64{1, 2}, 1, 7, 3, 8)...)
+
+	var node []byte
+	node = protowire.AppendTag(node, 1, protowire.VarintType)
+	node = protowire.AppendVarint(node, protowire.EncodeZigZag(6))
+	node = append(node, osmTestPacked(2, []int64{1, 3}, false)...)
+	node = append(node, osmTestPacked(3, []int64{11, 12}, false)...)
+	node = protowire.AppendTag(node, 8, protowire.VarintType)
+	node = protowire.AppendVarint(node, protowire.EncodeZigZag(osmTestCoord(39.8)))
+	node = protowire.AppendTag(node, 9, protowire.VarintType)
+	node = protowire.AppendVarint(node, protowire.EncodeZigZag(osmTestCoord(-89.65)))
+
+	var nodeGroup = protowire.AppendTag(nil, 1, protowire.BytesType)
+	nodeGroup = protowire.AppendBytes(nodeGroup, node)
+
+	var file []byte
+	file = append(file, osmTestBlob("OSMHeader", osmTestHeader(features...), false)...)
+	file = append(file, osmTestBlob("OSMData", osmTestBlock(denseGroup), false)...)
+	file = append(file, osmTestBlob("OSMData", osmTestBlock(wayGroup), true)...)
+	file = append(file, osmTestBlob("OSMData", osmTestBlock(nodeGroup), true)...)
+	return file
+}
+
+func TestAddressOSMReader(t *testing.T) {
+	path := writeTestFile(t, "extract.osm.pbf", osmTestFile("OsmSchema-V0.6", "DenseNodes"))
+
+	r, err := openAddressReader(path, rejectFilePath(path), nil)
+	if err != nil {
+		t.Fatalf("openAddressReader() = %v", err)
+	}
+	defer r.Close()
+
+	addresses := readAllAddresses(t, r)
+
+	// addressed nodes block by block, then the ways
+	var ids []string
+	for _, a := range addresses {
+		ids = append(ids, a.Id)
+	}
+	if want := []string{"node/5", "node/6", "way/10"}; !equalStrings(ids, want) {
+		t.Fatalf("read ids = %v, want %v", ids, want)
+	}
+
+	var tests = []struct {
+		composite string
+		lat, lng  float64
+	}{
+		{"54 MACON ST BROOKLYN", 40.681, -73.948},
+		{"1 MAIN ST", 39.8, -89.65},
+		{"10 WALL ST", 40.001, -73.999}, // the centroid of the building
+	}
+	for i, tt := range tests {
+		a := addresses[i]
+		if a.CompositeStreetAddress != tt.composite {
+			t.Errorf("%s composite address = %q, want %q", a.Id, a.CompositeStreetAddress, tt.composite)
+		}
+		if (math.Abs(float64(a.Location.Latitude)-tt.lat) > 1e-5) || (math.Abs(float64(a.Location.Longitude)-tt.lng) > 1e-5) {
+			t.Errorf("%s location = %v, want (%v, %v)", a.Id, a.Location, tt.lat, tt.lng)
+		}
+	}
+
+	if (addresses[0].HouseNumber != "54") || (addresses[0].Street != "MACON ST") || (addresses[0].Locality != "BROOKLYN") {
+		t.Errorf("node/5 = %+v", addresses[0])
+	}
+
+	// the way w. a node outside the extract
+	if r.NumRejected() != 1 {
+		t.Errorf("NumRejected() = %d, want 1", r.NumRejected())
+	}
+}
+
+func TestAddressOSMReaderUnsupportedFeature(t *testing.T) {
+	path := writeTestFile(t, "extract.osm.pbf", osmTestFile("OsmSchema-V0.6", "HistoricalInformation"))
+	if _, err := openAddressOSM(path, rejectFilePath(path)); err == nil {
+		t.Errorf("openAddressOSM() w. an unsupported required feature = nil, want an error")
+	}
+}
+
+func TestAddressOSMReaderTruncated(t *testing.T) {
+	data := osmTestFile("OsmSchema-V0.6", "DenseNodes")
+	path := writeTestFile(t, "extract.osm.pbf", data[:len(data)-10])
+	if _, err := openAddressOSM(path, rejectFilePath(path)); err == nil {
+		t.Errorf("openAddressOSM() on a truncated file = nil, want an error")
+	}
+}
+
+func TestOSMTagsToAddress(t *testing.T) {
+	if a := osmTagsToAddress("node", 1, map[string]string{"addr:housenumber": "54"}); a != nil {
+		t.Errorf("osmTagsToAddress() w.o. a street = %+v, want nil", a)
+	}
+
+	a := osmTagsToAddress("way", 7, map[string]string{
+		"addr:housenumber": "107-15", "addr:street": "QUEENS BLVD", "addr:unit": "2F", "addr:city": "FOREST HILLS",
+		"addr:state": "NY", "addr:postcode": "11375",
+	})
+	if (a.Id != "way/7") || (a.CompositeStreetAddress != "107-15 QUEENS BLVD 2F FOREST HILLS NY 11375") || (a.Region != "NY") {
+		t.Errorf("osmTagsToAddress() = %+v", a)
+	}
+}
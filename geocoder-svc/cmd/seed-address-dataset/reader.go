@@ -0,0 +1,390 @@
+package main
+
+import (
+
+	// standard lib
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	// internal
+	srv "github.com/dmw2151/geocoder/geocoder-svc/internal"
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// errRowColumns - a row w. neither `expectedColumnsInputData`, `expectedColumnsComponentData` nor
+	// `expectedColumnsRegionData` columns
+	errRowColumns = errors.New("expected 3, 7 or 8 columns")
+
+	// errRowLocation - a row w. a location that isn't `POINT (lng lat)`
+	errRowLocation = errors.New("location must be `POINT (lng lat)`")
+
+	// errRowSegmentColumns - a street segment row w.o. `expectedColumnsSegmentData` columns
+	errRowSegmentColumns = errors.New("expected 9 columns")
+
+	// errRowSegmentGeometry - a street segment row w. fewer than 2 valid points in its geometry
+	errRowSegmentGeometry = errors.New("geometry must be at least 2 points as `lat lng;lat lng;...`")
+)
+
+// gzipMagic - the first bytes of a gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// rejectWriter - writes malformed rows to a CSV file w. their line && the reason they were rejected, the file is
+// only created once a row is rejected
+type rejectWriter struct {
+	path        string
+	fi          *os.File
+	w           *csv.Writer
+	numRejected int
+}
+
+// Reject - writes a row as `line,reason,fields...`, `record` may be partial (or nil) for rows that failed to parse
+func (rw *rejectWriter) Reject(line int, reason error, record []string) error {
+	rw.numRejected++
+
+	log.WithFields(log.Fields{
+		"line":   line,
+		"reason": reason,
+	}).Warn("malformed row; skipping")
+
+	if rw.w == nil {
+		fi, err := os.Create(rw.path)
+		if err != nil {
+			return errors.Wrap(err, "failed creating reject file")
+		}
+		rw.fi, rw.w = fi, csv.NewWriter(fi)
+	}
+	return rw.w.Write(append([]string{strconv.Itoa(line), reason.Error()}, record...))
+}
+
+// Close - flushes && closes the reject file, if it was created
+func (rw *rejectWriter) Close() error {
+	if rw.w == nil {
+		return nil
+	}
+
+	rw.w.Flush()
+	if err := rw.w.Error(); err != nil {
+		rw.fi.Close()
+		return err
+	}
+	return rw.fi.Close()
+}
+
+// addressReader - streams well-formed addresses from an input file, malformed records are rejected && skipped
+type addressReader interface {
+	Read() (*pb.Address, error)
+	NumRejected() int
+	Close() error
+}
+
+// gzipReadCloser - closes both the gzip stream && the underlying file
+type gzipReadCloser struct {
+	*gzip.Reader
+	fi *os.File
+}
+
+// Close - closes the gzip stream && the file
+func (gr *gzipReadCloser) Close() error {
+	gerr := gr.Reader.Close()
+	if err := gr.fi.Close(); err != nil {
+		return err
+	}
+	return gerr
+}
+
+// openInput - opens a file for reading, gzipped files are detected by their header && decompressed while read
+func openInput(path string) (io.ReadCloser, *bufio.Reader, error) {
+	fi, err := os.Open(path)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed reading data file")
+	}
+
+	br := bufio.NewReader(fi)
+	if magic, _ := br.Peek(2); !bytes.Equal(magic, gzipMagic) {
+		return fi, br, nil
+	}
+
+	gr, err := gzip.NewReader(br)
+	if err != nil {
+		fi.Close()
+		return nil, nil, errors.Wrap(err, "failed reading gzipped data file")
+	}
+	return &gzipReadCloser{Reader: gr, fi: fi}, bufio.NewReader(gr), nil
+}
+
+// inputFormat - the format of a file, `--input-format` if set, else inferred from its extension (ignoring `.gz`)
+func inputFormat(path string) string {
+	if *inputFormatFlag != "" {
+		return *inputFormatFlag
+	}
+
+	switch strings.ToLower(filepath.Ext(strings.TrimSuffix(path, ".gz"))) {
+	case ".geojson", ".geojsonl", ".json", ".ndjson":
+		return "geojson"
+	case ".pbf":
+		return "osm-pbf"
+	}
+	return "csv"
+}
+
+// openAddressReader - opens a file in the format of `inputFormat`, malformed records are written to `rejectPath`
+func openAddressReader(path, rejectPath string, mapping *columnMapping) (addressReader, error) {
+	switch format := inputFormat(path); format {
+	case "csv":
+		return openAddressCSV(path, rejectPath, mapping)
+	case "geojson":
+		return openAddressGeoJSON(path, rejectPath, mapping)
+	case "osm-pbf":
+		if mapping != nil {
+			return nil, errors.New("OSM files are read from their `addr:*` tags, column mapping isn't supported")
+		}
+		return openAddressOSM(path, rejectPath)
+	default:
+		return nil, fmt.Errorf("unknown input format `%s`, must be one of `csv`, `geojson`, `osm-pbf`", format)
+	}
+}
+
+// addressCSVReader - streams addresses from an RFC 4180 CSV file w. a header row, one row at a time so memory stays
+// flat regardless of the size of the file; malformed rows are rejected && skipped
+type addressCSVReader struct {
+	src     io.Closer
+	r       *csv.Reader
+	rejects *rejectWriter
+	parse   func(record []string) (*pb.Address, error)
+}
+
+// openAddressCSV - opens a file && reads its header, malformed rows are written to `rejectPath`; rows are read w.
+// `mapping` if set, else as the NYC layout
+func openAddressCSV(path, rejectPath string, mapping *columnMapping) (*addressCSVReader, error) {
+	src, br, err := openInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := csv.NewReader(br)
+	r.FieldsPerRecord = -1 // the number of columns is checked per row so short rows are rejected, not fatal
+	r.ReuseRecord = true
+
+	header, err := r.Read()
+	if err != nil {
+		src.Close()
+		return nil, errors.Wrap(err, "failed reading header")
+	}
+
+	var parse = recordToAddress
+	if mapping != nil {
+		positions, err := mapping.resolve(header)
+		if err != nil {
+			src.Close()
+			return nil, errors.Wrap(err, "failed mapping columns")
+		}
+		parse = func(record []string) (*pb.Address, error) {
+			get := recordGetter(positions, record)
+			location, err := mapping.location(get)
+			if err != nil {
+				return nil, err
+			}
+			address := mapping.address(get)
+			address.Location = location
+			return address, nil
+		}
+	}
+
+	return &addressCSVReader{
+		src:     src,
+		r:       r,
+		rejects: &rejectWriter{path: rejectPath},
+		parse:   parse,
+	}, nil
+}
+
+// Read - the next well-formed address, io.EOF once the file is exhausted
+func (ar *addressCSVReader) Read() (*pb.Address, error) {
+	for {
+		record, err := ar.r.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+
+		// a parse error (e.g. a bare quote) only affects its own row, the reader resumes on the next row
+		if perr, ok := err.(*csv.ParseError); ok {
+			if rerr := ar.rejects.Reject(perr.StartLine, perr.Err, record); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading data file")
+		}
+
+		address, err := ar.parse(record)
+		if err != nil {
+			line, _ := ar.r.FieldPos(0)
+			if rerr := ar.rejects.Reject(line, err, record); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+		return address, nil
+	}
+}
+
+// NumRejected - the number of malformed rows skipped so far
+func (ar *addressCSVReader) NumRejected() int {
+	return ar.rejects.numRejected
+}
+
+// Close - closes the file && the reject file
+func (ar *addressCSVReader) Close() error {
+	rerr := ar.rejects.Close()
+	if err := ar.src.Close(); err != nil {
+		return err
+	}
+	return rerr
+}
+
+// recordToAddress - parses a row of the NYC layout, `id, location, composite address` w. optional `house number,
+// street, borough, ZIP` components && an optional `region` (NEW YORK if unset, e.g. exports of other regions include
+// it); the management service parses the components from the composite address if not sent. Files in any other
+// layout need a `columnMapping`
+func recordToAddress(record []string) (*pb.Address, error) {
+	switch len(record) {
+	case expectedColumnsInputData, expectedColumnsComponentData, expectedColumnsRegionData:
+	default:
+		return nil, errRowColumns
+	}
+
+	// NOTE: DANGEROUS FLAG...
+	location := srv.PointFromLocationString(record[1], true)
+	if (location.Latitude == 0) && (location.Longitude == 0) {
+		return nil, errRowLocation
+	}
+
+	address := &pb.Address{
+		Id:                     record[0],
+		Location:               location,
+		CompositeStreetAddress: record[2],
+	}
+
+	if len(record) >= expectedColumnsComponentData {
+		address.HouseNumber = record[3]
+		address.Street = record[4]
+		address.Locality = record[5]
+		address.PostalCode = record[6]
+		address.Region = "NEW YORK"
+	}
+	if (len(record) == expectedColumnsRegionData) && (record[7] != "") {
+		address.Region = record[7]
+	}
+	return address, nil
+}
+
+// segmentCSVReader - streams well-formed street segments from a CSV file, malformed rows are rejected && skipped
+type segmentCSVReader struct {
+	src     io.ReadCloser
+	r       *csv.Reader
+	rejects *rejectWriter
+}
+
+// openSegmentCSV - opens a street segment file && skips its header, malformed rows are written to `rejectPath`
+func openSegmentCSV(path, rejectPath string) (*segmentCSVReader, error) {
+	src, br, err := openInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := csv.NewReader(br)
+	r.FieldsPerRecord = -1 // the number of columns is checked per row so short rows are rejected, not fatal
+
+	if _, err := r.Read(); err != nil {
+		src.Close()
+		return nil, errors.Wrap(err, "failed reading header")
+	}
+
+	return &segmentCSVReader{
+		src:     src,
+		r:       r,
+		rejects: &rejectWriter{path: rejectPath},
+	}, nil
+}
+
+// Read - the next well-formed street segment, io.EOF once the file is exhausted
+func (sr *segmentCSVReader) Read() (*pb.StreetSegment, error) {
+	for {
+		record, err := sr.r.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+
+		// a parse error (e.g. a bare quote) only affects its own row, the reader resumes on the next row
+		if perr, ok := err.(*csv.ParseError); ok {
+			if rerr := sr.rejects.Reject(perr.StartLine, perr.Err, record); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading data file")
+		}
+
+		seg, err := recordToStreetSegment(record)
+		if err != nil {
+			line, _ := sr.r.FieldPos(0)
+			if rerr := sr.rejects.Reject(line, err, record); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+		return seg, nil
+	}
+}
+
+// NumRejected - the number of malformed rows skipped so far
+func (sr *segmentCSVReader) NumRejected() int {
+	return sr.rejects.numRejected
+}
+
+// Close - closes the file && the reject file
+func (sr *segmentCSVReader) Close() error {
+	rerr := sr.rejects.Close()
+	if err := sr.src.Close(); err != nil {
+		return err
+	}
+	return rerr
+}
+
+// recordToStreetSegment - parses a row of a street segment file, `id, street, borough, ZIP, left from, left to, right
+// from, right to, geometry`; see `expectedColumnsSegmentData`
+func recordToStreetSegment(record []string) (*pb.StreetSegment, error) {
+	if len(record) != expectedColumnsSegmentData {
+		return nil, errRowSegmentColumns
+	}
+
+	geometry := srv.ParseSegmentGeometry(record[8])
+	if len(geometry) < 2 {
+		return nil, errRowSegmentGeometry
+	}
+
+	return &pb.StreetSegment{
+		Id:         record[0],
+		Street:     record[1],
+		Locality:   record[2],
+		PostalCode: record[3],
+		Left:       &pb.AddressRange{FromHouseNumber: record[4], ToHouseNumber: record[5]},
+		Right:      &pb.AddressRange{FromHouseNumber: record[6], ToHouseNumber: record[7]},
+		Geometry:   geometry,
+	}, nil
+}
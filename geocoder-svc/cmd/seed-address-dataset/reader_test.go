@@ -0,0 +1,259 @@
+package main
+
+import (
+	// standard lib
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// writeTestFile - writes `data` to a file in a temp. directory, returns its path
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readAllAddresses - reads every address from a reader, failing the test on any error other than io.EOF
+func readAllAddresses(t *testing.T, r addressReader) []*pb.Address {
+	t.Helper()
+	var addresses []*pb.Address
+	for {
+		address, err := r.Read()
+		if err == io.EOF {
+			return addresses
+		}
+		if err != nil {
+			t.Fatalf("Read() = %v", err)
+		}
+		addresses = append(addresses, address)
+	}
+}
+
+// readRejects - the rows of a reject file, nil if it wasn't created
+func readRejects(t *testing.T, path string) [][]string {
+	t.Helper()
+	fi, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fi.Close()
+
+	r := csv.NewReader(fi)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("failed reading reject file: %v", err)
+	}
+	return records
+}
+
+const testNYCLayoutCSV = `id,location,composite_street_address,house_number,street,locality,postal_code
+a1,POINT (-73.9479 40.6812),54 MACON ST BROOKLYN NEW YORK 11216,54,MACON ST,BROOKLYN,11216
+a2,POINT (-74.008827 40.706005),"23 WALL ST, NEW YORK NEW YORK 10005"
+a3,POINT (-73.95 40.68),"1 MAIN ST, SPRINGFIELD IL",1,MAIN ST,SPRINGFIELD,62701,ILLINOIS
+a4,POINT (-73.95 40.68)
+a5,not a point,10 MAIN ST
+a6,POINT (-73.95 40.68),"10 "MAIN" ST
+a7,POINT (-73.95 40.68),12 ELM ST
+`
+
+func TestAddressCSVReader(t *testing.T) {
+	path := writeTestFile(t, "addresses.csv", []byte(testNYCLayoutCSV))
+	rejectPath := rejectFilePath(path)
+
+	r, err := openAddressCSV(path, rejectPath, nil)
+	if err != nil {
+		t.Fatalf("openAddressCSV() = %v", err)
+	}
+	addresses := readAllAddresses(t, r)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	var ids []string
+	for _, a := range addresses {
+		ids = append(ids, a.Id)
+	}
+	if want := []string{"a1", "a2", "a3", "a7"}; !equalStrings(ids, want) {
+		t.Fatalf("read ids = %v, want %v", ids, want)
+	}
+
+	// components && the default region of the 7 column layout
+	a1 := addresses[0]
+	if (a1.HouseNumber != "54") || (a1.Street != "MACON ST") || (a1.Locality != "BROOKLYN") ||
+		(a1.PostalCode != "11216") || (a1.Region != "NEW YORK") {
+		t.Errorf("a1 = %+v", a1)
+	}
+	if (a1.Location.Latitude != 40.6812) || (a1.Location.Longitude != -73.9479) {
+		t.Errorf("a1 location = %v, want (40.6812, -73.9479)", a1.Location)
+	}
+
+	// components are parsed by the management service when only the composite address is sent
+	if a2 := addresses[1]; (a2.CompositeStreetAddress != "23 WALL ST, NEW YORK NEW YORK 10005") || (a2.Region != "") {
+		t.Errorf("a2 = %+v", a2)
+	}
+
+	// the region column of the 8 column layout
+	if a3 := addresses[2]; a3.Region != "ILLINOIS" {
+		t.Errorf("a3 region = %q, want ILLINOIS", a3.Region)
+	}
+
+	if r.NumRejected() != 3 {
+		t.Errorf("NumRejected() = %d, want 3", r.NumRejected())
+	}
+	rejects := readRejects(t, rejectPath)
+	if len(rejects) != 3 {
+		t.Fatalf("reject file = %v, want 3 rows", rejects)
+	}
+	for i, want := range []struct{ line, reason string }{
+		{"5", errRowColumns.Error()},
+		{"6", errRowLocation.Error()},
+		{"7", csv.ErrQuote.Error()}, // the reader resumes on the next row
+	} {
+		if (rejects[i][0] != want.line) || (rejects[i][1] != want.reason) {
+			t.Errorf("reject %d = %v, want line %s w. reason %q", i, rejects[i], want.line, want.reason)
+		}
+	}
+}
+
+func TestAddressCSVReaderGzip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(testNYCLayoutCSV))
+	zw.Close()
+
+	path := writeTestFile(t, "addresses.csv.gz", buf.Bytes())
+	r, err := openAddressReader(path, rejectFilePath(path), nil)
+	if err != nil {
+		t.Fatalf("openAddressReader() = %v", err)
+	}
+	defer r.Close()
+
+	if addresses := readAllAddresses(t, r); len(addresses) != 4 {
+		t.Errorf("read %d addresses from a gzipped file, want 4", len(addresses))
+	}
+}
+
+func TestAddressCSVReaderNoRejects(t *testing.T) {
+	path := writeTestFile(t, "addresses.csv", []byte("id,location,composite_street_address\na1,POINT (-73.9 40.6),1 MAIN ST\n"))
+	rejectPath := rejectFilePath(path)
+
+	r, err := openAddressCSV(path, rejectPath, nil)
+	if err != nil {
+		t.Fatalf("openAddressCSV() = %v", err)
+	}
+	readAllAddresses(t, r)
+	r.Close()
+
+	if _, err := os.Stat(rejectPath); !os.IsNotExist(err) {
+		t.Errorf("reject file created w.o. any rejected rows")
+	}
+}
+
+func TestInputFormat(t *testing.T) {
+	for path, want := range map[string]string{
+		"a.csv":          "csv",
+		"a.csv.gz":       "csv",
+		"a.txt":          "csv",
+		"a.geojson":      "geojson",
+		"a.GeoJSON.gz":   "geojson",
+		"a.ndjson":       "geojson",
+		"a.osm.pbf":      "osm-pbf",
+		"nyc-latest.pbf": "osm-pbf",
+	} {
+		if got := inputFormat(path); got != want {
+			t.Errorf("inputFormat(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+const testSegmentsCSV = `id,street,borough,zip,left_from,left_to,right_from,right_to,geometry
+s1,MACON ST,BROOKLYN,11216,1,99,2,100,40.6812 -73.9479;40.6815 -73.9390
+s2,"FULTON ST, E",BROOKLYN,11216,1,49,2,50,40.6801 -73.9480;40.6803 -73.9401
+s3,ELM ST,BROOKLYN,11216,1,49
+s4,ELM ST,BROOKLYN,11216,1,49,2,50,not a line
+s5,OAK ST,BROOKLYN,11216,1,49,2,50,40.68 -73.94;40.69 -73.93
+`
+
+func TestSegmentCSVReader(t *testing.T) {
+	path := writeTestFile(t, "segments.csv", []byte(testSegmentsCSV))
+	rejectPath := rejectFilePath(path)
+
+	r, err := openSegmentCSV(path, rejectPath)
+	if err != nil {
+		t.Fatalf("openSegmentCSV() = %v", err)
+	}
+
+	var segments []*pb.StreetSegment
+	for {
+		seg, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() = %v", err)
+		}
+		segments = append(segments, seg)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	var ids []string
+	for _, seg := range segments {
+		ids = append(ids, seg.Id)
+	}
+	if want := []string{"s1", "s2", "s5"}; !equalStrings(ids, want) {
+		t.Fatalf("read ids = %v, want %v", ids, want)
+	}
+
+	s1 := segments[0]
+	if (s1.Street != "MACON ST") || (s1.Locality != "BROOKLYN") || (s1.PostalCode != "11216") ||
+		(s1.Left.ToHouseNumber != "99") || (s1.Right.FromHouseNumber != "2") || (len(s1.Geometry) != 2) {
+		t.Errorf("s1 = %+v", s1)
+	}
+
+	// quoted fields may contain commas
+	if s2 := segments[1]; s2.Street != "FULTON ST, E" {
+		t.Errorf("s2 street = %q, want \"FULTON ST, E\"", s2.Street)
+	}
+
+	rejects := readRejects(t, rejectPath)
+	if (r.NumRejected() != 2) || (len(rejects) != 2) {
+		t.Fatalf("NumRejected() = %d, reject file = %v, want 2 rows", r.NumRejected(), rejects)
+	}
+	for i, want := range []struct{ line, reason string }{
+		{"4", errRowSegmentColumns.Error()},
+		{"5", errRowSegmentGeometry.Error()},
+	} {
+		if (rejects[i][0] != want.line) || (rejects[i][1] != want.reason) {
+			t.Errorf("reject %d = %v, want line %s w. reason %q", i, rejects[i], want.line, want.reason)
+		}
+	}
+}
@@ -4,10 +4,13 @@ import (
 
 	// standard lib
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	// internal
@@ -15,62 +18,150 @@ import (
 	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
 
 	// external
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 	redis "github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
 	// queue parameters
-	pubsubHost = flag.String("pubsub-host", "pubsub", "...")
-	pubsubPort = flag.Int("pubsub-port", 6379, "...")
-	pubsubDB   = flag.Int("pubsub-db", 0, "...")
+	pubsubHost     = flag.String("pubsub-host", "pubsub", "...")
+	pubsubPort     = flag.Int("pubsub-port", 6379, "...")
+	pubsubDB       = flag.Int("pubsub-db", 0, "...")
+	queueConsumer  = flag.String("queue-consumer", "", "(optional) name of this worker in the `batch-workers` consumer group, must be unique per worker; the hostname if unset")
+	queueClaimIdle = flag.Duration("queue-claim-idle", time.Minute*5, "batches left pending by a worker (e.g. it crashed) for this long are claimed by another worker, must exceed `workerBatchTimeout`")
+	concurrency    = flag.Int("concurrency", 4, "number of batches each worker processes at once")
+
+	// progress options
+	progressInterval = flag.Duration("progress-interval", time.Second*5, "(optional) how often the progress of a batch being geocoded is published on `batch.status`, 0 to disable")
+
+	// retry options
+	maxAttempts     = flag.Int("max-attempts", 5, "attempts to process a batch before it's marked FAILED && moved to the `batch.dead-letter` stream")
+	retryBackoff    = flag.Duration("retry-backoff", time.Second*10, "wait before retrying a failed batch, doubled w. each failed attempt")
+	retryMaxBackoff = flag.Duration("retry-max-backoff", time.Minute*5, "max. wait before retrying a failed batch")
 
 	// rpc service options
 	geocoderServerHost = flag.String("rpc-server-host", "gcaas-geocoder", "host addresss of the gcaas grpc server to forward geocode requests")
 	geocoderServerPort = flag.Int("rpc-server-port", 50051, "port of the gcaas grpc server to forward geocode requests")
 )
 
+// workerBatchTimeout - max. time to process a single batch
+const workerBatchTimeout = time.Second * 180
+
+// workerQueueBlock - max. wait for a new batch before checking for batches left pending by other workers
+const workerQueueBlock = time.Second * 5
+
+// workerRetryPollInterval, workerRetryPromoteCount - how often && how many due retries each worker queues
+const (
+	workerRetryPollInterval = time.Second * 1
+	workerRetryPromoteCount = 100
+)
+
 // GeocoderServer - server API for Geocoder service
 type Worker struct {
-	pubsubClient   *redis.Client
 	spacesClient   *s3.S3
 	geocoderClient pb.GeocoderClient
-	listenTopic    string
-	replyTopic     string
-}
+	queue          *srv.BatchQueue // new batches, `Consumer` is suffixed per concurrent consumer
+	statusQueue    *srv.BatchQueue // status updates, consumed by the batch servers
+	concurrency    int
 
-func (w *Worker) updateBatchJobStatus(ctx context.Context, id string, bs pb.BatchGeocodeStatus, dlfp string) {
+	// progress reporting
+	progressInterval time.Duration
 
-	updatedJobStatus := pb.BatchStatusResponse{
-		Id:           id,
-		Status:       bs,
-		DownloadPath: dlfp,
-	}
+	// retry policy
+	maxAttempts     int
+	retryBackoff    time.Duration
+	retryMaxBackoff time.Duration
+
+	// batches being processed by this worker, canceled on a signal on `batch.cancel`
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+}
+
+// updateBatchJobStatus - publishes a batch's status for the batch servers to write to the cache
+func (w *Worker) updateBatchJobStatus(ctx context.Context, status *pb.BatchStatusResponse) error {
 
-	b, _ := proto.Marshal(&updatedJobStatus)
+	b, _ := proto.Marshal(status)
 
-	pubsubPipe := w.pubsubClient.TxPipeline()
-	pubsubPipe.Publish(ctx, w.replyTopic, b)
-	_, err := pubsubPipe.Exec(ctx)
+	_, err := w.statusQueue.Publish(ctx, map[string]interface{}{"payload": b})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err":          err,
-			"batch.id":     id,
-			"batch.status": bs.String(),
-		}).Error("failed to publish status event on batch.status")
+			"batch.id":     status.Id,
+			"batch.status": status.Status.String(),
+		}).Errorf("failed to publish status event on %s", w.statusQueue.Stream)
+	}
+	return err
+}
+
+// batchProgress - counts of an attempt at geocoding a batch, updated by the sender && receiver of the stream
+type batchProgress struct {
+	start     time.Time // time geocoding started, excludes the download
+	total     int
+	sent      int64
+	resolved  int64
+	unmatched int64
+}
+
+// fill - sets the counts of a status && (while geocoding) an ETA extrapolated from the rate of replies so far
+func (p *batchProgress) fill(status *pb.BatchStatusResponse, now time.Time) {
+	sent := atomic.LoadInt64(&p.sent)
+	resolved := atomic.LoadInt64(&p.resolved)
+	unmatched := atomic.LoadInt64(&p.unmatched)
+
+	status.Total = int32(p.total)
+	status.Sent = int32(sent)
+	status.Resolved = int32(resolved)
+	status.Unmatched = int32(unmatched)
+
+	if done := resolved + unmatched; (done > 0) && (done < int64(p.total)) {
+		elapsed := now.Sub(p.start)
+		remaining := time.Duration(float64(elapsed) * float64(int64(p.total)-done) / float64(done))
+		status.Eta = timestamppb.New(now.Add(remaining))
+	}
+}
+
+// reportProgress - publishes the progress of a batch every `progressInterval` until `ctx` is done
+func (w *Worker) reportProgress(ctx context.Context, Id string, attempt int, progress *batchProgress) {
+	if w.progressInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		status := &pb.BatchStatusResponse{
+			Id:       Id,
+			Status:   pb.BatchGeocodeStatus_IN_QUEUE,
+			Attempts: int32(attempt),
+		}
+		progress.fill(status, time.Now())
+		w.updateBatchJobStatus(ctx, status)
 	}
 }
 
 // submitStreamingGeocodeBatch
-func (w *Worker) submitStreamingGeocodeBatch(ctx context.Context, cbr *pb.CreateBatchRequest) (*pb.ResolvedBatch, error) {
+func (w *Worker) submitStreamingGeocodeBatch(ctx context.Context, cbr *pb.CreateBatchRequest, progress *batchProgress) (*pb.ResolvedBatch, error) {
 
 	var resolvedAddresses = make(
 		[]*pb.ResolvedAddress, (len(cbr.Addresses) + len(cbr.Points)),
 	)
 
 	var resolvedBatch = &pb.ResolvedBatch{}
+	var recvErr error // first error on stream recv, fails the batch
 
 	// The worker (acting as a client) sends requests to geocode batch; init conn
 	stream, err := w.geocoderClient.GeocodeBatch(ctx)
@@ -79,6 +170,7 @@ func (w *Worker) submitStreamingGeocodeBatch(ctx context.Context, cbr *pb.Create
 			"err": err,
 			"op":  "worker.sender",
 		}).Error("geocoder.GeocoderBatch failed on stream init")
+		return nil, err
 	}
 
 	waitc := make(chan struct{})
@@ -100,18 +192,28 @@ func (w *Worker) submitStreamingGeocodeBatch(ctx context.Context, cbr *pb.Create
 					"err": err,
 					"op":  "worker.recv",
 				}).Error("geocoder.GeocoderBatch failed on stream recv")
+				recvErr = err
+				close(waitc)
+				return
+			}
+
+			// note: the server replies in order && once per request, more replies than requests is a server error
+			if numResponsesRecv >= len(resolvedAddresses) {
+				continue
 			}
 
 			// note: avoid nil ptr deref here in the protocode by
 			if in.NumResults > 0 {
-				resolvedAddresses[i] = &pb.ResolvedAddress{
+				resolvedAddresses[numResponsesRecv] = &pb.ResolvedAddress{
 					Result: in.Result[0].Address,
 					Query:  in.Query,
 				}
+				atomic.AddInt64(&progress.resolved, 1)
 			} else {
-				resolvedAddresses[i] = &pb.ResolvedAddress{
+				resolvedAddresses[numResponsesRecv] = &pb.ResolvedAddress{
 					Query: in.Query,
 				}
+				atomic.AddInt64(&progress.unmatched, 1)
 			}
 
 			numResponsesRecv++
@@ -129,13 +231,17 @@ func (w *Worker) submitStreamingGeocodeBatch(ctx context.Context, cbr *pb.Create
 				},
 				Method:     pb.Method_FWD_FUZZY,
 				MaxResults: 1,
+				Dataset:    cbr.Dataset,
 			}
 
-			//
-			if err := stream.Send(gcreq); err != nil {
+			// io.EOF -> the server ended the stream, the reason is returned on recv
+			if err := stream.Send(gcreq); err == io.EOF {
+				break
+			} else if err != nil {
 				log.Error("/geocoder.Geocoder/GeocodeBatch: stream.Send failed")
 				return nil, err
 			}
+			atomic.AddInt64(&progress.sent, 1)
 		}
 	}
 
@@ -152,116 +258,355 @@ func (w *Worker) submitStreamingGeocodeBatch(ctx context.Context, cbr *pb.Create
 				},
 				Method:     pb.Method_REV_NEAREST,
 				MaxResults: 1,
+				Dataset:    cbr.Dataset,
 			}
 
-			//
-			if err := stream.Send(gcreq); err != nil {
+			// io.EOF -> the server ended the stream, the reason is returned on recv
+			if err := stream.Send(gcreq); err == io.EOF {
+				break
+			} else if err != nil {
 				log.Error("/geocoder.Geocoder/GeocodeBatch: stream.Send failed")
 				return nil, err
 			}
+			atomic.AddInt64(&progress.sent, 1)
 		}
 	}
 
 	stream.CloseSend()
 	<-waitc
 
+	// e.g. an unknown dataset ends the stream w. codes.NotFound
+	if recvErr != nil {
+		return nil, recvErr
+	}
+
 	resolvedBatch.Batch = resolvedAddresses
 
 	return resolvedBatch, nil
 }
 
-// Listen - the batch server listens with one client (pubsub) and writes to cache
-// with the other
+// Listen - consumes new batches w. `concurrency` consumers until `ctx` is done, each batch is processed by one
+// worker && acknowledged once it succeeds || fails
 func (w *Worker) Listen(ctx context.Context) {
-	sub := w.pubsubClient.Subscribe(ctx, w.listenTopic)
-	
-	defer func() {
-		log.Infof("exit from pub/sub channel: %s", w.listenTopic)
-		sub.Unsubscribe(w.pubsubClient.Context(), w.listenTopic)
+	var wg sync.WaitGroup
+	for i := 0; i < w.concurrency; i++ {
+		q := *w.queue
+		q.Consumer = fmt.Sprintf("%s-%d", w.queue.Consumer, i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := q.Consume(ctx, w.processBatch)
+			log.WithFields(log.Fields{
+				"err":            err,
+				"queue.consumer": q.Consumer,
+			}).Infof("exit from queue: %s", q.Stream)
+		}()
+	}
+	wg.Wait()
+}
+
+// processBatch - geocodes a batch && saves the results. A failed attempt is retried after a backoff, && a batch that
+// fails `maxAttempts` times is marked FAILED && dead-lettered; a canceled batch is skipped, || stopped before its
+// results are saved. The entry is acknowledged either way, only a failure to record the outcome leaves it pending to
+// be processed again
+func (w *Worker) processBatch(ctx context.Context, msg redis.XMessage) error {
+	log.Infof("worker recv msg on %s", w.queue.Stream)
+
+	// create a context w. long timeout
+	ctx, cancel := context.WithTimeout(ctx, workerBatchTimeout)
+	defer cancel()
+
+	Id, _ := srv.SafeCast[string](msg.Values["batch_id"])
+	if Id == "" {
+		log.WithFields(log.Fields{
+			"queue.id": msg.ID,
+		}).Error("batch event w.o. a batch_id; skipping")
+		return nil
+	}
+	attempt := srv.BatchAttempt(msg)
+
+	// a batch canceled while queued || waiting to be retried is skipped, the batch server already marked it CANCELED
+	if canceled, err := srv.BatchCanceled(ctx, w.queue.Client, Id); err != nil {
+		return err
+	} else if canceled {
+		log.WithFields(log.Fields{
+			"batch.id":      Id,
+			"batch.attempt": attempt,
+		}).Info("batch was canceled; skipping")
+		return nil
+	}
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	w.track(Id, stop)
+	defer w.untrack(Id)
+
+	// tell other services this job has been picked by a worker
+	w.updateBatchJobStatus(ctx, &pb.BatchStatusResponse{
+		Id:       Id,
+		Status:   pb.BatchGeocodeStatus_IN_QUEUE,
+		Attempts: int32(attempt),
+	})
+
+	var progress = &batchProgress{}
+	downloadPath, err := w.geocodeBatch(ctx, Id, attempt, progress)
+	if errors.Is(err, srv.ErrBatchCanceled) {
+		log.WithFields(log.Fields{
+			"batch.id":      Id,
+			"batch.attempt": attempt,
+		}).Info("batch was canceled; stopped before saving results")
+		return nil
+	}
+	if err != nil {
+		return w.failBatch(ctx, Id, attempt, err)
+	}
+
+	// success
+	var status = &pb.BatchStatusResponse{
+		Id:           Id,
+		Status:       pb.BatchGeocodeStatus_SUCCESS,
+		DownloadPath: downloadPath,
+		Attempts:     int32(attempt),
+	}
+	progress.fill(status, time.Now())
+	return w.updateBatchJobStatus(ctx, status)
+}
+
+// geocodeBatch - downloads a batch, geocodes it && uploads the results, returns the download path of the results;
+// the counts of `progress` are published while the batch is geocoded
+func (w *Worker) geocodeBatch(ctx context.Context, Id string, attempt int, progress *batchProgress) (string, error) {
+
+	var cbr pb.CreateBatchRequest
+
+	// get the file from spaces
+	baseFileKey := fmt.Sprintf("%s.json", Id)
+	resultsFileKey := fmt.Sprintf("%s-results.json", Id)
+
+	// get the batch from DO spaces...
+	err := srv.GetBatchFromStorage(w.spacesClient, baseFileKey, &cbr)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"batch.id": Id,
+		}).Error("batch failed in download from spaces")
+		return "", errors.Wrap(err, "failed downloading batch")
+	}
+
+	// process the file, reporting progress until the stream ends
+	progress.start, progress.total = time.Now(), len(cbr.Addresses)+len(cbr.Points)
+
+	reportCtx, stopReporting := context.WithCancel(ctx)
+	reportDone := make(chan struct{})
+	go func() {
+		defer close(reportDone)
+		w.reportProgress(reportCtx, Id, attempt, progress)
 	}()
 
-	channel := sub.Channel()
+	batchResults, err := w.submitStreamingGeocodeBatch(ctx, &cbr, progress)
+	stopReporting()
+	<-reportDone
 
-	// marshall msg from the pub/sub channel and write to cache
-	for msg := range channel {
-		log.Infof("worker recv msg on %s", w.listenTopic)
+	if w.canceled(Id) {
+		return "", srv.ErrBatchCanceled
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"batch.id": Id,
+		}).Error("batch failed in geocoding")
+		return "", errors.Wrap(err, "failed geocoding batch")
+	}
 
-		go func() {
+	// upload result to DO spaces
+	err = srv.PersistBatchToStorage(w.spacesClient, batchResults, resultsFileKey)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"batch.id": Id,
+		}).Error("batch failed in saving results to spaces")
+		return "", errors.Wrap(err, "failed saving results")
+	}
+
+	// handle for unauthenticated local development...
+	if env := os.Getenv("ENVIRONMENT"); env == "LOCAL" {
+		return fmt.Sprintf("/tmp/%s", resultsFileKey), nil
+	}
 
-			// create a context w. long timeout
-			ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
-			defer cancel()
+	// create a pre-signed URL for the file
+	downloadPath, err := srv.GeneratePresignedURL(w.spacesClient, resultsFileKey)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"batch.id": Id,
+		}).Error("batch failed in generating download URL")
+		return "", errors.Wrap(err, "failed generating download URL")
+	}
+	return downloadPath, nil
+}
 
-			var cbr pb.CreateBatchRequest
+// track, untrack - registers && removes the cancel func of a batch being processed
+func (w *Worker) track(Id string, cancel context.CancelFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.inflight[Id] = cancel
+}
 
-			// get the file from spaces
-			Id := msg.Payload
-			baseFileKey := fmt.Sprintf("%s.json", Id)
-			resultsFileKey := fmt.Sprintf("%s-results.json", Id)
+func (w *Worker) untrack(Id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.inflight, Id)
+}
 
-			// tell other services this job has been picked by a worker
-			w.updateBatchJobStatus(ctx, Id, pb.BatchGeocodeStatus_IN_QUEUE, "")
+// canceled - checks if a batch was canceled while it was geocoded; the signal may have been missed (e.g. the worker
+// reconnected), so the cancel marker is checked w. a fresh context before any results are saved
+func (w *Worker) canceled(Id string) bool {
+	checkCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
 
-			// get the batch from DO spaces...
-			err := srv.GetBatchFromStorage(w.spacesClient, baseFileKey, &cbr)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"err":          err,
-					"batch.id":     Id,
-					"batch.status": pb.BatchGeocodeStatus_FAILED.String(),
-				}).Error("batch failed in download from spaces")
-				w.updateBatchJobStatus(ctx, Id, pb.BatchGeocodeStatus_FAILED, "")
-				return
-			}
+	canceled, err := srv.BatchCanceled(checkCtx, w.queue.Client, Id)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"batch.id": Id,
+		}).Error("failed to check if batch was canceled")
+	}
+	return canceled
+}
 
-			// process the file
-			batchResults, err := w.submitStreamingGeocodeBatch(ctx, &cbr)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"err":          err,
-					"batch.id":     Id,
-					"batch.status": pb.BatchGeocodeStatus_FAILED.String(),
-				}).Error("batch failed in geocoding")
-				w.updateBatchJobStatus(ctx, Id, pb.BatchGeocodeStatus_FAILED, "")
+// ListenCancels - stops batches being processed by this worker when they're canceled, until `ctx` is done
+func (w *Worker) ListenCancels(ctx context.Context) {
+	sub := w.queue.Client.Subscribe(ctx, srv.BatchCancelChannel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
 				return
 			}
 
-			// upload result to DO spaces
-			err = srv.PersistBatchToStorage(w.spacesClient, batchResults, resultsFileKey)
-			if err != nil {
+			w.mu.Lock()
+			cancel, ok := w.inflight[msg.Payload]
+			w.mu.Unlock()
+
+			if ok {
 				log.WithFields(log.Fields{
-					"err":          err,
-					"batch.id":     Id,
-					"batch.status": pb.BatchGeocodeStatus_FAILED.String(),
-				}).Error("batch failed in saving results to spaces")
-				w.updateBatchJobStatus(ctx, Id, pb.BatchGeocodeStatus_FAILED, "")
-				return
+					"batch.id": msg.Payload,
+				}).Info("batch canceled; stopping")
+				cancel()
 			}
+		}
+	}
+}
 
-			// handle for unauthenticated local development...
-			if env := os.Getenv("ENVIRONMENT"); env == "LOCAL" {
-				w.updateBatchJobStatus(ctx, Id, pb.BatchGeocodeStatus_SUCCESS, fmt.Sprintf("/tmp/%s", resultsFileKey))
-				return
-			}
+// batchErrorRetryable - checks if a failed attempt can succeed when retried; only transient errors are retried, e.g.
+// the geocoder being unavailable || timing out && Spaces I/O. A batch that can't decode, is missing, || is rejected
+// by the geocoder (e.g. `NotFound`, `InvalidArgument`) fails on the first attempt
+func batchErrorRetryable(err error) bool {
+	cause := errors.Cause(err)
 
-			// create a pre-signed URL for the file
-			downloadPath, err := srv.GeneratePresignedURL(w.spacesClient, resultsFileKey)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"err":          err,
-					"batch.id":     Id,
-					"batch.status": pb.BatchGeocodeStatus_FAILED.String(),
-				}).Error("batch failed in generating download URL")
-				w.updateBatchJobStatus(ctx, Id, pb.BatchGeocodeStatus_FAILED, "")
-				return
-			}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(cause, &syntaxErr) || errors.As(cause, &typeErr) || os.IsNotExist(cause) {
+		return false
+	}
 
-			// success
-			w.updateBatchJobStatus(ctx, Id, pb.BatchGeocodeStatus_SUCCESS, downloadPath)
-		}()
+	if aerr, ok := cause.(awserr.Error); ok && (aerr.Code() == s3.ErrCodeNoSuchKey) {
+		return false
+	}
 
+	if errors.Is(cause, context.DeadlineExceeded) {
+		return true
 	}
 
+	// note: errors that aren't from an RPC (e.g. Spaces I/O) are `Unknown`
+	switch status.Code(cause) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// failBatch - schedules the next attempt of a failed batch, || dead-letters it once `maxAttempts` attempts failed ||
+// on an error that isn't retryable (see `batchErrorRetryable`)
+func (w *Worker) failBatch(ctx context.Context, Id string, attempt int, reason error) error {
+
+	var status = &pb.BatchStatusResponse{
+		Id:        Id,
+		Attempts:  int32(attempt),
+		LastError: reason.Error(),
+	}
+
+	// note: the outcome is recorded w. a fresh context, the batch's may have timed out
+	recordCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	retryable := batchErrorRetryable(reason)
+	if retryable && (attempt < w.maxAttempts) {
+		backoff := srv.BatchRetryBackoff(attempt, w.retryBackoff, w.retryMaxBackoff)
+		if err := srv.ScheduleBatchRetry(recordCtx, w.queue.Client, Id, attempt+1, time.Now().Add(backoff)); err != nil {
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"err":           reason,
+			"batch.id":      Id,
+			"batch.attempt": attempt,
+			"batch.status":  pb.BatchGeocodeStatus_RETRYING.String(),
+			"retry.backoff": backoff,
+		}).Warn("batch attempt failed; retrying")
+
+		status.Status = pb.BatchGeocodeStatus_RETRYING
+		return w.updateBatchJobStatus(recordCtx, status)
+	}
+
+	if err := srv.DeadLetterBatch(recordCtx, w.queue.Client, Id, attempt, reason); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"err":             reason,
+		"batch.id":        Id,
+		"batch.attempt":   attempt,
+		"batch.status":    pb.BatchGeocodeStatus_FAILED.String(),
+		"batch.retryable": retryable,
+	}).Errorf("batch failed; moved to %s", srv.BatchDeadLetterStream)
+
+	status.Status = pb.BatchGeocodeStatus_FAILED
+	return w.updateBatchJobStatus(recordCtx, status)
+}
+
+// promoteRetries - queues failed batches once their backoff has passed, until `ctx` is done; every worker promotes
+// retries, each retry is queued once
+func (w *Worker) promoteRetries(ctx context.Context) {
+	ticker := time.NewTicker(workerRetryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := srv.PromoteBatchRetries(ctx, w.queue.Client, time.Now(), workerRetryPromoteCount)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err": err,
+			}).Errorf("failed to queue retries from %s", srv.BatchRetriesKey)
+			continue
+		}
+		if n > 0 {
+			log.WithFields(log.Fields{
+				"retry.num_queued": n,
+			}).Info("queued batch retries")
+		}
+	}
 }
 
 func init() {
@@ -272,24 +617,72 @@ func init() {
 func main() {
 	flag.Parse()
 
+	if *concurrency < 1 {
+		log.WithFields(log.Fields{
+			"concurrency": *concurrency,
+		}).Fatal("`--concurrency` must be at least 1")
+	}
+
+	if (*maxAttempts < 1) || (*retryBackoff <= 0) || (*retryMaxBackoff < *retryBackoff) {
+		log.WithFields(log.Fields{
+			"max_attempts":      *maxAttempts,
+			"retry_backoff":     *retryBackoff,
+			"retry_max_backoff": *retryMaxBackoff,
+		}).Fatal("`--max-attempts` must be at least 1 && `--retry-backoff` positive && at most `--retry-max-backoff`")
+	}
+
+	// a batch still being processed must not be claimed by another worker
+	if *queueClaimIdle <= workerBatchTimeout {
+		log.WithFields(log.Fields{
+			"queue_claim_idle": *queueClaimIdle,
+		}).Fatalf("`--queue-claim-idle` must exceed %s", workerBatchTimeout)
+	}
+
+	if *queueConsumer == "" {
+		*queueConsumer, _ = os.Hostname()
+	}
+
 	geocoderConn := srv.MustRPCClient(*geocoderServerHost, *geocoderServerPort)
 
+	queueClient := srv.MustRedisClient(
+		context.Background(),
+		&srv.RedisClientOptions{
+			DB:   *pubsubDB,
+			Host: *pubsubHost,
+			Port: *pubsubPort,
+		},
+	)
+
 	// init batch server object
 	worker := &Worker{
-		geocoderClient: pb.NewGeocoderClient(geocoderConn),
-		spacesClient:   srv.MustSpacesClient(),
-		listenTopic:    "batch.creates",
-		replyTopic:     "batch.status",
-		pubsubClient: srv.MustRedisClient(
-			context.Background(),
-			&srv.RedisClientOptions{
-				DB:   *pubsubDB,
-				Host: *pubsubHost,
-				Port: *pubsubPort,
-			},
-		),
-	}
-
-	// begin listening - the worker server listens for updates on `batch.creates` and replies on `batch.status`
+		geocoderClient:   pb.NewGeocoderClient(geocoderConn),
+		spacesClient:     srv.MustSpacesClient(),
+		concurrency:      *concurrency,
+		progressInterval: *progressInterval,
+		maxAttempts:      *maxAttempts,
+		retryBackoff:     *retryBackoff,
+		retryMaxBackoff:  *retryMaxBackoff,
+		inflight:         make(map[string]context.CancelFunc),
+		queue: &srv.BatchQueue{
+			Client:    queueClient,
+			Stream:    srv.BatchCreatesStream,
+			Group:     srv.BatchWorkersGroup,
+			Consumer:  *queueConsumer,
+			ClaimIdle: *queueClaimIdle,
+			Block:     workerQueueBlock,
+		},
+		statusQueue: &srv.BatchQueue{
+			Client: queueClient,
+			Stream: srv.BatchStatusStream,
+		},
+	}
+
+	// stop batches canceled while this worker processes them
+	go worker.ListenCancels(context.Background())
+
+	// queue failed batches for retry once their backoff has passed
+	go worker.promoteRetries(context.Background())
+
+	// begin listening - the worker consumes batches on `batch.creates` and replies on `batch.status`
 	worker.Listen(context.Background())
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	// standard lib
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchProgressFill(t *testing.T) {
+	start := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	now := start.Add(time.Second * 10)
+
+	var tests = []struct {
+		name     string
+		progress *batchProgress
+		wantETA  time.Duration // after `now`, 0 for no ETA
+	}{
+		{"nothing resolved", &batchProgress{start: start, total: 100, sent: 40}, 0},
+		{"partially resolved", &batchProgress{start: start, total: 100, sent: 60, resolved: 50}, time.Second * 10},
+		{"unmatched count as done", &batchProgress{start: start, total: 100, sent: 100, resolved: 15, unmatched: 5}, time.Second * 40},
+		{"all resolved", &batchProgress{start: start, total: 100, sent: 100, resolved: 90, unmatched: 10}, 0},
+		{"empty batch", &batchProgress{start: start}, 0},
+	}
+
+	for _, tt := range tests {
+		status := &pb.BatchStatusResponse{}
+		tt.progress.fill(status, now)
+
+		p := tt.progress
+		if (status.Total != int32(p.total)) || (status.Sent != int32(p.sent)) ||
+			(status.Resolved != int32(p.resolved)) || (status.Unmatched != int32(p.unmatched)) {
+			t.Errorf("fill(%s) = %v, want counts of %+v", tt.name, status, p)
+		}
+
+		switch {
+		case (tt.wantETA == 0) && (status.Eta != nil):
+			t.Errorf("fill(%s) ETA = %v, want none", tt.name, status.Eta.AsTime())
+		case (tt.wantETA != 0) && (status.Eta == nil):
+			t.Errorf("fill(%s) ETA = none, want %v", tt.name, now.Add(tt.wantETA))
+		case (tt.wantETA != 0) && !status.Eta.AsTime().Equal(now.Add(tt.wantETA)):
+			t.Errorf("fill(%s) ETA = %v, want %v", tt.name, status.Eta.AsTime(), now.Add(tt.wantETA))
+		}
+	}
+}
+
+func TestBatchErrorRetryable(t *testing.T) {
+	var syntaxErr = json.Unmarshal([]byte("{"), &struct{}{})
+
+	var tests = []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"geocoder unavailable", status.Error(codes.Unavailable, "connection refused"), true},
+		{"geocoder timed out", status.Error(codes.DeadlineExceeded, "deadline exceeded"), true},
+		{"batch timed out", context.DeadlineExceeded, true},
+		{"spaces I/O", awserr.New("RequestError", "send request failed", nil), true},
+		{"wrapped geocoder error", errors.Wrap(status.Error(codes.Unavailable, "connection refused"), "failed geocoding batch"), true},
+		{"unknown dataset", errors.Wrap(status.Error(codes.NotFound, "dataset not found"), "failed geocoding batch"), false},
+		{"invalid request", status.Error(codes.InvalidArgument, "invalid query"), false},
+		{"undecodable batch", errors.Wrap(syntaxErr, "failed downloading batch"), false},
+		{"wrong field type", json.Unmarshal([]byte(`{"id": 1}`), &pb.CreateBatchRequest{}), false},
+		{"missing batch", errors.Wrap(awserr.New(s3.ErrCodeNoSuchKey, "key does not exist", nil), "failed downloading batch"), false},
+		{"missing local batch", errors.Wrap(&os.PathError{Op: "open", Path: "/tmp/b.json", Err: os.ErrNotExist}, "failed downloading batch"), false},
+	}
+
+	for _, tt := range tests {
+		if got := batchErrorRetryable(tt.err); got != tt.want {
+			t.Errorf("batchErrorRetryable(%s: %v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,409 @@
+package srv
+
+import (
+	// standard lib
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// ParsedAddress - an address split into its components; all components are upper-cased and
+// abbreviated to a canonical form (e.g. `EAST 76TH STREET` -> Street: `E 76`, Suffix: `ST`)
+type ParsedAddress struct {
+	HouseNumber string
+	Street      string
+	Suffix      string
+	Locality    string // borough or city
+	Region      string
+	PostalCode  string
+}
+
+// FullStreet - the street name w. its suffix (e.g. `ATLANTIC AVE`)
+func (p *ParsedAddress) FullStreet() string {
+	return strings.TrimSpace(p.Street + " " + p.Suffix)
+}
+
+// String - the canonical composite address, components in the same order as the NYC dataset
+// (e.g. `54 MACON ST BROOKLYN NEW YORK 11216`)
+func (p *ParsedAddress) String() string {
+	var parts []string
+	for _, c := range []string{p.HouseNumber, p.Street, p.Suffix, p.Locality, p.Region, p.PostalCode} {
+		if c != "" {
+			parts = append(parts, c)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+var (
+	// isPostalCode - 5 digit ZIP w. optional ZIP+4 extension
+	isPostalCode = regexp.MustCompile(`^(\d{5})(-\d{4})?$`)
+
+	// isOrdinal - numeric ordinals (e.g. `76TH`, `1ST`, `22ND`)
+	isOrdinal = regexp.MustCompile(`^(\d+)(ST|ND|RD|TH)$`)
+
+	// isRegionCode - two letter state codes (e.g. `NY`, `NJ`)
+	isRegionCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+	// isHouseNumber - house numbers start w. a digit, allows Queens-style hyphenated numbers (e.g. `107-15`)
+	isHouseNumber = regexp.MustCompile(`^\d[\dA-Z/-]*$`)
+)
+
+// streetSuffixes - common USPS street suffixes and their standard abbreviations
+var streetSuffixes = map[string]string{
+	"ALLEY": "ALY", "ALY": "ALY",
+	"AVENUE": "AVE", "AVE": "AVE", "AV": "AVE", "AVN": "AVE",
+	"BOULEVARD": "BLVD", "BLVD": "BLVD",
+	"BRIDGE": "BRG", "BRG": "BRG",
+	"CIRCLE": "CIR", "CIR": "CIR",
+	"CONCOURSE": "CONC", "CONC": "CONC",
+	"COURT": "CT", "CT": "CT",
+	"CRESCENT": "CRES", "CRES": "CRES",
+	"DRIVE": "DR", "DR": "DR",
+	"EXPRESSWAY": "EXPY", "EXPY": "EXPY",
+	"HIGHWAY": "HWY", "HWY": "HWY",
+	"LANE": "LN", "LN": "LN",
+	"LOOP":    "LOOP",
+	"OVAL":    "OVAL",
+	"PARKWAY": "PKWY", "PKWY": "PKWY",
+	"PATH":  "PATH",
+	"PLACE": "PL", "PL": "PL",
+	"PLAZA": "PLZ", "PLZ": "PLZ",
+	"ROAD": "RD", "RD": "RD",
+	"ROW":    "ROW",
+	"SQUARE": "SQ", "SQ": "SQ",
+	"STREET": "ST", "ST": "ST", "STR": "ST",
+	"TERRACE": "TER", "TER": "TER",
+	"TRAIL": "TRL", "TRL": "TRL",
+	"TURNPIKE": "TPKE", "TPKE": "TPKE",
+	"WALK": "WALK",
+	"WAY":  "WAY",
+}
+
+// directionals - street directionals and their standard abbreviations
+var directionals = map[string]string{
+	"NORTH": "N", "N": "N",
+	"SOUTH": "S", "S": "S",
+	"EAST": "E", "E": "E",
+	"WEST": "W", "W": "W",
+	"NORTHEAST": "NE", "NE": "NE",
+	"NORTHWEST": "NW", "NW": "NW",
+	"SOUTHEAST": "SE", "SE": "SE",
+	"SOUTHWEST": "SW", "SW": "SW",
+}
+
+// wordOrdinals - spelled out ordinals, NYC stores these as digits (e.g. `FIRST AVE` -> `1 AVE`)
+var wordOrdinals = map[string]string{
+	"FIRST": "1", "SECOND": "2", "THIRD": "3", "FOURTH": "4", "FIFTH": "5", "SIXTH": "6",
+	"SEVENTH": "7", "EIGHTH": "8", "NINTH": "9", "TENTH": "10", "ELEVENTH": "11", "TWELFTH": "12",
+}
+
+// knownLocalities - NYC boroughs (and common aliases), multi-word localities as a slice of words
+var knownLocalities = map[string][]string{
+	"MANHATTAN":     {"MANHATTAN"},
+	"NEW YORK":      {"NEW", "YORK"},
+	"BRONX":         {"BRONX"},
+	"THE BRONX":     {"THE", "BRONX"},
+	"BROOKLYN":      {"BROOKLYN"},
+	"BKLYN":         {"BKLYN"},
+	"QUEENS":        {"QUEENS"},
+	"STATEN ISLAND": {"STATEN", "ISLAND"},
+}
+
+// localityAliases - maps alternate spellings of a locality to its canonical form
+var localityAliases = map[string]string{
+	"THE BRONX": "BRONX",
+	"BKLYN":     "BROOKLYN",
+}
+
+// regionAliases - maps a trailing state to its canonical form
+var regionAliases = map[string]string{
+	"NY": "NEW YORK",
+}
+
+// addressWord - a single word of an address and the index of the comma-separated group it came from
+type addressWord struct {
+	word  string
+	group int
+}
+
+// splitAddressWords - upper-cases an address and splits it into words, dropping punctuation that
+// isn't meaningful in a house number (e.g. `107-15`, `1/2`)
+func splitAddressWords(s string) []addressWord {
+	var words []addressWord
+	for group, part := range strings.Split(strings.ToUpper(s), ",") {
+		for _, w := range strings.Fields(part) {
+			w = strings.Trim(w, ".#;:()\"'")
+			w = strings.ReplaceAll(w, ".", "")
+			if w != "" {
+				words = append(words, addressWord{word: w, group: group})
+			}
+		}
+	}
+	return words
+}
+
+// hasWordsSuffix - checks if the words of `ws` end w. `suffix`
+func hasWordsSuffix(ws []addressWord, suffix []string) bool {
+	if len(suffix) > len(ws) {
+		return false
+	}
+	for i, s := range suffix {
+		if ws[len(ws)-len(suffix)+i].word != s {
+			return false
+		}
+	}
+	return true
+}
+
+// trailingLocality - returns the canonical locality and number of words it spans if `ws` ends w. a known locality
+func trailingLocality(ws []addressWord) (string, int) {
+	var best string
+	var bestLen int
+	for name, words := range knownLocalities {
+		if len(words) > bestLen && hasWordsSuffix(ws, words) {
+			best, bestLen = name, len(words)
+		}
+	}
+	if alias, ok := localityAliases[best]; ok {
+		best = alias
+	}
+	return best, bestLen
+}
+
+// ParseAddress - splits a free text address into its components. Parsing runs from the back of the
+// address (postal code, region, locality) then the front (house number) and whatever remains is the street.
+//
+// Works best on NYC-style addresses (e.g. `54 MACON ST BROOKLYN NEW YORK 11216`); for other cities,
+// the locality is only recognized when set off by a comma (e.g. `10 MAIN ST, SPRINGFIELD, IL`).
+func ParseAddress(s string) *ParsedAddress {
+
+	var p = &ParsedAddress{}
+	var ws = splitAddressWords(s)
+
+	// postal code
+	if n := len(ws); n > 0 {
+		if m := isPostalCode.FindStringSubmatch(ws[n-1].word); m != nil {
+			p.PostalCode = m[1]
+			ws = ws[:n-1]
+		}
+	}
+
+	// region - `NY` always, `NEW YORK` only when preceded by a locality (otherwise it's the locality), any
+	// other two letter state only when set off by a comma
+	if n := len(ws); n > 1 {
+		if r, ok := regionAliases[ws[n-1].word]; ok {
+			p.Region = r
+			ws = ws[:n-1]
+		} else if isRegionCode.MatchString(ws[n-1].word) && ws[n-1].group > 0 {
+			p.Region = ws[n-1].word
+			ws = ws[:n-1]
+		} else if hasWordsSuffix(ws, knownLocalities["NEW YORK"]) {
+			if l, _ := trailingLocality(ws[:n-2]); l != "" {
+				p.Region = "NEW YORK"
+				ws = ws[:n-2]
+			}
+		}
+	}
+
+	// locality - known boroughs, or any trailing comma-separated group that isn't the first
+	if l, n := trailingLocality(ws); l != "" && n < len(ws) {
+		p.Locality = l
+		ws = ws[:len(ws)-n]
+	} else if n := len(ws); n > 1 && ws[n-1].group > 0 {
+		var i = n - 1
+		for i > 0 && ws[i-1].group == ws[n-1].group {
+			i--
+		}
+		if i > 0 {
+			var locality []string
+			for _, w := range ws[i:] {
+				locality = append(locality, w.word)
+			}
+			p.Locality = strings.Join(locality, " ")
+			ws = ws[:i]
+		}
+	}
+
+	// street suffix - the last word, so long as it isn't the whole street (e.g. `AVENUE J`, `BROADWAY`)
+	ws, p.Suffix = splitStreetSuffix(ws)
+
+	// house number - the first word, so long as something is left for the street name (e.g. `125 ST`)
+	if len(ws) > 1 && isHouseNumber.MatchString(ws[0].word) && !isOrdinal.MatchString(ws[0].word) {
+		p.HouseNumber = ws[0].word
+		ws = ws[1:]
+	}
+
+	p.Street = normalizeStreetName(ws)
+	return p
+}
+
+// ParseStructuredAddress - normalizes an address that's already split into components w. the same rules
+// as `ParseAddress`; `street` may include its suffix (e.g. `East 76th Street`)
+func ParseStructuredAddress(houseNumber, street, locality, region, postalCode string) *ParsedAddress {
+
+	var p = &ParsedAddress{
+		HouseNumber: strings.ToUpper(strings.TrimSpace(houseNumber)),
+		Locality:    strings.Join(strings.Fields(strings.ToUpper(locality)), " "),
+		Region:      strings.Join(strings.Fields(strings.ToUpper(region)), " "),
+		PostalCode:  strings.TrimSpace(postalCode),
+	}
+
+	if alias, ok := localityAliases[p.Locality]; ok {
+		p.Locality = alias
+	}
+	if alias, ok := regionAliases[p.Region]; ok {
+		p.Region = alias
+	}
+	if m := isPostalCode.FindStringSubmatch(p.PostalCode); m != nil {
+		p.PostalCode = m[1]
+	}
+
+	ws, suffix := splitStreetSuffix(splitAddressWords(street))
+	p.Street, p.Suffix = normalizeStreetName(ws), suffix
+	return p
+}
+
+// splitStreetSuffix - splits a trailing street suffix off of a street, so long as it isn't the whole
+// street (e.g. `AVENUE J`, `BROADWAY`), returns the remaining words and the abbreviated suffix
+func splitStreetSuffix(ws []addressWord) ([]addressWord, string) {
+	if n := len(ws); n > 1 {
+		if sfx, ok := streetSuffixes[ws[n-1].word]; ok {
+			return ws[:n-1], sfx
+		}
+	}
+	return ws, ""
+}
+
+// normalizeStreetName - abbreviates directionals and collapses ordinals in a street name
+// (e.g. `EAST 76TH` -> `E 76`)
+func normalizeStreetName(ws []addressWord) string {
+	var street = make([]string, len(ws))
+	for i, w := range ws {
+		street[i] = w.word
+
+		if m := isOrdinal.FindStringSubmatch(w.word); m != nil {
+			street[i] = m[1]
+		} else if o, ok := wordOrdinals[w.word]; ok {
+			street[i] = o
+		} else if d, ok := directionals[w.word]; ok && len(ws) > 1 && (i == 0 || i == len(ws)-1) {
+			// pre and post-directionals only, so long as they aren't the whole street (e.g. `WEST ST`)
+			street[i] = d
+		}
+	}
+	return strings.Join(street, " ")
+}
+
+// NormalizeAddress - returns the canonical composite form of an address, applied at ingest and query time
+// so that e.g. `E 76th Street` and `E 76 ST` are indexed && searched as `E 76 ST`
+func NormalizeAddress(s string) string {
+	return ParseAddress(s).String()
+}
+
+// NormalizeAddressQuery - `NormalizeAddress` for queries that may contain fuzzy-match markers (e.g.
+// `%ATLANTIC% %AVENUE%`); markers are kept on words that survive normalization unchanged
+func NormalizeAddressQuery(q string) string {
+
+	// strip markers, remembering the marker for each word
+	var fuzz = make(map[string][]string)
+	var bare []string
+	for _, w := range strings.Fields(q) {
+		core := strings.Trim(w, "%")
+		marker := w[:len(w)-len(strings.TrimLeft(w, "%"))]
+		fuzz[strings.ToUpper(core)] = append(fuzz[strings.ToUpper(core)], marker)
+		bare = append(bare, core)
+	}
+
+	// normalize && re-apply markers, words that changed are now canonical -> no marker
+	normalized := strings.Fields(NormalizeAddress(strings.Join(bare, " ")))
+	for i, w := range normalized {
+		if markers := fuzz[w]; len(markers) > 0 {
+			normalized[i] = markers[0] + w + markers[0]
+			fuzz[w] = markers[1:]
+		}
+	}
+	return strings.Join(normalized, " ")
+}
+
+// NormalizeAddressPrefix - normalizes a partially typed address for autocomplete; the last word is only
+// upper-cased unless it's followed by a space, e.g. `54 Macon Street Bro` -> `54 MACON ST BRO`
+func NormalizeAddressPrefix(s string) string {
+	words := strings.Fields(strings.ToUpper(s))
+	if len(words) == 0 {
+		return ""
+	}
+
+	if unicode.IsSpace(rune(s[len(s)-1])) {
+		return NormalizeAddress(s) + " "
+	}
+
+	head := NormalizeAddress(strings.Join(words[:len(words)-1], " "))
+	if head == "" {
+		return words[len(words)-1]
+	}
+	return head + " " + words[len(words)-1]
+}
+
+// NormalizeAddressComponents - normalizes an address in place at ingest; components that were sent are
+// normalized, otherwise all components are parsed from `composite_street_address`
+func NormalizeAddressComponents(a *pb.Address) {
+
+	var p *ParsedAddress
+	if (a.HouseNumber == "") && (a.Street == "") && (a.Locality == "") && (a.PostalCode == "") && (a.Region == "") {
+		p = ParseAddress(a.CompositeStreetAddress)
+	} else {
+		p = ParseStructuredAddress(a.HouseNumber, a.Street, a.Locality, a.Region, a.PostalCode)
+	}
+
+	if a.CompositeStreetAddress == "" {
+		a.CompositeStreetAddress = p.String()
+	} else {
+		a.CompositeStreetAddress = NormalizeAddress(a.CompositeStreetAddress)
+	}
+
+	a.HouseNumber = p.HouseNumber
+	a.Street = p.FullStreet()
+	a.Locality = p.Locality
+	a.PostalCode = p.PostalCode
+	a.Region = p.Region
+}
+
+// houseNumberSuffixWidth - digits reserved for the part of a hyphenated house number after the hyphen
+const houseNumberSuffixWidth = 4
+
+// HouseNumberValue - numeric value of a house number for range queries; the part of a hyphenated Queens-style
+// number after the hyphen is zero-padded so numbers keep their order (e.g. `107-15` -> 1070015, `107-5` -> 1070005),
+// letters and fractions are dropped (e.g. `12A` -> 12)
+func HouseNumberValue(s string) (float64, bool) {
+	var prefix, suffix strings.Builder
+	var digits = &prefix
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		} else if (r == '-') && (digits == &prefix) {
+			digits = &suffix
+		} else {
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(prefix.String(), 64)
+	if err != nil {
+		return 0, false
+	}
+	if suffix.Len() == 0 {
+		return v, true
+	}
+
+	// WARN: a longer suffix would sort after every number w. the same prefix
+	sv, err := strconv.ParseFloat(suffix.String(), 64)
+	if (err != nil) || (suffix.Len() > houseNumberSuffixWidth) {
+		return 0, false
+	}
+	return v*math.Pow10(houseNumberSuffixWidth) + sv, true
+}
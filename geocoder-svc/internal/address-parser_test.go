@@ -0,0 +1,170 @@
+package srv
+
+import (
+	// standard lib
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+func TestParseAddress(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want ParsedAddress
+	}{
+		{
+			in: "54 Macon St Brooklyn New York 11216",
+			want: ParsedAddress{
+				HouseNumber: "54", Street: "MACON", Suffix: "ST", Locality: "BROOKLYN", Region: "NEW YORK", PostalCode: "11216",
+			},
+		},
+		{
+			in:   "107-15 Queens Blvd, Forest Hills, NY 11375-1234",
+			want: ParsedAddress{HouseNumber: "107-15", Street: "QUEENS", Suffix: "BLVD", Locality: "FOREST HILLS", Region: "NEW YORK", PostalCode: "11375"},
+		},
+		{
+			in:   "10 Main Street, Springfield, IL",
+			want: ParsedAddress{HouseNumber: "10", Street: "MAIN", Suffix: "ST", Locality: "SPRINGFIELD", Region: "IL"},
+		},
+		{
+			in:   "2111 Atlantic Ave Bklyn",
+			want: ParsedAddress{HouseNumber: "2111", Street: "ATLANTIC", Suffix: "AVE", Locality: "BROOKLYN"},
+		},
+		{
+			in:   "125 St",
+			want: ParsedAddress{Street: "125", Suffix: "ST"},
+		},
+		{
+			in:   "Broadway",
+			want: ParsedAddress{Street: "BROADWAY"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ParseAddress(tt.in); *got != tt.want {
+			t.Errorf("ParseAddress(%q) = %+v, want %+v", tt.in, *got, tt.want)
+		}
+	}
+}
+
+func TestParsedAddressString(t *testing.T) {
+	p := ParseAddress("54 macon street, brooklyn, ny 11216")
+	if got, want := p.String(), "54 MACON ST BROOKLYN NEW YORK 11216"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := p.FullStreet(), "MACON ST"; got != want {
+		t.Errorf("FullStreet() = %q, want %q", got, want)
+	}
+}
+
+func TestHouseNumberValue(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"23", 23, true},
+		{"12A", 12, true},
+		{"12 1/2", 12, true},
+		{"107-15", 1070015, true},
+		{"107-5", 1070005, true},
+		{"107-15A", 1070015, true},
+		{"107-", 107, true},
+		{"107-12345", 0, false},
+		{"", 0, false},
+		{"A12", 0, false},
+		{"-15", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := HouseNumberValue(tt.in)
+		if (got != tt.want) || (ok != tt.ok) {
+			t.Errorf("HouseNumberValue(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestHouseNumberValueOrder(t *testing.T) {
+	var ordered = []string{"107-5", "107-15", "107-100", "108-1", "108-20"}
+	for i := 1; i < len(ordered); i++ {
+		prev, _ := HouseNumberValue(ordered[i-1])
+		next, _ := HouseNumberValue(ordered[i])
+		if prev >= next {
+			t.Errorf("HouseNumberValue(%q) = %v, want less than HouseNumberValue(%q) = %v", ordered[i-1], prev, ordered[i], next)
+		}
+	}
+}
+
+func TestNormalizeAddressComponents(t *testing.T) {
+
+	// components parsed from the composite address
+	a := &pb.Address{CompositeStreetAddress: "23 Wall Street, New York, NY 10005"}
+	NormalizeAddressComponents(a)
+	if (a.HouseNumber != "23") || (a.Street != "WALL ST") || (a.Locality != "NEW YORK") ||
+		(a.Region != "NEW YORK") || (a.PostalCode != "10005") {
+		t.Errorf("NormalizeAddressComponents(composite) = %+v", a)
+	}
+
+	// components sent are normalized, the composite address is built from them
+	a = &pb.Address{HouseNumber: "54", Street: "Macon Street", Locality: "bklyn", Region: "ny", PostalCode: "11216"}
+	NormalizeAddressComponents(a)
+	if (a.Street != "MACON ST") || (a.Locality != "BROOKLYN") || (a.Region != "NEW YORK") {
+		t.Errorf("NormalizeAddressComponents(structured) = %+v", a)
+	}
+	if want := "54 MACON ST BROOKLYN NEW YORK 11216"; a.CompositeStreetAddress != want {
+		t.Errorf("CompositeStreetAddress = %q, want %q", a.CompositeStreetAddress, want)
+	}
+}
+
+func TestNormalizeAddressQuery(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want string
+	}{
+		{"2111 Atlantic Avenue Brooklyn", "2111 ATLANTIC AVE BROOKLYN"},
+		{"%ATLANTIC% %AVENUE%", "%ATLANTIC% AVE"},
+		{"54 %MACN% St", "54 %MACN% ST"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeAddressQuery(tt.in); got != tt.want {
+			t.Errorf("NormalizeAddressQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseStructuredAddress(t *testing.T) {
+	got := ParseStructuredAddress(" 2111 ", "Atlantic Avenue", "bklyn", "ny", "11233-1234")
+	want := ParsedAddress{HouseNumber: "2111", Street: "ATLANTIC", Suffix: "AVE", Locality: "BROOKLYN", Region: "NEW YORK", PostalCode: "11233"}
+	if *got != want {
+		t.Errorf("ParseStructuredAddress() = %+v, want %+v", *got, want)
+	}
+
+	// the whole street is kept when it's only a suffix word, the same as `ParseAddress`
+	if got := ParseStructuredAddress("", "Broadway", "", "", ""); got.String() != "BROADWAY" {
+		t.Errorf("ParseStructuredAddress(Broadway).String() = %q, want %q", got.String(), "BROADWAY")
+	}
+	if got := ParseStructuredAddress("", "", "", "", ""); got.String() != "" {
+		t.Errorf("ParseStructuredAddress(empty).String() = %q, want empty", got.String())
+	}
+}
+
+func TestNormalizeAddressPrefix(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want string
+	}{
+		{"54 Macon Street Bro", "54 MACON ST BRO"},
+		{"54 Macon Street ", "54 MACON ST "},
+		{"54 mac", "54 MAC"},
+		{"mac", "MAC"},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeAddressPrefix(tt.in); got != tt.want {
+			t.Errorf("NormalizeAddressPrefix(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package srv
+
+import (
+	// standard lib
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// AddressValidator - validates the addresses of a single ingest stream, the ids of accepted addresses are kept to
+// reject duplicates within the stream
+type AddressValidator struct {
+	bounds    *pb.BoundingBox     // optional, the dataset's bounding box
+	indexable *pb.BoundingBox     // optional, the locations the search backend can index
+	seen      map[string]struct{} // ids of all accepted addresses
+}
+
+// NewAddressValidator - creates a validator for a stream, `bounds` && `indexable` may be nil
+func NewAddressValidator(bounds, indexable *pb.BoundingBox) *AddressValidator {
+	return &AddressValidator{
+		bounds:    bounds,
+		indexable: indexable,
+		seen:      make(map[string]struct{}),
+	}
+}
+
+// Validate - checks a normalized address w. `ValidateAddress`, then rejects ids already accepted in the stream
+func (v *AddressValidator) Validate(a *pb.Address) error {
+	if err := ValidateAddress(a, v.bounds, v.indexable); err != nil {
+		return err
+	}
+
+	if _, ok := v.seen[a.Id]; ok {
+		return ErrAddressDuplicateID
+	}
+	v.seen[a.Id] = struct{}{}
+	return nil
+}
+
+// ValidateAddress - checks a normalized address (see `NormalizeAddressComponents`) has an id, a location the search
+// backend can index (within `indexable`, may be nil) && within `bounds` (may be nil) && a non-empty address; returns
+// the reason it's rejected or nil
+//
+// NOTE: `PointFromLocationString` returns (0, 0) for an unparseable location, (0, 0) is always rejected
+func ValidateAddress(a *pb.Address, bounds, indexable *pb.BoundingBox) error {
+	if strings.TrimSpace(a.Id) == "" {
+		return ErrAddressMissingID
+	}
+
+	if pt := a.Location; !validPoint(pt) || ((pt.Latitude == 0) && (pt.Longitude == 0)) {
+		return ErrAddressInvalidLocation
+	}
+
+	if (indexable != nil) && !containsPoint(indexable, a.Location) {
+		return ErrAddressInvalidLocation
+	}
+
+	if (bounds != nil) && !containsPoint(bounds, a.Location) {
+		return ErrAddressOutOfBounds
+	}
+
+	if a.CompositeStreetAddress == "" {
+		return ErrAddressEmpty
+	}
+	return nil
+}
+
+// ParseDatasetBounds - parses the bounding box of each dataset from `name=min_lat,min_lng,max_lat,max_lng;...`,
+// returns an empty map for an empty string
+func ParseDatasetBounds(s string) (map[string]*pb.BoundingBox, error) {
+	bounds := make(map[string]*pb.BoundingBox)
+	if strings.TrimSpace(s) == "" {
+		return bounds, nil
+	}
+
+	for _, entry := range strings.Split(s, ";") {
+		name, box, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, ErrInvalidBoundingBox
+		}
+
+		dataset, err := ParseDataset(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		if bounds[dataset], err = ParseBoundingBox(box); err != nil {
+			return nil, err
+		}
+	}
+	return bounds, nil
+}
@@ -0,0 +1,111 @@
+package srv
+
+import (
+	// standard lib
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// testValidAddress - returns a normalized address in Brooklyn w. id `id`
+func testValidAddress(id string) *pb.Address {
+	a := &pb.Address{
+		Id:                     id,
+		CompositeStreetAddress: "1 FULTON ST, BROOKLYN, NY 11216",
+		Location:               &pb.Point{Latitude: 40.6812, Longitude: -73.9479},
+	}
+	NormalizeAddressComponents(a)
+	return a
+}
+
+func TestValidateAddress(t *testing.T) {
+	bounds := &pb.BoundingBox{
+		Min: &pb.Point{Latitude: 40.4, Longitude: -74.3},
+		Max: &pb.Point{Latitude: 41.0, Longitude: -73.7},
+	}
+
+	var tests = []struct {
+		name      string
+		modify    func(a *pb.Address)
+		bounds    *pb.BoundingBox
+		indexable *pb.BoundingBox
+		want      error
+	}{
+		{"valid", func(a *pb.Address) {}, nil, nil, nil},
+		{"valid within bounds", func(a *pb.Address) {}, bounds, nil, nil},
+		{"missing id", func(a *pb.Address) { a.Id = " " }, nil, nil, ErrAddressMissingID},
+		{"missing location", func(a *pb.Address) { a.Location = nil }, nil, nil, ErrAddressInvalidLocation},
+		{"zero location", func(a *pb.Address) { a.Location = &pb.Point{} }, nil, nil, ErrAddressInvalidLocation},
+		{"invalid latitude", func(a *pb.Address) { a.Location.Latitude = 91 }, nil, nil, ErrAddressInvalidLocation},
+		{"invalid longitude", func(a *pb.Address) { a.Location.Longitude = -181 }, nil, nil, ErrAddressInvalidLocation},
+		{"out of bounds", func(a *pb.Address) { a.Location.Latitude = 41.2 }, bounds, nil, ErrAddressOutOfBounds},
+		{"out of bounds ignored w.o. bounds", func(a *pb.Address) { a.Location.Latitude = 41.2 }, nil, nil, nil},
+		{"on bounds", func(a *pb.Address) { a.Location = &pb.Point{Latitude: 41.0, Longitude: -73.7} }, bounds, nil, nil},
+		{"empty address", func(a *pb.Address) { a.CompositeStreetAddress = "" }, nil, nil, ErrAddressEmpty},
+		{"longitude not indexable", func(a *pb.Address) { a.Location.Longitude = 120 }, nil, redisIndexableBounds, ErrAddressInvalidLocation},
+		{"longitude indexable", func(a *pb.Address) { a.Location.Longitude = 85 }, nil, redisIndexableBounds, nil},
+		{"longitude indexable w.o. limits", func(a *pb.Address) { a.Location.Longitude = 120 }, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		a := testValidAddress("1")
+		tt.modify(a)
+		if err := ValidateAddress(a, tt.bounds, tt.indexable); err != tt.want {
+			t.Errorf("ValidateAddress(%s) = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestAddressValidatorDuplicateIDs(t *testing.T) {
+	v := NewAddressValidator(nil, nil)
+
+	invalid := testValidAddress("1")
+	invalid.CompositeStreetAddress = ""
+
+	var tests = []struct {
+		a    *pb.Address
+		want error
+	}{
+		{invalid, ErrAddressEmpty},
+		{testValidAddress("1"), nil}, // a rejected address doesn't mark its id as seen
+		{testValidAddress("2"), nil},
+		{testValidAddress("1"), ErrAddressDuplicateID},
+		{testValidAddress("2"), ErrAddressDuplicateID},
+	}
+
+	for i, tt := range tests {
+		if err := v.Validate(tt.a); err != tt.want {
+			t.Errorf("Validate(#%d, %s) = %v, want %v", i, tt.a.Id, err, tt.want)
+		}
+	}
+}
+
+func TestParseDatasetBounds(t *testing.T) {
+	bounds, err := ParseDatasetBounds(" nyc=40.4,-74.3,41.0,-73.7; nj = 38.9,-75.6,41.4,-73.9")
+	if err != nil {
+		t.Fatalf("ParseDatasetBounds() = %v", err)
+	}
+	if (len(bounds) != 2) || (bounds["nyc"].Max.Latitude != 41.0) || (bounds["nj"].Min.Longitude != -75.6) {
+		t.Errorf("ParseDatasetBounds() = %v", bounds)
+	}
+
+	if bounds, err := ParseDatasetBounds(""); (err != nil) || (len(bounds) != 0) {
+		t.Errorf("ParseDatasetBounds(\"\") = %v, %v, want empty map", bounds, err)
+	}
+
+	var tests = []struct {
+		s    string
+		want error
+	}{
+		{"nyc", ErrInvalidBoundingBox},
+		{"nyc=40.4,-74.3,41.0", ErrInvalidBoundingBox},
+		{"NYC=40.4,-74.3,41.0,-73.7", ErrInvalidDataset},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseDatasetBounds(tt.s); err != tt.want {
+			t.Errorf("ParseDatasetBounds(%q) = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}
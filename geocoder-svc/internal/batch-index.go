@@ -0,0 +1,129 @@
+package srv
+
+import (
+
+	// standard lib
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// external
+	redis "github.com/go-redis/redis/v8"
+)
+
+const (
+	// batchIndexCreatedKey - sorted set of every batch, scored by create time (ms)
+	batchIndexCreatedKey = "batch.index:created"
+
+	// batchIndexOwnerPrefix - sorted set of an owner's batches, scored by create time (ms)
+	batchIndexOwnerPrefix = "batch.index:owner:"
+)
+
+// IndexBatch - adds a batch to the create time && owner indexes on a pipeline, entries older than `retention` (i.e.
+// batches that expired from the cache) are trimmed
+func IndexBatch(ctx context.Context, pipe redis.Pipeliner, id, owner string, created time.Time, retention time.Duration) {
+	var keys = []string{batchIndexCreatedKey}
+	if owner != "" {
+		keys = append(keys, batchIndexOwnerPrefix+owner)
+	}
+
+	expired := strconv.FormatInt(created.Add(-retention).UnixMilli(), 10)
+	for _, key := range keys {
+		pipe.ZAdd(ctx, key, &redis.Z{Score: float64(created.UnixMilli()), Member: id})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+expired)
+	}
+}
+
+// ParseOperatorOwners - parses a comma-separated list of owners (e.g. `--operator-owners`), owners allowed to act on
+// any owner's batches
+func ParseOperatorOwners(s string) map[string]bool {
+	var owners = make(map[string]bool)
+	for _, owner := range strings.Split(s, ",") {
+		if owner = strings.TrimSpace(owner); owner != "" {
+			owners[owner] = true
+		}
+	}
+	return owners
+}
+
+// BatchIndexCursor - the cursor of a page ending w. `z`, the page after it starts w. the next older batch
+func BatchIndexCursor(z redis.Z) string {
+	return fmt.Sprintf("%d:%s", int64(z.Score), z.Member)
+}
+
+// parseBatchIndexCursor - the create time (ms) && id of the last batch of the previous page
+func parseBatchIndexCursor(cursor string) (int64, string, error) {
+	i := strings.IndexByte(cursor, ':')
+	if i < 0 {
+		return 0, "", ErrInvalidBatchCursor
+	}
+	score, err := strconv.ParseInt(cursor[:i], 10, 64)
+	if (err != nil) || (cursor[i+1:] == "") {
+		return 0, "", ErrInvalidBatchCursor
+	}
+	return score, cursor[i+1:], nil
+}
+
+// ScanBatchIndex - up to `count` batches created in (`after`, `before`), newest first, starting after `cursor` (|| the
+// newest batch if empty); batches of `owner` only if set. A zero `after` || `before` isn't a bound
+func ScanBatchIndex(
+	ctx context.Context, client *redis.Client, owner string, after, before time.Time, cursor string, count int,
+) ([]redis.Z, error) {
+
+	var key = batchIndexCreatedKey
+	if owner != "" {
+		key = batchIndexOwnerPrefix + owner
+	}
+
+	var min, max = "-inf", "+inf"
+	if !after.IsZero() {
+		min = "(" + strconv.FormatInt(after.UnixMilli(), 10)
+	}
+	if !before.IsZero() {
+		max = "(" + strconv.FormatInt(before.UnixMilli(), 10)
+	}
+
+	// batches created in the same ms are ordered by id (descending), those up to && including the cursor's id were
+	// on the previous page
+	var cursorScore int64
+	var cursorID string
+	if cursor != "" {
+		var err error
+		if cursorScore, cursorID, err = parseBatchIndexCursor(cursor); err != nil {
+			return nil, err
+		}
+		if before.IsZero() || (cursorScore < before.UnixMilli()) {
+			max = strconv.FormatInt(cursorScore, 10)
+		}
+	}
+
+	var page []redis.Z
+	for offset := int64(0); len(page) < count; offset += int64(count) {
+		zs, err := client.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+			Min:    min,
+			Max:    max,
+			Offset: offset,
+			Count:  int64(count),
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, z := range zs {
+			id, _ := SafeCast[string](z.Member)
+			if (cursorID != "") && (int64(z.Score) == cursorScore) && (id >= cursorID) {
+				continue
+			}
+			if len(page) < count {
+				page = append(page, z)
+			}
+		}
+
+		if len(zs) < count {
+			break
+		}
+	}
+	return page, nil
+}
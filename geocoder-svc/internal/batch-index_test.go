@@ -0,0 +1,202 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	// external
+	redis "github.com/go-redis/redis/v8"
+)
+
+func TestBatchIndexCursor(t *testing.T) {
+	cursor := BatchIndexCursor(redis.Z{Score: 1700000000123, Member: "batch-1"})
+	if cursor != "1700000000123:batch-1" {
+		t.Errorf("BatchIndexCursor() = %q", cursor)
+	}
+
+	score, id, err := parseBatchIndexCursor(cursor)
+	if (err != nil) || (score != 1700000000123) || (id != "batch-1") {
+		t.Errorf("parseBatchIndexCursor(%q) = %v, %q, %v", cursor, score, id, err)
+	}
+
+	for _, cursor := range []string{"", "batch-1", "1700000000123:", "abc:batch-1", ":batch-1"} {
+		if _, _, err := parseBatchIndexCursor(cursor); err != ErrInvalidBatchCursor {
+			t.Errorf("parseBatchIndexCursor(%q) = %v, want %v", cursor, err, ErrInvalidBatchCursor)
+		}
+	}
+}
+
+// testBatch - a batch as indexed by `IndexBatch`
+type testBatch struct {
+	id      string
+	owner   string
+	created time.Time
+}
+
+// indexTestBatches - indexes `n` batches of alternating owners, created 1 minute apart from `start`; every third
+// batch shares its create time w. the previous one so pages split ties. Returns the batches newest first
+func indexTestBatches(t *testing.T, client *redis.Client, start time.Time, n int) []testBatch {
+	t.Helper()
+	ctx := context.Background()
+
+	var batches []testBatch
+	created := start
+	for i := 0; i < n; i++ {
+		if i%3 != 2 {
+			created = created.Add(time.Minute)
+		}
+		b := testBatch{id: fmt.Sprintf("batch-%02d", i), owner: []string{"alice", "bob"}[i%2], created: created}
+		if _, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			IndexBatch(ctx, pipe, b.id, b.owner, b.created, time.Hour*24)
+			return nil
+		}); err != nil {
+			t.Fatalf("IndexBatch() = %v", err)
+		}
+		batches = append([]testBatch{b}, batches...)
+	}
+
+	// ties are ordered by id, descending
+	for i := 1; i < len(batches); i++ {
+		if batches[i].created.Equal(batches[i-1].created) && (batches[i].id > batches[i-1].id) {
+			batches[i], batches[i-1] = batches[i-1], batches[i]
+		}
+	}
+	return batches
+}
+
+// scanAllBatches - pages through the index w. `ScanBatchIndex` the way `ListBatches` does, returns the ids read
+func scanAllBatches(t *testing.T, client *redis.Client, owner string, after, before time.Time, count int) []string {
+	t.Helper()
+
+	var ids []string
+	var cursor string
+	for pages := 0; pages < 100; pages++ {
+		page, err := ScanBatchIndex(context.Background(), client, owner, after, before, cursor, count)
+		if err != nil {
+			t.Fatalf("ScanBatchIndex() = %v", err)
+		}
+		for _, z := range page {
+			ids = append(ids, z.Member.(string))
+			cursor = BatchIndexCursor(z)
+		}
+		if len(page) < count {
+			return ids
+		}
+	}
+	t.Fatalf("ScanBatchIndex() never exhausted the index")
+	return nil
+}
+
+func TestScanBatchIndexPages(t *testing.T) {
+	client, _ := newFakeRedis(t)
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	batches := indexTestBatches(t, client, start, 20)
+
+	var tests = []struct {
+		name          string
+		owner         string
+		after, before time.Time
+		keep          func(b testBatch) bool
+	}{
+		{name: "all", keep: func(b testBatch) bool { return true }},
+		{name: "owner", owner: "bob", keep: func(b testBatch) bool { return b.owner == "bob" }},
+		{
+			name:  "after",
+			after: batches[10].created,
+			keep:  func(b testBatch) bool { return b.created.After(batches[10].created) },
+		},
+		{
+			name:   "before",
+			before: batches[5].created,
+			keep:   func(b testBatch) bool { return b.created.Before(batches[5].created) },
+		},
+		{
+			name:  "owner, after && before",
+			owner: "alice", after: batches[15].created, before: batches[3].created,
+			keep: func(b testBatch) bool {
+				return (b.owner == "alice") && b.created.After(batches[15].created) && b.created.Before(batches[3].created)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		var want []string
+		for _, b := range batches {
+			if tt.keep(b) {
+				want = append(want, b.id)
+			}
+		}
+
+		for _, count := range []int{1, 2, 3, 7, 50} {
+			if got := scanAllBatches(t, client, tt.owner, tt.after, tt.before, count); !equalIDs(got, want) {
+				t.Errorf("%s, pages of %d: ScanBatchIndex() = %v, want %v", tt.name, count, got, want)
+			}
+		}
+	}
+}
+
+func TestScanBatchIndexCursorBeforeBound(t *testing.T) {
+	client, _ := newFakeRedis(t)
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	batches := indexTestBatches(t, client, start, 6)
+
+	// a cursor newer than `before` (e.g. the filter changed between pages) doesn't widen the range
+	cursor := BatchIndexCursor(redis.Z{Score: float64(batches[0].created.UnixMilli()), Member: batches[0].id})
+	page, err := ScanBatchIndex(context.Background(), client, "", time.Time{}, batches[3].created, cursor, 10)
+	if err != nil {
+		t.Fatalf("ScanBatchIndex() = %v", err)
+	}
+	for _, z := range page {
+		if int64(z.Score) >= batches[3].created.UnixMilli() {
+			t.Errorf("ScanBatchIndex() returned %v, created at || after `before`", z.Member)
+		}
+	}
+
+	if _, err := ScanBatchIndex(context.Background(), client, "", time.Time{}, time.Time{}, "bad", 10); err != ErrInvalidBatchCursor {
+		t.Errorf("ScanBatchIndex(bad cursor) = %v, want %v", err, ErrInvalidBatchCursor)
+	}
+}
+
+func TestIndexBatchTrimsExpired(t *testing.T) {
+	client, fake := newFakeRedis(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	index := func(id, owner string, created time.Time) {
+		if _, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			IndexBatch(ctx, pipe, id, owner, created, time.Hour)
+			return nil
+		}); err != nil {
+			t.Fatalf("IndexBatch() = %v", err)
+		}
+	}
+
+	index("old", "alice", now.Add(-2*time.Hour))
+	index("anonymous", "", now.Add(-time.Minute))
+	index("new", "alice", now)
+
+	if _, ok := fake.zscore(batchIndexCreatedKey, "old"); ok {
+		t.Errorf("batch older than the retention still in %s", batchIndexCreatedKey)
+	}
+	if _, ok := fake.zscore(batchIndexOwnerPrefix+"alice", "old"); ok {
+		t.Errorf("batch older than the retention still in the owner index")
+	}
+	if got := fake.zcard(batchIndexCreatedKey); got != 2 {
+		t.Errorf("%s has %d batches, want 2", batchIndexCreatedKey, got)
+	}
+
+	// batches w.o. an owner are only in the create time index
+	if fake.zcard(batchIndexOwnerPrefix) > 0 {
+		t.Errorf("batch w.o. an owner indexed under an empty owner")
+	}
+}
+
+func TestParseOperatorOwners(t *testing.T) {
+	owners := ParseOperatorOwners(" op-1,,op-2 ,")
+	if (len(owners) != 2) || !owners["op-1"] || !owners["op-2"] {
+		t.Errorf("ParseOperatorOwners() = %v, want op-1, op-2", owners)
+	}
+}
@@ -0,0 +1,330 @@
+package srv
+
+import (
+
+	// standard lib
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	// external
+	redis "github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// BatchCreatesStream - stream of new batches, each entry is the `batch_id` of a batch saved to storage
+	BatchCreatesStream = "batch.creates"
+
+	// BatchStatusStream - stream of batch status updates, each entry is a `payload` of a proto BatchStatusResponse
+	BatchStatusStream = "batch.status"
+
+	// BatchWorkersGroup - consumer group of the workers on `BatchCreatesStream`, each batch goes to one worker
+	BatchWorkersGroup = "batch-workers"
+
+	// BatchServersGroup - consumer group of the batch servers on `BatchStatusStream`
+	BatchServersGroup = "batch-servers"
+
+	// BatchRetriesKey - sorted set of failed batches waiting to be retried, scored by when they're due
+	BatchRetriesKey = "batch.retries"
+
+	// BatchDeadLetterStream - stream of batches that failed every attempt (or w. an error that isn't retried), kept for
+	// operators to inspect && retry
+	BatchDeadLetterStream = "batch.dead-letter"
+
+	// BatchCancelChannel - pub/sub channel of canceled batches, each message is the id of a batch to stop
+	BatchCancelChannel = "batch.cancel"
+
+	// batchCanceledKeyPrefix, batchCanceledTTL - marks a canceled batch so batches still queued || waiting to be
+	// retried are skipped; the TTL only needs to outlast the queue && retry backlog
+	batchCanceledKeyPrefix = "batch.canceled:"
+	batchCanceledTTL       = time.Hour * 24 * 7
+
+	// batchQueueMaxLen - approx. max. length of a stream, acknowledged entries are only kept for debugging
+	batchQueueMaxLen = 100000
+
+	// batchDeadLetterScanCount - entries read per call when looking up a batch in `BatchDeadLetterStream`
+	batchDeadLetterScanCount = 500
+
+	// batchQueueRetryInterval - wait before reading again after a failed read, e.g. while redis restarts
+	batchQueueRetryInterval = time.Second * 5
+)
+
+// BatchQueue - a queue of batch events on a redis stream. Each entry is delivered to a single consumer of the
+// group && stays pending until acknowledged; entries pending for longer than `ClaimIdle` (e.g. the consumer crashed)
+// are claimed && redelivered to another consumer
+type BatchQueue struct {
+	Client    *redis.Client
+	Stream    string
+	Group     string
+	Consumer  string        // unique per process, a restarted consumer w. the same name reads its own pending entries
+	ClaimIdle time.Duration // must exceed the time to handle an entry, else entries are handled twice
+	Block     time.Duration // max. wait for new entries before checking for idle entries
+}
+
+// Publish - adds an entry to the stream, entries published before any consumer starts are kept until read
+func (q *BatchQueue) Publish(ctx context.Context, values map[string]interface{}) (string, error) {
+	return q.Client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.Stream,
+		MaxLen: batchQueueMaxLen,
+		Approx: true,
+		Values: values,
+	}).Result()
+}
+
+// CreateGroup - creates the consumer group (&& the stream) if it doesn't exist, the group starts from the first
+// entry so entries published before the group existed aren't lost
+func (q *BatchQueue) CreateGroup(ctx context.Context) error {
+	err := q.Client.XGroupCreateMkStream(ctx, q.Stream, q.Group, "0").Err()
+	if (err != nil) && strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil
+	}
+	return err
+}
+
+// Consume - calls `handle` w. each entry delivered to this consumer until `ctx` is done. An entry is acknowledged
+// once `handle` returns nil, && is left pending (to be claimed once idle) if it returns an error
+func (q *BatchQueue) Consume(ctx context.Context, handle func(ctx context.Context, msg redis.XMessage) error) error {
+
+	if err := q.CreateGroup(ctx); err != nil {
+		return err
+	}
+
+	var claimStart = "0-0" // cursor of XAUTOCLAIM, wraps to `0-0` once every pending entry is checked
+	var readStart = "0"    // read this consumer's own pending entries first, then new entries (`>`)
+
+	for ctx.Err() == nil {
+
+		msgs, err := q.next(ctx, &claimStart, &readStart)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			log.WithFields(log.Fields{
+				"err":            err,
+				"queue.stream":   q.Stream,
+				"queue.group":    q.Group,
+				"queue.consumer": q.Consumer,
+			}).Error("failed reading from queue")
+			time.Sleep(batchQueueRetryInterval)
+			continue
+		}
+
+		for _, msg := range msgs {
+			if err := handle(ctx, msg); err != nil {
+				log.WithFields(log.Fields{
+					"err":          err,
+					"queue.stream": q.Stream,
+					"queue.id":     msg.ID,
+				}).Warn("failed handling entry; left pending")
+				continue
+			}
+
+			if err := q.Client.XAck(ctx, q.Stream, q.Group, msg.ID).Err(); err != nil {
+				log.WithFields(log.Fields{
+					"err":          err,
+					"queue.stream": q.Stream,
+					"queue.id":     msg.ID,
+				}).Error("failed acknowledging entry")
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// next - the next entries for this consumer, in order: entries claimed from idle consumers, this consumer's own
+// pending entries, && new entries
+func (q *BatchQueue) next(ctx context.Context, claimStart, readStart *string) ([]redis.XMessage, error) {
+
+	// note: go-redis v8 expects the 2-element reply of redis 6.2, redis 7 adds the ids of deleted entries
+	res, err := q.Client.Do(
+		ctx, "XAUTOCLAIM", q.Stream, q.Group, q.Consumer, q.ClaimIdle.Milliseconds(), *claimStart, "COUNT", 1,
+	).Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) < 2 {
+		return nil, fmt.Errorf("unexpected XAUTOCLAIM reply of %d elements", len(res))
+	}
+
+	*claimStart, _ = SafeCast[string](res[0])
+	claimed := parseXMessages(res[1])
+
+	if len(claimed) > 0 {
+		log.WithFields(log.Fields{
+			"queue.stream":   q.Stream,
+			"queue.id":       claimed[0].ID,
+			"queue.consumer": q.Consumer,
+		}).Warn("claimed idle entry")
+		return claimed, nil
+	}
+
+	// own pending entries are read w.o. blocking, new entries block up to `Block`
+	var block = q.Block
+	if *readStart != ">" {
+		block = -1
+	}
+
+	streams, err := q.Client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    q.Group,
+		Consumer: q.Consumer,
+		Streams:  []string{q.Stream, *readStart},
+		Count:    1,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []redis.XMessage
+	for _, s := range streams {
+		msgs = append(msgs, s.Messages...)
+	}
+
+	// pending entries are read after the last entry read, once there are none left read new entries
+	if *readStart != ">" {
+		if len(msgs) == 0 {
+			*readStart = ">"
+		} else {
+			*readStart = msgs[len(msgs)-1].ID
+		}
+	}
+	return msgs, nil
+}
+
+// parseXMessages - parses the entries of a raw stream reply, entries deleted from the stream (nil) are skipped
+func parseXMessages(v interface{}) []redis.XMessage {
+	entries, _ := SafeCast[[]interface{}](v)
+
+	var msgs []redis.XMessage
+	for _, e := range entries {
+		entry, _ := SafeCast[[]interface{}](e)
+		if len(entry) < 2 {
+			continue
+		}
+
+		id, _ := SafeCast[string](entry[0])
+		fields, _ := SafeCast[[]interface{}](entry[1])
+		if (id == "") || (fields == nil) {
+			continue
+		}
+
+		var values = make(map[string]interface{}, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			k, _ := SafeCast[string](fields[i])
+			values[k] = fields[i+1]
+		}
+		msgs = append(msgs, redis.XMessage{ID: id, Values: values})
+	}
+	return msgs
+}
+
+// promoteBatchRetriesScript - atomically moves due retries from `BatchRetriesKey` to `BatchCreatesStream`, so a retry
+// is queued exactly once when several workers promote retries at the same time
+var promoteBatchRetriesScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, member in ipairs(due) do
+	redis.call('ZREM', KEYS[1], member)
+	local id, attempt = string.match(member, '^(.*):(%d+)$')
+	redis.call('XADD', KEYS[2], 'MAXLEN', '~', ARGV[3], '*', 'batch_id', id, 'attempt', attempt)
+end
+return #due
+`)
+
+// BatchAttempt - the attempt number of a batch entry, 1 for entries w.o. an attempt (e.g. new batches)
+func BatchAttempt(msg redis.XMessage) int {
+	v, _ := SafeCast[string](msg.Values["attempt"])
+	if attempt, err := strconv.Atoi(v); (err == nil) && (attempt > 0) {
+		return attempt
+	}
+	return 1
+}
+
+// BatchRetryBackoff - the wait before retrying a batch after a failed attempt, doubles w. each attempt up to `max`;
+// up to 20% jitter is added so batches that failed together aren't retried together
+func BatchRetryBackoff(attempt int, base, max time.Duration) time.Duration {
+	var backoff = base
+	for i := 1; (i < attempt) && (backoff < max); i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return backoff + time.Duration(rand.Int63n(int64(backoff)/5+1))
+}
+
+// ScheduleBatchRetry - schedules attempt `attempt` of a batch at `due`
+func ScheduleBatchRetry(ctx context.Context, client *redis.Client, id string, attempt int, due time.Time) error {
+	return client.ZAdd(ctx, BatchRetriesKey, &redis.Z{
+		Score:  float64(due.UnixMilli()),
+		Member: fmt.Sprintf("%s:%d", id, attempt),
+	}).Err()
+}
+
+// PromoteBatchRetries - queues up to `count` retries due by `now`, returns the number queued
+func PromoteBatchRetries(ctx context.Context, client *redis.Client, now time.Time, count int) (int, error) {
+	return promoteBatchRetriesScript.Run(
+		ctx, client, []string{BatchRetriesKey, BatchCreatesStream}, now.UnixMilli(), count, batchQueueMaxLen,
+	).Int()
+}
+
+// DeadLetterBatch - adds a batch that failed every attempt (or w. an error that isn't retried) to `BatchDeadLetterStream`
+func DeadLetterBatch(ctx context.Context, client *redis.Client, id string, attempts int, reason error) error {
+	return client.XAdd(ctx, &redis.XAddArgs{
+		Stream: BatchDeadLetterStream,
+		MaxLen: batchQueueMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"batch_id":    id,
+			"attempts":    attempts,
+			"error":       reason.Error(),
+			"failed_time": time.Now().Format(time.RFC3339Nano),
+		},
+	}).Err()
+}
+
+// RemoveDeadLetter - deletes a batch's entry from `BatchDeadLetterStream` (e.g. once it's requeued), the stream is
+// read newest first until the entry is found; returns false if the batch has no entry (e.g. it was trimmed)
+func RemoveDeadLetter(ctx context.Context, client *redis.Client, id string) (bool, error) {
+	var end = "+"
+	for {
+		msgs, err := client.XRevRangeN(ctx, BatchDeadLetterStream, end, "-", batchDeadLetterScanCount).Result()
+		if err != nil {
+			return false, err
+		}
+
+		for _, msg := range msgs {
+			if batchID, _ := SafeCast[string](msg.Values["batch_id"]); batchID == id {
+				return true, client.XDel(ctx, BatchDeadLetterStream, msg.ID).Err()
+			}
+		}
+		if len(msgs) < batchDeadLetterScanCount {
+			return false, nil
+		}
+		end = "(" + msgs[len(msgs)-1].ID
+	}
+}
+
+// CancelBatch - marks a batch canceled && signals the workers, a worker processing the batch stops it before the
+// results are saved
+func CancelBatch(ctx context.Context, client *redis.Client, id string) error {
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, batchCanceledKeyPrefix+id, 1, batchCanceledTTL)
+		pipe.Publish(ctx, BatchCancelChannel, id)
+		return nil
+	})
+	return err
+}
+
+// BatchCanceled - checks if a batch was canceled, the signal on `BatchCancelChannel` isn't delivered to workers
+// that are disconnected so workers also check before starting && before saving a batch
+func BatchCanceled(ctx context.Context, client *redis.Client, id string) (bool, error) {
+	n, err := client.Exists(ctx, batchCanceledKeyPrefix+id).Result()
+	return n > 0, err
+}
@@ -0,0 +1,119 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	// external
+	redis "github.com/go-redis/redis/v8"
+)
+
+func TestBatchAttempt(t *testing.T) {
+	var tests = []struct {
+		values map[string]interface{}
+		want   int
+	}{
+		{map[string]interface{}{"batch_id": "b1"}, 1},
+		{map[string]interface{}{"batch_id": "b1", "attempt": "3"}, 3},
+		{map[string]interface{}{"batch_id": "b1", "attempt": "0"}, 1},
+		{map[string]interface{}{"batch_id": "b1", "attempt": "-2"}, 1},
+		{map[string]interface{}{"batch_id": "b1", "attempt": "x"}, 1},
+		{map[string]interface{}{"batch_id": "b1", "attempt": 3}, 1}, // stream values are always read as strings
+	}
+
+	for _, tt := range tests {
+		if got := BatchAttempt(redis.XMessage{ID: "1-0", Values: tt.values}); got != tt.want {
+			t.Errorf("BatchAttempt(%v) = %d, want %d", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestBatchRetryBackoff(t *testing.T) {
+	const base, max = time.Second, time.Second * 30
+
+	var tests = []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, time.Second * 2},
+		{3, time.Second * 4},
+		{5, time.Second * 16},
+		{6, max},
+		{100, max},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			got := BatchRetryBackoff(tt.attempt, base, max)
+			if (got < tt.want) || (got > tt.want+tt.want/5) {
+				t.Errorf("BatchRetryBackoff(%d) = %v, want %v + up to 20%%", tt.attempt, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseXMessages(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{"1-0", []interface{}{"batch_id", "b1", "attempt", "2"}},
+		[]interface{}{"2-0", nil}, // deleted from the stream while pending
+		nil,
+		[]interface{}{"3-0"},
+		[]interface{}{"4-0", []interface{}{"batch_id", "b4", "dangling"}},
+	}
+
+	msgs := parseXMessages(reply)
+	if len(msgs) != 2 {
+		t.Fatalf("parseXMessages() = %v, want 2 entries", msgs)
+	}
+	if (msgs[0].ID != "1-0") || (msgs[0].Values["batch_id"] != "b1") || (BatchAttempt(msgs[0]) != 2) {
+		t.Errorf("parseXMessages()[0] = %+v", msgs[0])
+	}
+	if (msgs[1].ID != "4-0") || (msgs[1].Values["batch_id"] != "b4") || (len(msgs[1].Values) != 1) {
+		t.Errorf("parseXMessages()[1] = %+v", msgs[1])
+	}
+
+	if msgs := parseXMessages(nil); len(msgs) != 0 {
+		t.Errorf("parseXMessages(nil) = %v, want none", msgs)
+	}
+}
+
+func TestRemoveDeadLetter(t *testing.T) {
+	client, f := newFakeRedis(t)
+	ctx := context.Background()
+
+	// the batch is older than a full page of entries, so it's only found on the second page
+	if err := DeadLetterBatch(ctx, client, "batch-1", 5, errors.New("unavailable")); err != nil {
+		t.Fatalf("DeadLetterBatch() = %v", err)
+	}
+	for i := 0; i < batchDeadLetterScanCount+10; i++ {
+		if err := DeadLetterBatch(ctx, client, fmt.Sprintf("other-%d", i), 5, errors.New("unavailable")); err != nil {
+			t.Fatalf("DeadLetterBatch() = %v", err)
+		}
+	}
+
+	var tests = []struct {
+		id   string
+		want bool
+	}{
+		{"batch-1", true},
+		{"batch-1", false}, // already removed
+		{"unknown", false},
+		{"other-3", true},
+	}
+
+	for _, tt := range tests {
+		if got, err := RemoveDeadLetter(ctx, client, tt.id); (err != nil) || (got != tt.want) {
+			t.Errorf("RemoveDeadLetter(%s) = %v, %v, want %v", tt.id, got, err, tt.want)
+		}
+	}
+	if n := f.xlen(BatchDeadLetterStream); n != batchDeadLetterScanCount+9 {
+		t.Errorf("dead-letter stream has %d entries, want %d", n, batchDeadLetterScanCount+9)
+	}
+}
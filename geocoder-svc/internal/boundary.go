@@ -0,0 +1,131 @@
+package srv
+
+import (
+	// standard lib
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// BoundaryQuery - a backend-neutral point-in-polygon query
+type BoundaryQuery struct {
+	Point  *pb.Point // query point
+	Layers []string  // only boundaries in one of `Layers` are considered, all layers if empty
+}
+
+// multiPolygon - polygons as rings of (lng, lat) positions, the first ring of each polygon is the exterior
+// and all others are holes; same layout as GeoJSON
+type multiPolygon [][][][2]float64
+
+// geoJSONGeometry - the subset of a GeoJSON geometry (or a Feature wrapping one) used for boundaries
+type geoJSONGeometry struct {
+	Type        string           `json:"type"`
+	Coordinates json.RawMessage  `json:"coordinates"`
+	Geometry    *geoJSONGeometry `json:"geometry"`
+}
+
+// boundaryKey - the key a boundary is stored under, ids are only unique within a layer
+func boundaryKey(layer, id string) string {
+	return "boundary:" + layer + ":" + id
+}
+
+// NormalizeBoundaryLayer - layers are matched case-insensitively, e.g. `Borough` -> `borough`
+func NormalizeBoundaryLayer(layer string) string {
+	return strings.ToLower(strings.TrimSpace(layer))
+}
+
+// parseBoundaryGeometry - parses a GeoJSON Polygon, MultiPolygon or a Feature w. either as its geometry
+func parseBoundaryGeometry(s string) (multiPolygon, error) {
+	var g geoJSONGeometry
+	if err := json.Unmarshal([]byte(s), &g); err != nil {
+		return nil, ErrInvalidBoundaryGeometry
+	}
+
+	if (g.Type == "Feature") && (g.Geometry != nil) {
+		g = *g.Geometry
+	}
+
+	var mp multiPolygon
+	switch g.Type {
+	case "Polygon":
+		var p [][][2]float64
+		if err := json.Unmarshal(g.Coordinates, &p); err != nil {
+			return nil, ErrInvalidBoundaryGeometry
+		}
+		mp = multiPolygon{p}
+	case "MultiPolygon":
+		if err := json.Unmarshal(g.Coordinates, &mp); err != nil {
+			return nil, ErrInvalidBoundaryGeometry
+		}
+	default:
+		return nil, ErrInvalidBoundaryGeometry
+	}
+
+	for _, p := range mp {
+		if (len(p) == 0) || (len(p[0]) < 4) {
+			return nil, ErrInvalidBoundaryGeometry
+		}
+	}
+	return mp, nil
+}
+
+// bounds - (min lat, min lng, max lat, max lng) of all exterior rings
+func (mp multiPolygon) bounds() (float64, float64, float64, float64) {
+	minLat, minLng, maxLat, maxLng := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range mp {
+		for _, pos := range p[0] {
+			minLng, maxLng = math.Min(minLng, pos[0]), math.Max(maxLng, pos[0])
+			minLat, maxLat = math.Min(minLat, pos[1]), math.Max(maxLat, pos[1])
+		}
+	}
+	return minLat, minLng, maxLat, maxLng
+}
+
+// contains - even-odd ray casting over every ring of each polygon, a point in a hole crosses two rings
+func (mp multiPolygon) contains(pt *pb.Point) bool {
+	lat, lng := float64(pt.Latitude), float64(pt.Longitude)
+	for _, p := range mp {
+		var inside bool
+		for _, ring := range p {
+			for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+				if ((ring[i][1] > lat) != (ring[j][1] > lat)) &&
+					(lng < (ring[j][0]-ring[i][0])*(lat-ring[i][1])/(ring[j][1]-ring[i][1])+ring[i][0]) {
+					inside = !inside
+				}
+			}
+		}
+		if inside {
+			return true
+		}
+	}
+	return false
+}
+
+// BoundaryContains - checks a boundary's geometry contains a point, invalid geometries contain nothing
+func BoundaryContains(b *pb.Boundary, pt *pb.Point) bool {
+	mp, err := parseBoundaryGeometry(b.Geojson)
+	return (err == nil) && mp.contains(pt)
+}
+
+// ValidateBoundary - checks a boundary has an id, a layer && a valid geometry
+func ValidateBoundary(b *pb.Boundary) error {
+	if (strings.TrimSpace(b.Id) == "") || (NormalizeBoundaryLayer(b.Layer) == "") {
+		return ErrInvalidBoundary
+	}
+	_, err := parseBoundaryGeometry(b.Geojson)
+	return err
+}
+
+// sortBoundaries - sorts boundaries by layer, then id
+func sortBoundaries(boundaries []*pb.Boundary) {
+	sort.Slice(boundaries, func(i, j int) bool {
+		if boundaries[i].Layer == boundaries[j].Layer {
+			return boundaries[i].Id < boundaries[j].Id
+		}
+		return boundaries[i].Layer < boundaries[j].Layer
+	})
+}
@@ -0,0 +1,111 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// squareWithHole - a 10x10 square w. a 2x2 hole in the middle, as (lng, lat) positions
+const squareWithHole = `{"type": "Polygon", "coordinates": [
+	[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]],
+	[[4, 4], [6, 4], [6, 6], [4, 6], [4, 4]]
+]}`
+
+// twoSquares - disjoint unit squares at (0, 0) && (20, 20), wrapped in a Feature
+const twoSquares = `{"type": "Feature", "properties": {}, "geometry": {"type": "MultiPolygon", "coordinates": [
+	[[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]],
+	[[[20, 20], [21, 20], [21, 21], [20, 21], [20, 20]]]
+]}}`
+
+func TestBoundaryContains(t *testing.T) {
+	var tests = []struct {
+		geojson  string
+		lat, lng float32
+		want     bool
+	}{
+		{squareWithHole, 2, 2, true},
+		{squareWithHole, 5, 5, false}, // in the hole
+		{squareWithHole, 4.5, 7, true},
+		{squareWithHole, 11, 5, false},
+		{squareWithHole, 5, -1, false},
+		{twoSquares, 0.5, 0.5, true},
+		{twoSquares, 20.5, 20.5, true},
+		{twoSquares, 10, 10, false},
+		{`{"type": "Point", "coordinates": [0, 0]}`, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		b := &pb.Boundary{Id: "b", Layer: "test", Geojson: tt.geojson}
+		if got := BoundaryContains(b, &pb.Point{Latitude: tt.lat, Longitude: tt.lng}); got != tt.want {
+			t.Errorf("BoundaryContains(%.20q, (%v, %v)) = %v, want %v", tt.geojson, tt.lat, tt.lng, got, tt.want)
+		}
+	}
+}
+
+func TestValidateBoundary(t *testing.T) {
+	for _, b := range []*pb.Boundary{
+		{Id: "b", Layer: "test", Geojson: squareWithHole},
+		{Id: "b", Layer: "test", Geojson: twoSquares},
+	} {
+		if err := ValidateBoundary(b); err != nil {
+			t.Errorf("ValidateBoundary(%v) = %v", b, err)
+		}
+	}
+
+	for _, b := range []*pb.Boundary{
+		{Id: "", Layer: "test", Geojson: squareWithHole},
+		{Id: "b", Layer: " ", Geojson: squareWithHole},
+		{Id: "b", Layer: "test", Geojson: "not json"},
+		{Id: "b", Layer: "test", Geojson: `{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`},
+		{Id: "b", Layer: "test", Geojson: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [0, 0]]]}`},
+		{Id: "b", Layer: "test", Geojson: `{"type": "Polygon", "coordinates": []}`},
+	} {
+		if err := ValidateBoundary(b); err == nil {
+			t.Errorf("ValidateBoundary(%v) = nil, want an error", b)
+		}
+	}
+}
+
+func TestMemorySearchBoundaries(t *testing.T) {
+	b := NewMemorySearchBackend()
+	ctx := context.Background()
+
+	if err := b.UpsertBoundaries(ctx, []*pb.Boundary{
+		{Id: "outer", Layer: "Borough", Name: "Outer", Geojson: squareWithHole},
+		{Id: "inner", Layer: "district", Name: "Inner", Geojson: `{"type": "Polygon", "coordinates": [[[1, 1], [3, 1], [3, 3], [1, 3], [1, 1]]]}`},
+	}); err != nil {
+		t.Fatalf("UpsertBoundaries() = %v", err)
+	}
+
+	var tests = []struct {
+		q    *BoundaryQuery
+		want []string
+	}{
+		{&BoundaryQuery{Point: &pb.Point{Latitude: 2, Longitude: 2}}, []string{"outer", "inner"}},
+		{&BoundaryQuery{Point: &pb.Point{Latitude: 2, Longitude: 2}, Layers: []string{"district"}}, []string{"inner"}},
+		{&BoundaryQuery{Point: &pb.Point{Latitude: 8, Longitude: 8}}, []string{"outer"}},
+		{&BoundaryQuery{Point: &pb.Point{Latitude: 5, Longitude: 5}}, []string{}},
+	}
+
+	for _, tt := range tests {
+		boundaries, err := b.SearchBoundaries(ctx, tt.q)
+		if err != nil {
+			t.Fatalf("SearchBoundaries(%v) = %v", tt.q.Point, err)
+		}
+
+		var got = make([]string, len(boundaries))
+		for i, bnd := range boundaries {
+			got[i] = bnd.Id
+			if bnd.Geojson != "" {
+				t.Errorf("SearchBoundaries(%v) returned a geometry, want it omitted", tt.q.Point)
+			}
+		}
+		if !equalIDs(got, tt.want) {
+			t.Errorf("SearchBoundaries(%v, %v) = %v, want %v", tt.q.Point, tt.q.Layers, got, tt.want)
+		}
+	}
+}
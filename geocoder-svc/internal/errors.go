@@ -18,20 +18,110 @@ var (
 	ErrRedisClient = errors.New("redis client error")
 
 	// ErrInvalidForwardGeocodeRequest -
-	ErrInvalidForwardGeocodeRequest = errors.New("forward geocode requests must have a valid `query_addr`")
+	ErrInvalidForwardGeocodeRequest = errors.New("forward geocode requests must have a valid `query_addr` or `query_structured`")
 
 	// ErrInvalidReverseGeocodeRequest -
 	ErrInvalidReverseGeocodeRequest = errors.New("reverse geocode requests must have a valid `query_lat` and `query_lng`")
 
 	// ErrInvalidGeocodeMethod -
-	ErrInvalidGeocodeMethod = errors.New("`method` must be one of (`FWD_FUZZY`, `REV_NEAREST`)")
+	ErrInvalidGeocodeMethod = errors.New("`method` must be one of (`FWD_FUZZY`, `FWD_INTERPOLATED`, `REV_NEAREST`)")
+
+	// ErrInvalidGeocodeFilter -
+	ErrInvalidGeocodeFilter = errors.New("`filter` must have a valid `bounding_box` (min <= max) and/or `center` w. a `radius_meters` between 0 and 50000")
 
 	// ErrMaxResultsOutofRange -
 	ErrMaxResultsOutofRange = errors.New("`max_results` must be an int between 1 and 1024")
 
+	// ErrMaxDistanceOutofRange -
+	ErrMaxDistanceOutofRange = errors.New("`max_distance_meters` must be between 0 and 1000")
+
+	// ErrInvalidBoundary -
+	ErrInvalidBoundary = errors.New("boundaries must have an `id` and a `layer`")
+
+	// ErrInvalidBoundaryGeometry -
+	ErrInvalidBoundaryGeometry = errors.New("boundary `geojson` must be a valid GeoJSON Polygon or MultiPolygon")
+
+	// ErrInvalidDataset -
+	ErrInvalidDataset = errors.New("`dataset` must be 1-64 lowercase letters, digits, `-` or `_` and not a reserved name")
+
+	// ErrDatasetNotFound -
+	ErrDatasetNotFound = errors.New("dataset not found")
+
+	// ErrAddressNotFound -
+	ErrAddressNotFound = errors.New("address not found")
+
+	// ErrAddressMissingID -
+	ErrAddressMissingID = errors.New("addresses must have an `id`")
+
+	// ErrAddressDuplicateID -
+	ErrAddressDuplicateID = errors.New("address `id` was already sent in this stream")
+
+	// ErrAddressInvalidLocation -
+	ErrAddressInvalidLocation = errors.New("address `location` must be set, not (0, 0) and within (-90, 90), (-180, 180); the redis search backend only indexes longitudes within (-85.05, 85.05)")
+
+	// ErrAddressOutOfBounds -
+	ErrAddressOutOfBounds = errors.New("address `location` is outside the dataset's bounding box")
+
+	// ErrAddressEmpty -
+	ErrAddressEmpty = errors.New("addresses must have a `composite_street_address` or address components")
+
+	// ErrInvalidBoundingBox -
+	ErrInvalidBoundingBox = errors.New("bounding box must be `min_lat,min_lng,max_lat,max_lng` w. min <= max")
+
+	// ErrIngestJobNotFound -
+	ErrIngestJobNotFound = errors.New("ingest job not found")
+
+	// ErrIngestJobMismatch -
+	ErrIngestJobMismatch = errors.New("all chunks in a stream must have the same `job_id`")
+
+	// ErrIngestChunkOutOfOrder -
+	ErrIngestChunkOutOfOrder = errors.New("chunk `offset` is past the job's `committed_offset`, chunks must be sent in order")
+
+	// ErrDatasetVersionNotFound -
+	ErrDatasetVersionNotFound = errors.New("dataset version not found")
+
+	// ErrDatasetVersionEmpty -
+	ErrDatasetVersionEmpty = errors.New("dataset version has no addresses")
+
+	// ErrDatasetVersionCountMismatch -
+	ErrDatasetVersionCountMismatch = errors.New("dataset version doesn't have `expected_count` addresses")
+
+	// ErrDatasetVersionPending -
+	ErrDatasetVersionPending = errors.New("a new version of the dataset is loading, apply changes once it's promoted")
+
+	// ErrDatasetVersionLive -
+	ErrDatasetVersionLive = errors.New("dataset version is being served, only unserved versions can be dropped")
+
+	// ErrDatasetVersionShrunk -
+	ErrDatasetVersionShrunk = errors.New("dataset version has far fewer addresses than the version being served, set `allow_shrink` to promote it")
+
+	// ErrDatasetMismatch -
+	ErrDatasetMismatch = errors.New("all messages in a stream must have the same `dataset` and `version`")
+
+	// ErrInvalidAddressChange -
+	ErrInvalidAddressChange = errors.New("address changes must set one of `upsert` or `delete`")
+
 	// ErrBatchMustHavePointsOrAddresses -
 	ErrBatchMustHavePointsOrAddresses = errors.New("batches must have points *or* addresses")
 
+	// ErrBatchNotFound -
+	ErrBatchNotFound = errors.New("batch not found")
+
+	// ErrBatchNotRetryable -
+	ErrBatchNotRetryable = errors.New("only `FAILED` batches can be retried")
+
+	// ErrBatchNotCancelable -
+	ErrBatchNotCancelable = errors.New("only batches that haven't finished (`SUCCESS`, `FAILED` or `REJECTED`) can be canceled")
+
+	// ErrBatchCanceled -
+	ErrBatchCanceled = errors.New("batch was canceled")
+
+	// ErrInvalidBatchCursor -
+	ErrInvalidBatchCursor = errors.New("`cursor` must be the `next_cursor` of a previous page")
+
+	// ErrInvalidBatchListFilter -
+	ErrInvalidBatchListFilter = errors.New("`created_after` must be before `created_before` and `limit` between 0 and 500")
+
 	// ErrEnvironmentNotSet -
 	ErrEnvironmentNotSet = errors.New("expected environment var not set")
 )
@@ -0,0 +1,370 @@
+package srv
+
+import (
+	// standard lib
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	// external
+	redis "github.com/go-redis/redis/v8"
+)
+
+// fakeRedis - a RESP2 server implementing the sorted set commands used by the batch index && the stream commands
+// used by the dead-letter stream, enough to test the queries built by the client w.o. a redis server; scripts &&
+// modules aren't supported, FT.SEARCH only replays the results set w. `setSearchResults`
+type fakeRedis struct {
+	mu       sync.Mutex
+	zsets    map[string]map[string]float64
+	streams  map[string][]fakeStreamEntry
+	searches map[string][]fakeSearchResult // index -> results of every FT.SEARCH on it, best match first
+	lastID   int64                         // ms part of the last stream entry id, entries are numbered `1-0`, `2-0`, ...
+}
+
+// fakeSearchResult - a document returned by FT.SEARCH, `fields` are field/value pairs
+type fakeSearchResult struct {
+	id     string
+	score  float64
+	fields []string
+}
+
+// fakeStreamEntry - an entry of a stream, `values` are field/value pairs
+type fakeStreamEntry struct {
+	id     int64
+	values []string
+}
+
+// newFakeRedis - starts a fake server for the duration of the test, returns a client connected to it
+func newFakeRedis(t *testing.T) (*redis.Client, *fakeRedis) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed starting fake redis: %v", err)
+	}
+
+	f := &fakeRedis{
+		zsets:    make(map[string]map[string]float64),
+		streams:  make(map[string][]fakeStreamEntry),
+		searches: make(map[string][]fakeSearchResult),
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{Addr: ln.Addr().String(), MaxRetries: -1})
+	t.Cleanup(func() {
+		client.Close()
+		ln.Close()
+	})
+	return client, f
+}
+
+// serve - reads commands from a connection until it's closed
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+	for {
+		args, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+		w.WriteString(f.exec(args))
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readRESPCommand - reads a command sent as an array of bulk strings
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("expected an array, got %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		if line, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, size+2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		args[i] = string(b[:size])
+	}
+	return args, nil
+}
+
+// exec - runs a command, returns its RESP reply
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "ZADD":
+		zset, ok := f.zsets[args[1]]
+		if !ok {
+			zset = make(map[string]float64)
+			f.zsets[args[1]] = zset
+		}
+		var added int
+		for i := 2; i+1 < len(args); i += 2 {
+			score, _ := strconv.ParseFloat(args[i], 64)
+			if _, ok := zset[args[i+1]]; !ok {
+				added++
+			}
+			zset[args[i+1]] = score
+		}
+		return fmt.Sprintf(":%d\r\n", added)
+	case "ZREMRANGEBYSCORE":
+		var removed int
+		for member, score := range f.zsets[args[1]] {
+			if scoreInRange(score, args[2], args[3]) {
+				delete(f.zsets[args[1]], member)
+				removed++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", removed)
+	case "ZREVRANGEBYSCORE":
+		return f.zrevrangebyscore(args)
+	case "FT.SEARCH":
+		return f.ftsearch(args)
+	case "XADD":
+		return f.xadd(args)
+	case "XREVRANGE":
+		return f.xrevrange(args)
+	case "XDEL":
+		var deleted int
+		for _, id := range args[2:] {
+			entries := f.streams[args[1]]
+			for i, e := range entries {
+				if fmt.Sprintf("%d-0", e.id) == id {
+					f.streams[args[1]] = append(entries[:i], entries[i+1:]...)
+					deleted++
+					break
+				}
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	}
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+// zrevrangebyscore - `ZREVRANGEBYSCORE key max min [WITHSCORES] [LIMIT offset count]`, ties are ordered by member
+// (descending) like redis
+func (f *fakeRedis) zrevrangebyscore(args []string) string {
+	var withScores bool
+	var offset, count = 0, -1
+	for i := 4; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WITHSCORES":
+			withScores = true
+		case "LIMIT":
+			offset, _ = strconv.Atoi(args[i+1])
+			count, _ = strconv.Atoi(args[i+2])
+			i += 2
+		}
+	}
+
+	var members []string
+	zset := f.zsets[args[1]]
+	for member, score := range zset {
+		if scoreInRange(score, args[3], args[2]) {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if zset[members[i]] == zset[members[j]] {
+			return members[i] > members[j]
+		}
+		return zset[members[i]] > zset[members[j]]
+	})
+
+	if offset > len(members) {
+		offset = len(members)
+	}
+	members = members[offset:]
+	if (count >= 0) && (count < len(members)) {
+		members = members[:count]
+	}
+
+	var reply []string
+	for _, member := range members {
+		reply = append(reply, member)
+		if withScores {
+			reply = append(reply, strconv.FormatFloat(zset[member], 'f', -1, 64))
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(reply))
+	for _, s := range reply {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(s), s)
+	}
+	return sb.String()
+}
+
+// ftsearch - `FT.SEARCH index query WITHSCORES ... [LIMIT offset num]`, the query is ignored && the results set for
+// the index are returned in order
+func (f *fakeRedis) ftsearch(args []string) string {
+	var offset, num = 0, 10
+	for i := 3; i+2 < len(args); i++ {
+		if strings.ToUpper(args[i]) == "LIMIT" {
+			offset, _ = strconv.Atoi(args[i+1])
+			num, _ = strconv.Atoi(args[i+2])
+		}
+	}
+
+	results := f.searches[args[1]]
+	if offset > len(results) {
+		offset = len(results)
+	}
+	page := results[offset:]
+	if num < len(page) {
+		page = page[:num]
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n:%d\r\n", 1+3*len(page), len(results))
+	for _, r := range page {
+		score := strconv.FormatFloat(r.score, 'f', -1, 64)
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n$%d\r\n%s\r\n*%d\r\n", len(r.id), r.id, len(score), score, len(r.fields))
+		for _, v := range r.fields {
+			fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(v), v)
+		}
+	}
+	return sb.String()
+}
+
+// setSearchResults - sets the results of every FT.SEARCH on an index
+func (f *fakeRedis) setSearchResults(index string, results []fakeSearchResult) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.searches[index] = results
+}
+
+// xadd - `XADD key [MAXLEN [~] n] * field value ...`, entries are never trimmed && ids are always generated
+func (f *fakeRedis) xadd(args []string) string {
+	i := 2
+	for strings.ToUpper(args[i]) == "MAXLEN" {
+		i += 2
+		if (args[i-1] == "~") || (args[i-1] == "=") {
+			i++
+		}
+	}
+
+	f.lastID++
+	f.streams[args[1]] = append(f.streams[args[1]], fakeStreamEntry{id: f.lastID, values: args[i+1:]})
+	id := fmt.Sprintf("%d-0", f.lastID)
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(id), id)
+}
+
+// xrevrange - `XREVRANGE key end start [COUNT n]`, bounds are `+`, `-` || ids w. an optional `(` (exclusive)
+func (f *fakeRedis) xrevrange(args []string) string {
+	var count = -1
+	if (len(args) == 6) && (strings.ToUpper(args[4]) == "COUNT") {
+		count, _ = strconv.Atoi(args[5])
+	}
+	bound := func(s string, inf float64) (float64, bool) {
+		switch s {
+		case "+", "-":
+			return inf, false
+		}
+		ms, _ := strconv.ParseFloat(strings.SplitN(strings.TrimPrefix(s, "("), "-", 2)[0], 64)
+		return ms, strings.HasPrefix(s, "(")
+	}
+	hi, hiExclusive := bound(args[2], math.Inf(1))
+	lo, loExclusive := bound(args[3], math.Inf(-1))
+
+	var sb, entries strings.Builder
+	var n int
+	stream := f.streams[args[1]]
+	for i := len(stream) - 1; (i >= 0) && (n != count); i-- {
+		id := float64(stream[i].id)
+		if (id > hi) || (hiExclusive && (id == hi)) || (id < lo) || (loExclusive && (id == lo)) {
+			continue
+		}
+		n++
+		fmt.Fprintf(&entries, "*2\r\n$%d\r\n%d-0\r\n*%d\r\n", len(fmt.Sprintf("%d-0", stream[i].id)), stream[i].id, len(stream[i].values))
+		for _, v := range stream[i].values {
+			fmt.Fprintf(&entries, "$%d\r\n%s\r\n", len(v), v)
+		}
+	}
+	fmt.Fprintf(&sb, "*%d\r\n%s", n, entries.String())
+	return sb.String()
+}
+
+// zscore - the score of a member of a sorted set, false if it isn't a member
+func (f *fakeRedis) zscore(key, member string) (float64, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	score, ok := f.zsets[key][member]
+	return score, ok
+}
+
+// xlen - the number of entries of a stream
+func (f *fakeRedis) xlen(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.streams[key])
+}
+
+// zcard - the number of members of a sorted set
+func (f *fakeRedis) zcard(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.zsets[key])
+}
+
+// scoreInRange - checks `min <= score <= max`, bounds may be exclusive (`(`) || infinite (`-inf`, `+inf`)
+func scoreInRange(score float64, min, max string) bool {
+	bound := func(s string) (float64, bool) {
+		exclusive := strings.HasPrefix(s, "(")
+		s = strings.TrimPrefix(s, "(")
+		switch s {
+		case "-inf":
+			return math.Inf(-1), exclusive
+		case "+inf", "inf":
+			return math.Inf(1), exclusive
+		}
+		v, _ := strconv.ParseFloat(s, 64)
+		return v, exclusive
+	}
+
+	lo, loExclusive := bound(min)
+	hi, hiExclusive := bound(max)
+	if (score < lo) || (loExclusive && (score == lo)) {
+		return false
+	}
+	return (score < hi) || (!hiExclusive && (score == hi))
+}
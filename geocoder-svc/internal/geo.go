@@ -0,0 +1,29 @@
+package srv
+
+import (
+	// standard lib
+	"math"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// earthRadiusMeters - mean radius of the earth, used for all great-circle distances
+const earthRadiusMeters = 6371008.8
+
+// metersPerDegreeLatitude - approximate length of one degree of latitude
+const metersPerDegreeLatitude = 111320.0
+
+// ReverseMaxDistanceMeters - upper bound on `max_distance_meters` for reverse geocoding
+const ReverseMaxDistanceMeters = 1000
+
+// HaversineDistance - great-circle distance (in meters) between two points
+func HaversineDistance(a, b *pb.Point) float64 {
+	lat1 := float64(a.Latitude) * math.Pi / 180
+	lat2 := float64(b.Latitude) * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := float64(b.Longitude-a.Longitude) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,292 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"time"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// memorySnapshotDefaultInterval - how often the writer saves && the readers check the snapshot file
+const memorySnapshotDefaultInterval = time.Second
+
+// MemorySnapshotOptions - shares a memory backend between processes through a snapshot file, e.g. addresses written
+// through the management service are served by the geocoder. The writer saves its data to `Path` after each change,
+// readers reload `Path` whenever it's replaced; both load `Path` on startup if it exists
+type MemorySnapshotOptions struct {
+	Path     string
+	Writer   bool          // only one process may write a snapshot
+	Interval time.Duration // max. delay between a write && readers serving it, `memorySnapshotDefaultInterval` if unset
+}
+
+// memorySnapshot - the data of a memory backend as saved to a snapshot file, indexes are rebuilt on load; messages
+// are stored as proto bytes
+type memorySnapshot struct {
+	LastVersion int64
+	Datasets    []memorySnapshotDataset // versions being served
+	Versions    []memorySnapshotDataset // unserved versions
+	Boundaries  [][]byte
+	Jobs        [][]byte
+}
+
+// memorySnapshotDataset - a version of a dataset in a snapshot
+type memorySnapshotDataset struct {
+	Name      string
+	Version   int64
+	LastWrite time.Time
+	Addresses [][]byte
+	Segments  [][]byte
+}
+
+// NewSnapshotMemorySearchBackend - creates an in-memory backend shared through a snapshot file, loading the snapshot
+// if it exists; the backend saves || reloads the snapshot until `ctx` is done
+func NewSnapshotMemorySearchBackend(ctx context.Context, opts *MemorySnapshotOptions) (*MemorySearchBackend, error) {
+	b := NewMemorySearchBackend()
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = memorySnapshotDefaultInterval
+	}
+
+	modTime, err := b.loadSnapshot(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Writer {
+		go b.saveSnapshots(ctx, opts.Path, interval)
+	} else {
+		go b.watchSnapshot(ctx, opts.Path, interval, modTime)
+	}
+	return b, nil
+}
+
+// changed - marks the backend as written to, the writer saves a snapshot on its next tick; caller must hold the
+// write lock
+func (b *MemorySearchBackend) changed() {
+	b.generation++
+}
+
+// snapshot - copies the backend's data; caller must hold the lock
+func (b *MemorySearchBackend) snapshot() *memorySnapshot {
+
+	snapshotDataset := func(name string, d *memoryDataset) memorySnapshotDataset {
+		sd := memorySnapshotDataset{Name: name, Version: d.version, LastWrite: d.lastWrite}
+		for _, address := range d.addresses {
+			ab, _ := proto.Marshal(address)
+			sd.Addresses = append(sd.Addresses, ab)
+		}
+		for _, seg := range d.segments {
+			sb, _ := proto.Marshal(seg)
+			sd.Segments = append(sd.Segments, sb)
+		}
+		return sd
+	}
+
+	var snap = &memorySnapshot{LastVersion: b.lastVersion}
+	for name, d := range b.datasets {
+		snap.Datasets = append(snap.Datasets, snapshotDataset(name, d))
+	}
+	for k, d := range b.versions {
+		snap.Versions = append(snap.Versions, snapshotDataset(k.dataset, d))
+	}
+	for _, mb := range b.bounds {
+		bb, _ := proto.Marshal(mb.boundary)
+		snap.Boundaries = append(snap.Boundaries, bb)
+	}
+	for _, job := range b.jobs {
+		jb, _ := proto.Marshal(job)
+		snap.Jobs = append(snap.Jobs, jb)
+	}
+	return snap
+}
+
+// restore - replaces the backend's data w. a snapshot, the indexes are built before the lock is taken
+func (b *MemorySearchBackend) restore(snap *memorySnapshot) error {
+
+	restoreDataset := func(sd memorySnapshotDataset) (*memoryDataset, error) {
+		d := newMemoryDataset()
+		d.version, d.lastWrite = sd.Version, sd.LastWrite
+
+		addresses := make([]*pb.Address, len(sd.Addresses))
+		for i, ab := range sd.Addresses {
+			addresses[i] = &pb.Address{}
+			if err := proto.Unmarshal(ab, addresses[i]); err != nil {
+				return nil, err
+			}
+		}
+		d.upsert(sd.Name, addresses)
+
+		for _, sb := range sd.Segments {
+			var seg pb.StreetSegment
+			if err := proto.Unmarshal(sb, &seg); err != nil {
+				return nil, err
+			}
+			d.segments[segmentKey(sd.Name, seg.Id)] = &seg
+		}
+		return d, nil
+	}
+
+	next := NewMemorySearchBackend()
+	next.lastVersion = snap.LastVersion
+
+	for _, sd := range snap.Datasets {
+		d, err := restoreDataset(sd)
+		if err != nil {
+			return err
+		}
+		next.datasets[sd.Name] = d
+	}
+	for _, sd := range snap.Versions {
+		d, err := restoreDataset(sd)
+		if err != nil {
+			return err
+		}
+		next.versions[memoryVersionKey{sd.Name, sd.Version}] = d
+	}
+
+	var boundaries []*pb.Boundary
+	for _, bb := range snap.Boundaries {
+		var bnd pb.Boundary
+		if err := proto.Unmarshal(bb, &bnd); err != nil {
+			return err
+		}
+		boundaries = append(boundaries, &bnd)
+	}
+	if err := next.UpsertBoundaries(context.Background(), boundaries); err != nil {
+		return err
+	}
+
+	for _, jb := range snap.Jobs {
+		var job pb.IngestJob
+		if err := proto.Unmarshal(jb, &job); err != nil {
+			return err
+		}
+		next.jobs[job.JobId] = &job
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.datasets, b.versions, b.lastVersion = next.datasets, next.versions, next.lastVersion
+	b.bounds, b.jobs = next.bounds, next.jobs
+	return nil
+}
+
+// loadSnapshot - restores the snapshot at `path` if it exists, returns its modification time (zero if it doesn't)
+func (b *MemorySearchBackend) loadSnapshot(path string) (time.Time, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var snap memorySnapshot
+	if err := gob.NewDecoder(f).Decode(&snap); err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed reading memory snapshot %s", path)
+	}
+	return info.ModTime(), b.restore(&snap)
+}
+
+// saveSnapshot - writes a snapshot to `path`, replacing it atomically so readers never see a partial file
+func (b *MemorySearchBackend) saveSnapshot(path string) error {
+	b.mu.RLock()
+	snap := b.snapshot()
+	b.mu.RUnlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(snap); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// saveSnapshots - saves a snapshot every `interval` if the backend changed since the last save, until `ctx` is done
+func (b *MemorySearchBackend) saveSnapshots(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var saved int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		b.mu.RLock()
+		generation := b.generation
+		b.mu.RUnlock()
+		if generation == saved {
+			continue
+		}
+
+		if err := b.saveSnapshot(path); err != nil {
+			log.WithFields(log.Fields{
+				"err":      err,
+				"snapshot": path,
+			}).Error("failed to save memory snapshot")
+			continue
+		}
+		saved = generation
+	}
+}
+
+// watchSnapshot - reloads the snapshot at `path` whenever it's replaced, until `ctx` is done
+func (b *MemorySearchBackend) watchSnapshot(ctx context.Context, path string, interval time.Duration, loaded time.Time) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if (err != nil) || !info.ModTime().After(loaded) {
+			continue
+		}
+
+		modTime, err := b.loadSnapshot(path)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":      err,
+				"snapshot": path,
+			}).Error("failed to reload memory snapshot")
+			continue
+		}
+		loaded = modTime
+
+		log.WithFields(log.Fields{
+			"snapshot": path,
+		}).Info("reloaded memory snapshot")
+	}
+}
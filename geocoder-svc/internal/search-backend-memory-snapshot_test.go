@@ -0,0 +1,115 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+func TestMemorySnapshotRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "memory.snapshot")
+
+	writer, err := NewSnapshotMemorySearchBackend(ctx, &MemorySnapshotOptions{Path: path, Writer: true, Interval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewSnapshotMemorySearchBackend(writer) = %v", err)
+	}
+	if err := writer.Upsert(ctx, DefaultDataset, LiveVersion, testAddresses()); err != nil {
+		t.Fatalf("Upsert() = %v", err)
+	}
+	if err := writer.UpsertBoundaries(ctx, []*pb.Boundary{{
+		Id: "square", Layer: "test", Geojson: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]]}`,
+	}}); err != nil {
+		t.Fatalf("UpsertBoundaries() = %v", err)
+	}
+	if err := writer.SaveIngestJob(ctx, &pb.IngestJob{JobId: "job-1", Dataset: DefaultDataset, CommittedOffset: 4}); err != nil {
+		t.Fatalf("SaveIngestJob() = %v", err)
+	}
+	if err := writer.saveSnapshot(path); err != nil {
+		t.Fatalf("saveSnapshot() = %v", err)
+	}
+
+	// a reader loads the snapshot on startup && rebuilds the indexes
+	reader, err := NewSnapshotMemorySearchBackend(ctx, &MemorySnapshotOptions{Path: path, Interval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewSnapshotMemorySearchBackend(reader) = %v", err)
+	}
+
+	results, err := reader.SearchText(ctx, &TextQuery{Text: "WALL ST", Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchText() = %v", err)
+	}
+	if got, want := resultIDs(results), []string{"wall-23", "wall-40"}; !equalIDs(got, want) {
+		t.Errorf("SearchText() on reader = %v, want %v", got, want)
+	}
+
+	results, err = reader.SearchRadius(ctx, &RadiusQuery{Center: &pb.Point{Latitude: 40.6812, Longitude: -73.9479}, RadiusMeters: 50, Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchRadius() = %v", err)
+	}
+	if got, want := resultIDs(results), []string{"macon-54"}; !equalIDs(got, want) {
+		t.Errorf("SearchRadius() on reader = %v, want %v", got, want)
+	}
+
+	boundaries, _ := reader.SearchBoundaries(ctx, &BoundaryQuery{Point: &pb.Point{Latitude: 0.5, Longitude: 0.5}})
+	if len(boundaries) != 1 {
+		t.Errorf("SearchBoundaries() on reader = %v, want 1 boundary", boundaries)
+	}
+	if job, err := reader.GetIngestJob(ctx, "job-1"); (err != nil) || (job.CommittedOffset != 4) {
+		t.Errorf("GetIngestJob() on reader = %v, %v", job, err)
+	}
+
+	// the reader picks up a replaced snapshot; the mod. time is bumped so the test doesn't depend on the
+	// resolution of the filesystem's timestamps
+	if _, err := writer.Delete(ctx, DefaultDataset, []string{"wall-40"}); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if err := writer.saveSnapshot(path); err != nil {
+		t.Fatalf("saveSnapshot() = %v", err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes() = %v", err)
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		results, _ = reader.SearchText(ctx, &TextQuery{Text: "WALL ST", Limit: 5})
+		if got := resultIDs(results); equalIDs(got, []string{"wall-23"}) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("reader never reloaded the snapshot, SearchText() = %v", resultIDs(results))
+		}
+	}
+}
+
+func TestMemorySnapshotMissingFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b, err := NewSnapshotMemorySearchBackend(ctx, &MemorySnapshotOptions{Path: filepath.Join(t.TempDir(), "missing")})
+	if err != nil {
+		t.Fatalf("NewSnapshotMemorySearchBackend() = %v", err)
+	}
+	if ok, _ := b.DatasetExists(ctx, DefaultDataset); !ok {
+		t.Errorf("DatasetExists(default) = false, want an empty default dataset")
+	}
+}
+
+func TestMemorySnapshotCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupt")
+	if err := os.WriteFile(path, []byte("not a snapshot"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSnapshotMemorySearchBackend(context.Background(), &MemorySnapshotOptions{Path: path}); err == nil {
+		t.Errorf("NewSnapshotMemorySearchBackend(corrupt) = nil, want an error")
+	}
+}
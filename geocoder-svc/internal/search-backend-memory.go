@@ -0,0 +1,864 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryCellSizeDegrees - size (in degrees) of each cell in the spatial grid, ~1km at NYC's latitude
+const memoryCellSizeDegrees = 0.01
+
+// geoCell - a cell in the spatial grid, identified by the floor of lat/lng over `memoryCellSizeDegrees`
+type geoCell struct {
+	lat, lng int32
+}
+
+// cellOf - returns the grid cell containing a point
+func cellOf(lat, lng float64) geoCell {
+	return geoCell{
+		lat: int32(math.Floor(lat / memoryCellSizeDegrees)),
+		lng: int32(math.Floor(lng / memoryCellSizeDegrees)),
+	}
+}
+
+// MemorySearchBackend - `SearchBackend` implemented in pure-Go; per dataset, an inverted index over the terms of
+// `composite_street_address` and a fixed-size grid over `location`. Data lives only as long as the process, unless
+// shared w. other processes through a snapshot file (see `MemorySnapshotOptions`).
+type MemorySearchBackend struct {
+	mu          sync.RWMutex
+	datasets    map[string]*memoryDataset           // dataset name -> version being served
+	versions    map[memoryVersionKey]*memoryDataset // (dataset name, version) -> unserved versions
+	lastVersion int64                               // last version created, versions are unique across datasets
+	bounds      map[string]memoryBoundary           // boundary key -> boundary
+	jobs        map[string]*pb.IngestJob            // job id -> ingest job
+	generation  int64                               // incremented on each write, see `changed`
+}
+
+// memoryVersionKey - identifies an unserved version of a dataset
+type memoryVersionKey struct {
+	dataset string
+	version int64
+}
+
+// memoryDataset - the addresses && street segments of a single dataset w. their indexes
+type memoryDataset struct {
+	addresses map[string]*pb.Address          // address key -> address
+	postings  map[string]map[string]int       // term -> address key -> term frequency
+	docTerms  map[string][]string             // address key -> all terms in the address (w. repeats)
+	cells     map[geoCell]map[string]struct{} // grid cell -> address keys
+	segments  map[string]*pb.StreetSegment    // segment key -> street segment
+	version   int64                           // version number, `LiveVersion` for the dataset as created
+	lastWrite time.Time                       // last upsert || delete of an address, zero if never written
+}
+
+// newMemoryDataset - creates an empty dataset
+func newMemoryDataset() *memoryDataset {
+	return &memoryDataset{
+		addresses: make(map[string]*pb.Address),
+		postings:  make(map[string]map[string]int),
+		docTerms:  make(map[string][]string),
+		cells:     make(map[geoCell]map[string]struct{}),
+		segments:  make(map[string]*pb.StreetSegment),
+	}
+}
+
+// memoryBoundary - a boundary w. its parsed geometry && bounding box
+type memoryBoundary struct {
+	boundary                       *pb.Boundary
+	geometry                       multiPolygon
+	minLat, minLng, maxLat, maxLng float64
+}
+
+// NewMemorySearchBackend - creates an in-memory backend w. an empty default dataset
+func NewMemorySearchBackend() *MemorySearchBackend {
+	return &MemorySearchBackend{
+		datasets: map[string]*memoryDataset{DefaultDataset: newMemoryDataset()},
+		versions: make(map[memoryVersionKey]*memoryDataset),
+		bounds:   make(map[string]memoryBoundary),
+		jobs:     make(map[string]*pb.IngestJob),
+	}
+}
+
+// IndexesReady - the in-memory indexes are maintained on write, nothing to create
+func (b *MemorySearchBackend) IndexesReady(ctx context.Context) error {
+	return nil
+}
+
+// CreateDataset - creates an empty dataset if it doesn't exist
+func (b *MemorySearchBackend) CreateDataset(ctx context.Context, dataset string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.dataset(dataset); !ok {
+		b.datasets[dataset] = newMemoryDataset()
+		b.changed()
+	}
+	return nil
+}
+
+// DatasetExists - checks a dataset has been created
+func (b *MemorySearchBackend) DatasetExists(ctx context.Context, dataset string) (bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	_, ok := b.dataset(dataset)
+	return ok, nil
+}
+
+// dataset - looks up the version of a dataset being served, an empty name is the default dataset; caller must
+// hold the lock
+func (b *MemorySearchBackend) dataset(name string) (*memoryDataset, bool) {
+	d, ok := b.datasets[memoryDatasetName(name)]
+	return d, ok
+}
+
+// version - looks up a version of a dataset, `LiveVersion` is the version being served; caller must hold the lock
+func (b *MemorySearchBackend) version(name string, version int64) (*memoryDataset, error) {
+	live, ok := b.dataset(name)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+	if version == LiveVersion {
+		return live, nil
+	}
+
+	d, ok := b.versions[memoryVersionKey{memoryDatasetName(name), version}]
+	if !ok {
+		return nil, ErrDatasetVersionNotFound
+	}
+	return d, nil
+}
+
+// memoryDatasetName - an empty name is the default dataset
+func memoryDatasetName(name string) string {
+	if name == "" {
+		return DefaultDataset
+	}
+	return name
+}
+
+// CreateVersion - creates an empty, unserved version of a dataset
+func (b *MemorySearchBackend) CreateVersion(ctx context.Context, dataset string) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.dataset(dataset); !ok {
+		return 0, ErrDatasetNotFound
+	}
+
+	b.lastVersion++
+	d := newMemoryDataset()
+	d.version = b.lastVersion
+	b.versions[memoryVersionKey{memoryDatasetName(dataset), b.lastVersion}] = d
+	b.changed()
+	return b.lastVersion, nil
+}
+
+// CountAddresses - the number of addresses in a version of a dataset
+func (b *MemorySearchBackend) CountAddresses(ctx context.Context, dataset string, version int64) (int, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, err := b.version(dataset, version)
+	if err != nil {
+		return 0, err
+	}
+	return len(d.addresses), nil
+}
+
+// PromoteVersion - serves a version of a dataset, street segments aren't versioned && carry over to the new version
+func (b *MemorySearchBackend) PromoteVersion(ctx context.Context, dataset string, version int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if version == LiveVersion {
+		return nil
+	}
+
+	d, err := b.version(dataset, version)
+	if err != nil {
+		return err
+	}
+
+	name := memoryDatasetName(dataset)
+	d.segments = b.datasets[name].segments
+	b.datasets[name] = d
+	delete(b.versions, memoryVersionKey{name, version})
+	b.changed()
+	return nil
+}
+
+// HasPendingVersion - checks for an unserved version of a dataset newer than the one being served
+func (b *MemorySearchBackend) HasPendingVersion(ctx context.Context, dataset string) (bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	live, ok := b.dataset(dataset)
+	if !ok {
+		return false, ErrDatasetNotFound
+	}
+	for k, d := range b.versions {
+		if (k.dataset == memoryDatasetName(dataset)) && (d.version > live.version) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DropStaleVersions - deletes all unserved versions of a dataset, the replaced version is released on promotion
+func (b *MemorySearchBackend) DropStaleVersions(ctx context.Context, dataset string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var n int
+	for k := range b.versions {
+		if k.dataset == memoryDatasetName(dataset) {
+			delete(b.versions, k)
+			n++
+		}
+	}
+	if n > 0 {
+		b.changed()
+	}
+	return n, nil
+}
+
+// DropVersion - deletes a single unserved version of a dataset
+func (b *MemorySearchBackend) DropVersion(ctx context.Context, dataset string, version int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	live, ok := b.dataset(dataset)
+	if !ok {
+		return ErrDatasetNotFound
+	}
+	if (version == LiveVersion) || (version == live.version) {
+		return ErrDatasetVersionLive
+	}
+
+	k := memoryVersionKey{memoryDatasetName(dataset), version}
+	if _, ok := b.versions[k]; !ok {
+		return ErrDatasetVersionNotFound
+	}
+	delete(b.versions, k)
+	b.changed()
+	return nil
+}
+
+// IndexableBounds - any valid location, distances are computed on each address
+func (b *MemorySearchBackend) IndexableBounds() *pb.BoundingBox {
+	return nil
+}
+
+// Upsert - inserts or replaces a batch of addresses
+func (b *MemorySearchBackend) Upsert(ctx context.Context, dataset string, version int64, addresses []*pb.Address) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, err := b.version(dataset, version)
+	if err != nil {
+		return err
+	}
+
+	d.upsert(dataset, addresses)
+	d.lastWrite = time.Now()
+	b.changed()
+	return nil
+}
+
+// upsert - adds a batch of addresses to all indexes; caller must hold the write lock
+func (d *memoryDataset) upsert(dataset string, addresses []*pb.Address) {
+	for _, address := range addresses {
+		key := addressKey(dataset, address.Id)
+		d.remove(key)
+
+		terms := tokenize(address.CompositeStreetAddress)
+		for _, t := range terms {
+			if _, ok := d.postings[t]; !ok {
+				d.postings[t] = make(map[string]int)
+			}
+			d.postings[t][key]++
+		}
+
+		cell := cellOf(float64(address.Location.Latitude), float64(address.Location.Longitude))
+		if _, ok := d.cells[cell]; !ok {
+			d.cells[cell] = make(map[string]struct{})
+		}
+		d.cells[cell][key] = struct{}{}
+
+		d.docTerms[key] = terms
+		d.addresses[key] = address
+	}
+}
+
+// UpsertSegments - inserts or replaces a batch of street segments
+func (b *MemorySearchBackend) UpsertSegments(ctx context.Context, dataset string, segments []*pb.StreetSegment) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, ok := b.dataset(dataset)
+	if !ok {
+		return ErrDatasetNotFound
+	}
+
+	for _, seg := range segments {
+		d.segments[segmentKey(dataset, seg.Id)] = seg
+	}
+	b.changed()
+	return nil
+}
+
+// UpsertBoundaries - inserts or replaces a batch of boundaries
+func (b *MemorySearchBackend) UpsertBoundaries(ctx context.Context, boundaries []*pb.Boundary) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, bnd := range boundaries {
+		mp, err := parseBoundaryGeometry(bnd.Geojson)
+		if err != nil {
+			return err
+		}
+
+		mb := memoryBoundary{boundary: bnd, geometry: mp}
+		mb.minLat, mb.minLng, mb.maxLat, mb.maxLng = mp.bounds()
+		b.bounds[boundaryKey(bnd.Layer, bnd.Id)] = mb
+	}
+	b.changed()
+	return nil
+}
+
+// Delete - removes a batch of addresses by `Address.Id`
+func (b *MemorySearchBackend) Delete(ctx context.Context, dataset string, ids []string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, ok := b.dataset(dataset)
+	if !ok {
+		return 0, ErrDatasetNotFound
+	}
+
+	var n int
+	for _, id := range ids {
+		if d.remove(addressKey(dataset, id)) {
+			n++
+		}
+	}
+	d.lastWrite = time.Now()
+	b.changed()
+	return n, nil
+}
+
+// Stats - the size && extent of the version of a dataset being served, the in-memory indexes aren't measured
+func (b *MemorySearchBackend) Stats(ctx context.Context, dataset string) (*pb.DatasetStats, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, ok := b.dataset(dataset)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+
+	stats := &pb.DatasetStats{
+		Dataset:                  memoryDatasetName(dataset),
+		Version:                  d.version,
+		NumAddresses:             int32(len(d.addresses)),
+		NumAddressesByLocality:   make(map[string]int32),
+		NumAddressesByPostalCode: make(map[string]int32),
+	}
+
+	if !d.lastWrite.IsZero() {
+		stats.LastIngestTime = timestamppb.New(d.lastWrite)
+	}
+
+	for _, address := range d.addresses {
+		stats.NumAddressesByLocality[address.Locality]++
+		stats.NumAddressesByPostalCode[address.PostalCode]++
+
+		pt := address.Location
+		if stats.BoundingBox == nil {
+			stats.BoundingBox = &pb.BoundingBox{
+				Min: &pb.Point{Latitude: pt.Latitude, Longitude: pt.Longitude},
+				Max: &pb.Point{Latitude: pt.Latitude, Longitude: pt.Longitude},
+			}
+			continue
+		}
+
+		bbox := stats.BoundingBox
+		bbox.Min.Latitude = float32(math.Min(float64(bbox.Min.Latitude), float64(pt.Latitude)))
+		bbox.Min.Longitude = float32(math.Min(float64(bbox.Min.Longitude), float64(pt.Longitude)))
+		bbox.Max.Latitude = float32(math.Max(float64(bbox.Max.Latitude), float64(pt.Latitude)))
+		bbox.Max.Longitude = float32(math.Max(float64(bbox.Max.Longitude), float64(pt.Longitude)))
+	}
+	return stats, nil
+}
+
+// GetAddress - a single address by `Address.Id` from the version of a dataset being served
+func (b *MemorySearchBackend) GetAddress(ctx context.Context, dataset, id string) (*pb.Address, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, ok := b.dataset(dataset)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+
+	key := addressKey(dataset, id)
+	if _, ok := d.addresses[key]; !ok {
+		return nil, ErrAddressNotFound
+	}
+
+	address := proto.Clone(d.addresses[key]).(*pb.Address)
+	address.Id = key
+	return address, nil
+}
+
+// Export - copies the matching addresses (sorted by key) under the read lock, `fn` is called after it's released
+func (b *MemorySearchBackend) Export(ctx context.Context, q *ExportQuery, fn func(*pb.Address) error) error {
+	b.mu.RLock()
+
+	d, ok := b.dataset(q.Dataset)
+	if !ok {
+		b.mu.RUnlock()
+		return ErrDatasetNotFound
+	}
+
+	prefix := addressKey(q.Dataset, "")
+	keys := make([]string, 0, len(d.addresses))
+	for key, address := range d.addresses {
+		if strings.HasPrefix(key, prefix+q.IDPrefix) && q.Filter.Matches(address) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	addresses := make([]*pb.Address, len(keys))
+	for i, key := range keys {
+		addresses[i] = proto.Clone(d.addresses[key]).(*pb.Address)
+		addresses[i].Dataset, addresses[i].Version = q.Dataset, LiveVersion
+	}
+	b.mu.RUnlock()
+
+	for _, address := range addresses {
+		if err := fn(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveIngestJob - creates || replaces the progress of an ingest job
+func (b *MemorySearchBackend) SaveIngestJob(ctx context.Context, job *pb.IngestJob) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.jobs[job.JobId] = proto.Clone(job).(*pb.IngestJob)
+	b.changed()
+	return nil
+}
+
+// GetIngestJob - the progress of an ingest job by id
+func (b *MemorySearchBackend) GetIngestJob(ctx context.Context, id string) (*pb.IngestJob, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	job, ok := b.jobs[id]
+	if !ok {
+		return nil, ErrIngestJobNotFound
+	}
+	return proto.Clone(job).(*pb.IngestJob), nil
+}
+
+// remove - drops an address from all indexes; caller must hold the write lock
+func (d *memoryDataset) remove(key string) bool {
+	address, ok := d.addresses[key]
+	if !ok {
+		return false
+	}
+
+	for _, t := range d.docTerms[key] {
+		delete(d.postings[t], key)
+		if len(d.postings[t]) == 0 {
+			delete(d.postings, t)
+		}
+	}
+
+	cell := cellOf(float64(address.Location.Latitude), float64(address.Location.Longitude))
+	delete(d.cells[cell], key)
+	if len(d.cells[cell]) == 0 {
+		delete(d.cells, cell)
+	}
+
+	delete(d.docTerms, key)
+	delete(d.addresses, key)
+	return true
+}
+
+// SearchText - all query terms must match (intersection); scored w. TFIDF normalized by document length
+// to mirror RediSearch's `TFIDF.DOCNORM`. Structured queries score on the street && filter on all other
+// components.
+func (b *MemorySearchBackend) SearchText(ctx context.Context, q *TextQuery) ([]*pb.ScoredAddress, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, ok := b.dataset(q.Dataset)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+
+	if q.Address == nil {
+		scores := d.scoreTerms(parseTextQuery(q.Text))
+		for key := range scores {
+			if !q.Filter.Matches(d.addresses[key]) {
+				delete(scores, key)
+			}
+		}
+		return d.rank(scores, q.Limit, func(i, j float64) bool { return i > j }), nil
+	}
+
+	// structured queries w. no street -> every address is a candidate w. an equal score
+	var scores map[string]float64
+	if street := q.Address.FullStreet(); street != "" {
+		scores = d.scoreTerms(parseTextQuery(street))
+	} else {
+		scores = make(map[string]float64, len(d.addresses))
+		for key := range d.addresses {
+			scores[key] = 1
+		}
+	}
+
+	for key := range scores {
+		if !matchesComponents(d.addresses[key], q.Address) || !q.Filter.Matches(d.addresses[key]) {
+			delete(scores, key)
+		}
+	}
+	return d.rank(scores, q.Limit, func(i, j float64) bool { return i > j }), nil
+}
+
+// scoreTerms - scores all addresses matching every term; caller must hold the read lock
+func (d *memoryDataset) scoreTerms(terms []queryTerm) map[string]float64 {
+
+	var scores map[string]float64
+	var nDocs = float64(len(d.addresses))
+
+	for _, qt := range terms {
+
+		// expand the query term to all indexed terms within its edit distance, each address keeps the
+		// best score of any expansion
+		termScores := make(map[string]float64)
+		for _, t := range d.expandTerm(qt) {
+			idf := math.Log(1 + nDocs/float64(len(d.postings[t])))
+			for key, tf := range d.postings[t] {
+				s := float64(tf) * idf / float64(len(d.docTerms[key]))
+				if s > termScores[key] {
+					termScores[key] = s
+				}
+			}
+		}
+
+		// intersect w. the results of all previous terms
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for key := range scores {
+			if s, ok := termScores[key]; ok {
+				scores[key] += s
+			} else {
+				delete(scores, key)
+			}
+		}
+	}
+	return scores
+}
+
+// matchesComponents - checks an address against the non-street components of a structured query, same
+// semantics as the NUMERIC && TAG clauses used by RediSearch
+func matchesComponents(address *pb.Address, p *ParsedAddress) bool {
+	if v, ok := HouseNumberValue(p.HouseNumber); ok {
+		if av, aok := HouseNumberValue(address.HouseNumber); !aok || av != v {
+			return false
+		}
+	}
+	for _, c := range [][2]string{
+		{p.Locality, address.Locality},
+		{p.PostalCode, address.PostalCode},
+		{p.Region, address.Region},
+	} {
+		if (c[0] != "") && !strings.EqualFold(c[0], c[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchSegments - scans all segments for an exact match on the street (&& locality, postal code) w. an address
+// range containing the house number; all matches get an equal score
+func (b *MemorySearchBackend) SearchSegments(ctx context.Context, q *SegmentQuery) ([]*ScoredSegment, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, ok := b.dataset(q.Dataset)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+
+	if _, ok := HouseNumberValue(q.Address.HouseNumber); !ok || (q.Address.FullStreet() == "") {
+		return nil, ErrMalformedRedisQuery
+	}
+
+	var keys []string
+	for key, seg := range d.segments {
+		if (strings.Join(tokenize(seg.Street), " ") != strings.Join(tokenize(q.Address.FullStreet()), " ")) ||
+			((q.Address.Locality != "") && !strings.EqualFold(q.Address.Locality, seg.Locality)) ||
+			((q.Address.PostalCode != "") && (q.Address.PostalCode != seg.PostalCode)) ||
+			!SegmentContains(seg, q.Address.HouseNumber) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	if len(keys) > q.Limit {
+		keys = keys[:q.Limit]
+	}
+
+	results := make([]*ScoredSegment, len(keys))
+	for i, key := range keys {
+		results[i] = &ScoredSegment{
+			Segment:          proto.Clone(d.segments[key]).(*pb.StreetSegment),
+			NormedConfidence: 1,
+		}
+	}
+	return results, nil
+}
+
+// SearchBoundaries - scans all boundaries, testing the bounding box before the geometry
+func (b *MemorySearchBackend) SearchBoundaries(ctx context.Context, q *BoundaryQuery) ([]*pb.Boundary, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	lat, lng := float64(q.Point.Latitude), float64(q.Point.Longitude)
+
+	var boundaries []*pb.Boundary
+	for _, mb := range b.bounds {
+		if (lat < mb.minLat) || (lat > mb.maxLat) || (lng < mb.minLng) || (lng > mb.maxLng) ||
+			!matchesAnyTag(q.Layers, mb.boundary.Layer) || !mb.geometry.contains(q.Point) {
+			continue
+		}
+		boundaries = append(boundaries, &pb.Boundary{
+			Id:    mb.boundary.Id,
+			Layer: mb.boundary.Layer,
+			Name:  mb.boundary.Name,
+		})
+	}
+
+	sortBoundaries(boundaries)
+	return boundaries, nil
+}
+
+// Suggest - scans all addresses for a `composite_street_address` starting w. the prefix; shorter completions
+// score higher, mirroring FT.SUGGET's preference for closer matches
+func (b *MemorySearchBackend) Suggest(ctx context.Context, q *SuggestQuery) ([]*pb.Suggestion, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, ok := b.dataset(q.Dataset)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+
+	prefix := strings.ToUpper(q.Prefix)
+	if prefix == "" {
+		return []*pb.Suggestion{}, nil
+	}
+
+	scores := make(map[string]float64)
+	for key, address := range d.addresses {
+		text := strings.ToUpper(address.CompositeStreetAddress)
+		if len(text) < len(prefix) {
+			continue
+		}
+		if strings.HasPrefix(text, prefix) || (q.Fuzzy && (levenshtein(prefix, text[:len(prefix)], 1) <= 1)) {
+			scores[key] = float64(len(prefix)) / float64(len(text))
+		}
+	}
+
+	results := d.rank(scores, q.Limit, func(i, j float64) bool { return i > j })
+	suggestions := make([]*pb.Suggestion, len(results))
+	for i, r := range results {
+		suggestions[i] = &pb.Suggestion{
+			Text:  r.Address.CompositeStreetAddress,
+			Score: float32(scores[r.Address.Id]),
+			Id:    r.Address.Id,
+		}
+	}
+	return suggestions, nil
+}
+
+// SearchRadius - scans all grid cells overlapping the query radius && filters on true distance
+func (b *MemorySearchBackend) SearchRadius(ctx context.Context, q *RadiusQuery) ([]*pb.ScoredAddress, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, ok := b.dataset(q.Dataset)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+
+	lat, lng := float64(q.Center.Latitude), float64(q.Center.Longitude)
+	dLat := q.RadiusMeters / metersPerDegreeLatitude
+	dLng := q.RadiusMeters / (metersPerDegreeLatitude * math.Max(math.Cos(lat*math.Pi/180), 1e-6))
+
+	minCell, maxCell := cellOf(lat-dLat, lng-dLng), cellOf(lat+dLat, lng+dLng)
+
+	distances := make(map[string]float64)
+	for i := minCell.lat; i <= maxCell.lat; i++ {
+		for j := minCell.lng; j <= maxCell.lng; j++ {
+			for key := range d.cells[geoCell{i, j}] {
+				address := d.addresses[key]
+				if d := HaversineDistance(q.Center, address.Location); (d <= q.RadiusMeters) && q.Filter.Matches(address) {
+					distances[key] = d
+				}
+			}
+		}
+	}
+
+	// RediSearch gives all results of a pure geo filter an equal score, order by distance here instead
+	results := d.rank(distances, q.Limit, func(i, j float64) bool { return i < j })
+	for _, r := range results {
+		r.NormedConfidence = 1
+		r.DistanceMeters = float32(distances[r.Address.Id])
+	}
+	return results, nil
+}
+
+// rank - sorts scored address keys w. `better`, truncates to `limit` and normalizes each score by the best score
+func (d *memoryDataset) rank(scores map[string]float64, limit int, better func(i, j float64) bool) []*pb.ScoredAddress {
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] == scores[keys[j]] {
+			return keys[i] < keys[j]
+		}
+		return better(scores[keys[i]], scores[keys[j]])
+	})
+
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	results := make([]*pb.ScoredAddress, len(keys))
+	for i, key := range keys {
+		address := proto.Clone(d.addresses[key]).(*pb.Address)
+		address.Id = key
+		results[i] = &pb.ScoredAddress{
+			Address:          address,
+			NormedConfidence: float32(scores[key] / scores[keys[0]]),
+		}
+	}
+	return results
+}
+
+// queryTerm - a single term from a text query w. the max edit distance it may match at
+type queryTerm struct {
+	term     string
+	distance int
+}
+
+// expandTerm - returns all indexed terms within the query term's edit distance; caller must hold the read lock
+func (d *memoryDataset) expandTerm(qt queryTerm) []string {
+	if qt.distance == 0 {
+		if _, ok := d.postings[qt.term]; ok {
+			return []string{qt.term}
+		}
+		return nil
+	}
+
+	var terms []string
+	for t := range d.postings {
+		if levenshtein(qt.term, t, qt.distance) <= qt.distance {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// parseTextQuery - splits a query into terms, words wrapped in n `%` (e.g. `%%ATLANTIC%%`) get
+// an edit distance of n, same as RediSearch's fuzzy matching
+func parseTextQuery(s string) []queryTerm {
+	var terms []queryTerm
+	for _, word := range strings.Fields(s) {
+		distance := len(word) - len(strings.TrimLeft(word, "%"))
+		if distance > 3 {
+			distance = 3
+		}
+		for _, t := range tokenize(word) {
+			terms = append(terms, queryTerm{term: t, distance: distance})
+		}
+	}
+	return terms
+}
+
+// tokenize - upper-cases and splits a string on anything that isn't a letter or digit
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// levenshtein - edit distance between two strings, returns early (w. a value > maxDistance) once the distance
+// is known to exceed maxDistance
+func levenshtein(a, b string, maxDistance int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > maxDistance || -d > maxDistance {
+		return maxDistance + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > maxDistance {
+			return maxDistance + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
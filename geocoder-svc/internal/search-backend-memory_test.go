@@ -0,0 +1,437 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// testAddresses - a few addresses around lower Manhattan && Brooklyn, normalized as at ingest
+func testAddresses() []*pb.Address {
+	addresses := []*pb.Address{
+		{Id: "wall-23", CompositeStreetAddress: "23 WALL ST NEW YORK NEW YORK 10005", Location: &pb.Point{Latitude: 40.706005, Longitude: -74.008827}},
+		{Id: "wall-40", CompositeStreetAddress: "40 WALL ST NEW YORK NEW YORK 10005", Location: &pb.Point{Latitude: 40.706911, Longitude: -74.009370}},
+		{Id: "broad-25", CompositeStreetAddress: "25 BROAD ST NEW YORK NEW YORK 10004", Location: &pb.Point{Latitude: 40.705425, Longitude: -74.011396}},
+		{Id: "macon-54", CompositeStreetAddress: "54 MACON ST BROOKLYN NEW YORK 11216", Location: &pb.Point{Latitude: 40.681200, Longitude: -73.947900}},
+	}
+	for _, a := range addresses {
+		NormalizeAddressComponents(a)
+	}
+	return addresses
+}
+
+// newTestMemorySearchBackend - a memory backend w. `testAddresses` in the default dataset
+func newTestMemorySearchBackend(t *testing.T) *MemorySearchBackend {
+	t.Helper()
+	b := NewMemorySearchBackend()
+	if err := b.Upsert(context.Background(), DefaultDataset, LiveVersion, testAddresses()); err != nil {
+		t.Fatalf("Upsert() = %v", err)
+	}
+	return b
+}
+
+// resultIDs - the `Address.Id` sent at ingest of each result, in order
+func resultIDs(results []*pb.ScoredAddress) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = ParseAddressID(DefaultDataset, r.Address.Id)
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMemorySearchTextForward(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	var tests = []struct {
+		q    *TextQuery
+		want []string
+	}{
+		{q: &TextQuery{Text: "23 WALL ST", Limit: 5}, want: []string{"wall-23"}},
+		{q: &TextQuery{Text: "WALL ST", Limit: 5}, want: []string{"wall-23", "wall-40"}},
+		{q: &TextQuery{Text: "WALL ST", Limit: 1}, want: []string{"wall-23"}},
+		{q: &TextQuery{Text: "54 %MACN% ST", Limit: 5}, want: []string{"macon-54"}},
+		{q: &TextQuery{Text: "54 MACN ST", Limit: 5}, want: []string{}},
+		{q: &TextQuery{Address: ParseAddress("40 Wall Street, New York"), Limit: 5}, want: []string{"wall-40"}},
+		{q: &TextQuery{Address: ParseAddress("40 Wall Street, Brooklyn"), Limit: 5}, want: []string{}},
+		{q: &TextQuery{Address: &ParsedAddress{PostalCode: "11216"}, Limit: 5}, want: []string{"macon-54"}},
+		{
+			q:    &TextQuery{Text: "ST", Filter: &SearchFilter{PostalCodes: []string{"10004", "11216"}}, Limit: 5},
+			want: []string{"macon-54", "broad-25"}, // shorter documents score higher
+		},
+	}
+
+	for _, tt := range tests {
+		results, err := b.SearchText(ctx, tt.q)
+		if err != nil {
+			t.Fatalf("SearchText(%+v) = %v", tt.q, err)
+		}
+		if got := resultIDs(results); !equalIDs(got, tt.want) {
+			t.Errorf("SearchText(%+v) = %v, want %v", tt.q, got, tt.want)
+		}
+		if (len(results) > 0) && (results[0].NormedConfidence != 1) {
+			t.Errorf("SearchText(%+v) best NormedConfidence = %v, want 1", tt.q, results[0].NormedConfidence)
+		}
+	}
+
+	if _, err := b.SearchText(ctx, &TextQuery{Dataset: "missing", Text: "WALL", Limit: 5}); err != ErrDatasetNotFound {
+		t.Errorf("SearchText(missing dataset) = %v, want %v", err, ErrDatasetNotFound)
+	}
+}
+
+func TestMemorySearchTextReturnsComponents(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+
+	results, err := b.SearchText(context.Background(), &TextQuery{Text: "54 MACON ST", Limit: 1})
+	if (err != nil) || (len(results) != 1) {
+		t.Fatalf("SearchText() = %v, %v, want 1 result", resultIDs(results), err)
+	}
+	a := results[0].Address
+	if (a.HouseNumber != "54") || (a.Street != "MACON ST") || (a.Locality != "BROOKLYN") || (a.PostalCode != "11216") {
+		t.Errorf("SearchText() address components = %+v", a)
+	}
+}
+
+func TestMemorySearchRadiusReverse(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	center := &pb.Point{Latitude: 40.706005, Longitude: -74.008827}
+	results, err := b.SearchRadius(ctx, &RadiusQuery{Center: center, RadiusMeters: 500, Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchRadius() = %v", err)
+	}
+	if got, want := resultIDs(results), []string{"wall-23", "wall-40", "broad-25"}; !equalIDs(got, want) {
+		t.Fatalf("SearchRadius() = %v, want %v", got, want)
+	}
+	for i, r := range results {
+		if (i > 0) && (r.DistanceMeters < results[i-1].DistanceMeters) {
+			t.Errorf("SearchRadius() results not sorted nearest -> farthest: %v", results)
+		}
+		if r.DistanceMeters > 500 {
+			t.Errorf("SearchRadius() result %s at %vm, outside the radius", r.Address.Id, r.DistanceMeters)
+		}
+	}
+
+	// a filter constrains results in addition to the radius
+	results, err = b.SearchRadius(ctx, &RadiusQuery{
+		Center: center, RadiusMeters: 500, Filter: &SearchFilter{PostalCodes: []string{"10004"}}, Limit: 5,
+	})
+	if err != nil {
+		t.Fatalf("SearchRadius(filter) = %v", err)
+	}
+	if got, want := resultIDs(results), []string{"broad-25"}; !equalIDs(got, want) {
+		t.Errorf("SearchRadius(filter) = %v, want %v", got, want)
+	}
+
+	results, err = b.SearchRadius(ctx, &RadiusQuery{Center: center, RadiusMeters: 10, Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchRadius(10m) = %v", err)
+	}
+	if got, want := resultIDs(results), []string{"wall-23"}; !equalIDs(got, want) {
+		t.Errorf("SearchRadius(10m) = %v, want %v", got, want)
+	}
+}
+
+func TestMemorySuggest(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	var tests = []struct {
+		q    *SuggestQuery
+		want []string
+	}{
+		{q: &SuggestQuery{Prefix: "23 WALL", Limit: 5}, want: []string{"23 WALL ST NEW YORK NEW YORK 10005"}},
+		{q: &SuggestQuery{Prefix: "54 mac", Limit: 5}, want: []string{"54 MACON ST BROOKLYN NEW YORK 11216"}},
+		{q: &SuggestQuery{Prefix: "24 WALL", Limit: 5}, want: []string{}},
+		{q: &SuggestQuery{Prefix: "24 WALL", Fuzzy: true, Limit: 5}, want: []string{"23 WALL ST NEW YORK NEW YORK 10005"}},
+		{q: &SuggestQuery{Prefix: "", Limit: 5}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		suggestions, err := b.Suggest(ctx, tt.q)
+		if err != nil {
+			t.Fatalf("Suggest(%+v) = %v", tt.q, err)
+		}
+		got := make([]string, len(suggestions))
+		for i, s := range suggestions {
+			got[i] = s.Text
+		}
+		if !equalIDs(got, tt.want) {
+			t.Errorf("Suggest(%+v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryUpsertReplacesAndDeletes(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	// replacing an address drops it from the postings of its old text
+	moved := &pb.Address{Id: "wall-23", CompositeStreetAddress: "23 PINE ST NEW YORK NEW YORK 10005", Location: &pb.Point{Latitude: 40.7068, Longitude: -74.0090}}
+	if err := b.Upsert(ctx, DefaultDataset, LiveVersion, []*pb.Address{moved}); err != nil {
+		t.Fatalf("Upsert() = %v", err)
+	}
+	results, _ := b.SearchText(ctx, &TextQuery{Text: "WALL", Limit: 5})
+	if got, want := resultIDs(results), []string{"wall-40"}; !equalIDs(got, want) {
+		t.Errorf("SearchText(WALL) after replace = %v, want %v", got, want)
+	}
+
+	n, err := b.Delete(ctx, DefaultDataset, []string{"wall-40", "unknown"})
+	if (err != nil) || (n != 1) {
+		t.Fatalf("Delete() = %d, %v, want 1, nil", n, err)
+	}
+	results, _ = b.SearchText(ctx, &TextQuery{Text: "ST", Limit: 5})
+	if len(results) != 3 {
+		t.Errorf("SearchText(ST) after delete = %v, want 3 results", resultIDs(results))
+	}
+	if n, _ := b.CountAddresses(ctx, DefaultDataset, LiveVersion); n != 3 {
+		t.Errorf("CountAddresses() = %d, want 3", n)
+	}
+}
+
+func TestMemoryPromoteVersion(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	version, err := b.CreateVersion(ctx, DefaultDataset)
+	if err != nil {
+		t.Fatalf("CreateVersion() = %v", err)
+	}
+	if err := b.Upsert(ctx, DefaultDataset, version, testAddresses()[3:]); err != nil {
+		t.Fatalf("Upsert(version) = %v", err)
+	}
+
+	// unserved until promoted
+	results, _ := b.SearchText(ctx, &TextQuery{Text: "WALL", Limit: 5})
+	if len(results) != 2 {
+		t.Errorf("SearchText(WALL) before promote = %v, want 2 results", resultIDs(results))
+	}
+
+	if err := b.PromoteVersion(ctx, DefaultDataset, version); err != nil {
+		t.Fatalf("PromoteVersion() = %v", err)
+	}
+	results, _ = b.SearchText(ctx, &TextQuery{Text: "WALL", Limit: 5})
+	if len(results) != 0 {
+		t.Errorf("SearchText(WALL) after promote = %v, want none", resultIDs(results))
+	}
+	if n, _ := b.CountAddresses(ctx, DefaultDataset, LiveVersion); n != 1 {
+		t.Errorf("CountAddresses() after promote = %d, want 1", n)
+	}
+}
+
+func TestMemoryDropStaleVersions(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	version, err := b.CreateVersion(ctx, DefaultDataset)
+	if err != nil {
+		t.Fatalf("CreateVersion() = %v", err)
+	}
+
+	// an abandoned reload is dropped, the served version is untouched
+	if n, err := b.DropStaleVersions(ctx, DefaultDataset); (err != nil) || (n != 1) {
+		t.Fatalf("DropStaleVersions() = %d, %v, want 1, nil", n, err)
+	}
+	if err := b.PromoteVersion(ctx, DefaultDataset, version); err != ErrDatasetVersionNotFound {
+		t.Errorf("PromoteVersion(dropped version) = %v, want %v", err, ErrDatasetVersionNotFound)
+	}
+	if n, _ := b.CountAddresses(ctx, DefaultDataset, LiveVersion); n != 4 {
+		t.Errorf("CountAddresses() = %d, want 4", n)
+	}
+}
+
+func TestMemoryHasPendingVersion(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	var tests = []struct {
+		name string
+		step func() error
+		want bool
+	}{
+		{"none", func() error { return nil }, false},
+		{"created", func() error { _, err := b.CreateVersion(ctx, DefaultDataset); return err }, true},
+		{"dropped", func() error { _, err := b.DropStaleVersions(ctx, DefaultDataset); return err }, false},
+		{"promoted", func() error {
+			version, err := b.CreateVersion(ctx, DefaultDataset)
+			if err != nil {
+				return err
+			}
+			return b.PromoteVersion(ctx, DefaultDataset, version)
+		}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.step(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got, err := b.HasPendingVersion(ctx, DefaultDataset); (err != nil) || (got != tt.want) {
+			t.Errorf("HasPendingVersion(%s) = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestMemoryDropVersion(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	version, err := b.CreateVersion(ctx, DefaultDataset)
+	if err != nil {
+		t.Fatalf("CreateVersion() = %v", err)
+	}
+
+	// an abandoned version is dropped w.o. touching the version being served
+	if err := b.DropVersion(ctx, DefaultDataset, version); err != nil {
+		t.Fatalf("DropVersion(%d) = %v", version, err)
+	}
+	if pending, _ := b.HasPendingVersion(ctx, DefaultDataset); pending {
+		t.Errorf("HasPendingVersion() after DropVersion = true, want false")
+	}
+	if n, _ := b.CountAddresses(ctx, DefaultDataset, LiveVersion); n != 4 {
+		t.Errorf("CountAddresses() after DropVersion = %d, want 4", n)
+	}
+
+	promoted, _ := b.CreateVersion(ctx, DefaultDataset)
+	if err := b.PromoteVersion(ctx, DefaultDataset, promoted); err != nil {
+		t.Fatalf("PromoteVersion(%d) = %v", promoted, err)
+	}
+
+	var tests = []struct {
+		version int64
+		want    error
+	}{
+		{version, ErrDatasetVersionNotFound},
+		{LiveVersion, ErrDatasetVersionLive},
+		{promoted, ErrDatasetVersionLive},
+	}
+	for _, tt := range tests {
+		if err := b.DropVersion(ctx, DefaultDataset, tt.version); err != tt.want {
+			t.Errorf("DropVersion(%d) = %v, want %v", tt.version, err, tt.want)
+		}
+	}
+}
+
+func TestMemoryStatsAndGetAddress(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	stats, err := b.Stats(ctx, DefaultDataset)
+	if err != nil {
+		t.Fatalf("Stats() = %v", err)
+	}
+	if (stats.NumAddresses != 4) || (stats.NumAddressesByLocality["NEW YORK"] != 3) || (stats.NumAddressesByPostalCode["11216"] != 1) {
+		t.Errorf("Stats() = %v, want 4 addresses, 3 in NEW YORK && 1 in 11216", stats)
+	}
+	if bb := stats.BoundingBox; (bb.Min.Latitude != 40.6812) || (bb.Max.Latitude != 40.706911) ||
+		(bb.Min.Longitude != -74.011396) || (bb.Max.Longitude != -73.9479) {
+		t.Errorf("Stats() bounding box = %v", bb)
+	}
+	if stats.LastIngestTime == nil {
+		t.Errorf("Stats() last ingest time unset after an upsert")
+	}
+
+	address, err := b.GetAddress(ctx, DefaultDataset, "macon-54")
+	if err != nil {
+		t.Fatalf("GetAddress() = %v", err)
+	}
+	if (address.Id != addressKey(DefaultDataset, "macon-54")) || (address.Street != "MACON ST") {
+		t.Errorf("GetAddress() = %v", address)
+	}
+
+	if _, err := b.GetAddress(ctx, DefaultDataset, "missing"); err != ErrAddressNotFound {
+		t.Errorf("GetAddress(missing) = %v, want %v", err, ErrAddressNotFound)
+	}
+	if _, err := b.Stats(ctx, "missing"); err != ErrDatasetNotFound {
+		t.Errorf("Stats(missing dataset) = %v, want %v", err, ErrDatasetNotFound)
+	}
+}
+
+func TestMemoryExport(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	var tests = []struct {
+		q    *ExportQuery
+		want []string
+	}{
+		{q: &ExportQuery{}, want: []string{"broad-25", "macon-54", "wall-23", "wall-40"}},
+		{q: &ExportQuery{IDPrefix: "wall-"}, want: []string{"wall-23", "wall-40"}},
+		{
+			q: &ExportQuery{Filter: &SearchFilter{BoundingBox: &pb.BoundingBox{
+				Min: &pb.Point{Latitude: 40.67, Longitude: -73.96}, Max: &pb.Point{Latitude: 40.69, Longitude: -73.94},
+			}}},
+			want: []string{"macon-54"},
+		},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		err := b.Export(ctx, tt.q, func(a *pb.Address) error {
+			got = append(got, a.Id)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Export(%+v) = %v", tt.q, err)
+		}
+		if !equalIDs(got, tt.want) {
+			t.Errorf("Export(%+v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+
+	// an error from the callback stops the export
+	var n int
+	err := b.Export(ctx, &ExportQuery{}, func(a *pb.Address) error {
+		n++
+		return ErrAddressNotFound
+	})
+	if (err != ErrAddressNotFound) || (n != 1) {
+		t.Errorf("Export(failing callback) = %v after %d addresses, want %v after 1", err, n, ErrAddressNotFound)
+	}
+}
+
+func TestMemoryDatasetsAreIsolated(t *testing.T) {
+	b := newTestMemorySearchBackend(t)
+	ctx := context.Background()
+
+	if err := b.Upsert(ctx, "nj", LiveVersion, testAddresses()[:1]); err != ErrDatasetNotFound {
+		t.Fatalf("Upsert(uncreated dataset) = %v, want %v", err, ErrDatasetNotFound)
+	}
+	if err := b.CreateDataset(ctx, "nj"); err != nil {
+		t.Fatalf("CreateDataset() = %v", err)
+	}
+	if ok, _ := b.DatasetExists(ctx, "nj"); !ok {
+		t.Fatalf("DatasetExists(nj) = false, want true")
+	}
+
+	jersey := &pb.Address{Id: "wall-23", CompositeStreetAddress: "23 WALL ST JERSEY CITY NEW JERSEY 07302", Location: &pb.Point{Latitude: 40.7178, Longitude: -74.0431}}
+	NormalizeAddressComponents(jersey)
+	if err := b.Upsert(ctx, "nj", LiveVersion, []*pb.Address{jersey}); err != nil {
+		t.Fatalf("Upsert(nj) = %v", err)
+	}
+
+	// the same `Address.Id` in two datasets are two addresses
+	results, _ := b.SearchText(ctx, &TextQuery{Dataset: "nj", Text: "WALL ST", Limit: 5})
+	if (len(results) != 1) || (results[0].Address.Id != addressKey("nj", "wall-23")) {
+		t.Errorf("SearchText(nj, WALL ST) = %v, want [%s]", results, addressKey("nj", "wall-23"))
+	}
+	results, _ = b.SearchText(ctx, &TextQuery{Text: "WALL ST", Limit: 5})
+	if got, want := resultIDs(results), []string{"wall-23", "wall-40"}; !equalIDs(got, want) {
+		t.Errorf("SearchText(default, WALL ST) = %v, want %v", got, want)
+	}
+}
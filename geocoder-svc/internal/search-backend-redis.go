@@ -0,0 +1,1286 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	redis "github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// redisSearchAddressIndex - name of the FT index over all `address:*` hashes, prefixed w. the dataset &&
+	// suffixed w. the version
+	redisSearchAddressIndex = "addr-idx"
+
+	// redisSearchAddressAlias - name of the FT alias of the address index being served, prefixed w. the dataset;
+	// all address searches go through the alias so that a reload is swapped in atomically
+	redisSearchAddressAlias = "addr-live"
+
+	// redisSearchSegmentIndex - name of the FT index over all `segment:*` hashes, prefixed w. the dataset
+	redisSearchSegmentIndex = "seg-idx"
+
+	// redisSearchBoundaryIndex - name of the FT index over all `boundary:*` hashes
+	redisSearchBoundaryIndex = "bnd-idx"
+
+	// redisSearchSuggestionDict - name of the FT.SUGADD dictionary of all `composite_street_address`, prefixed w.
+	// the dataset && suffixed w. the version
+	redisSearchSuggestionDict = "addr-sug"
+
+	// redisLiveVersionKey - version of a dataset's addresses being served, prefixed w. the dataset; unset until the
+	// first reload, the original (unversioned) keys are version 0
+	redisLiveVersionKey = "addr-version"
+
+	// redisVersionsKey - SET of all versions of a dataset's addresses, prefixed w. the dataset
+	redisVersionsKey = "addr-versions"
+
+	// redisVersionSequenceKey - counter for new versions, versions are unique across datasets
+	redisVersionSequenceKey = "addr-version-seq"
+
+	// redisIngestTimeKey - unix time of the last write to a version of a dataset's addresses, prefixed w. the
+	// dataset && suffixed w. the version
+	redisIngestTimeKey = "addr-ingest-time"
+
+	// redisIngestJobKeyPrefix - prefix of the hash of each ingest job's progress, jobs aren't tied to a dataset
+	redisIngestJobKeyPrefix = "ingest-job:"
+
+	// redisIngestJobTTL - time an ingest job can be resumed after its last chunk
+	redisIngestJobTTL = time.Hour * 24 * 7
+
+	// redisDatasetsKey - SET of the names of all datasets created w. `CreateDataset`
+	redisDatasetsKey = "datasets"
+
+	// redisSearchMaxBoundaryCandidates - max number of boundaries w. a bounding box containing the query point
+	redisSearchMaxBoundaryCandidates = 1024
+
+	// redisSearchMaxRadiusCandidates - max number of addresses fetched by a radius search before sorting by
+	// distance, FT.SEARCH can't sort on distance from a point so the nearest results are chosen client-side
+	redisSearchMaxRadiusCandidates = 4096
+
+	// redisSearchMaxStatsGroups - max number of distinct localities (or postal codes) counted in a dataset's stats
+	redisSearchMaxStatsGroups = 10000
+
+	// redisScanBatchSize - COUNT hint for each SCAN of an export, the hashes of each batch are fetched in one pipeline
+	redisScanBatchSize = 1000
+
+	// redisSearchNFieldsResponse - number of expected fields per result in a `FT.SEARCH ... WITHSCORES`
+	// response (id, score, fields) - assumes fixed across multiple methods
+	redisSearchNFieldsResponse = 3
+)
+
+// redisVersionPattern - matches the version in a versioned address key, e.g. `nyc:address@v3:1`
+var redisVersionPattern = regexp.MustCompile(`^([^@]*)@v[0-9]+:`)
+
+// RedisSearchBackend - `SearchBackend` implemented w. RediSearch
+type RedisSearchBackend struct {
+	client   *redis.Client
+	datasets sync.Map // dataset name -> struct{}, datasets known to exist; datasets are never dropped
+}
+
+// NewRedisSearchBackend - creates a RediSearch backend from an existing client
+func NewRedisSearchBackend(client *redis.Client) *RedisSearchBackend {
+	return &RedisSearchBackend{client: client}
+}
+
+// redisSearchComponentSchema - schema for the address component (and filter) fields, kept separate so they
+// can be added to an index that was created before components were stored
+var redisSearchComponentSchema = [][]interface{}{
+	{"street", "TEXT"},
+	{"locality", "TAG"},
+	{"postal_code", "TAG"},
+	{"region", "TAG"},
+	{"house_number_value", "NUMERIC"},
+	{"latitude", "NUMERIC"},
+	{"longitude", "NUMERIC"},
+}
+
+// redisSearchSegmentSchema - schema for street segments, the NUMERIC bounds of each side's address range are
+// (low, high) regardless of the direction of the range
+var redisSearchSegmentSchema = []interface{}{
+	"street", "TEXT",
+	"locality", "TAG",
+	"postal_code", "TAG",
+	"left_low", "NUMERIC",
+	"left_high", "NUMERIC",
+	"right_low", "NUMERIC",
+	"right_high", "NUMERIC",
+}
+
+// redisSearchBoundarySchema - schema for boundaries, only the bounding box is indexed -> point-in-polygon tests
+// run on the candidates
+var redisSearchBoundarySchema = []interface{}{
+	"layer", "TAG",
+	"min_lat", "NUMERIC",
+	"min_lng", "NUMERIC",
+	"max_lat", "NUMERIC",
+	"max_lng", "NUMERIC",
+}
+
+// IndexesReady - Creates the indexes of the default dataset && indexes boundaries starting w. `boundary:*`,
+// boundaries are shared by all datasets
+func (b *RedisSearchBackend) IndexesReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := b.CreateDataset(ctx, DefaultDataset); err != nil {
+		return err
+	}
+	return b.createIndex(ctx, redisSearchBoundaryIndex, "boundary:", redisSearchBoundarySchema)
+}
+
+// CreateDataset - Creates the index of the version of `<dataset>:address:*` being served && indexes the street
+// segments starting w. `<dataset>:segment:*`. The default dataset's keys have no prefix.
+func (b *RedisSearchBackend) CreateDataset(ctx context.Context, dataset string) error {
+	prefix := datasetPrefix(dataset)
+
+	version, err := b.liveVersion(ctx, dataset)
+	if err != nil {
+		return err
+	}
+
+	if err := b.createAddressIndex(ctx, dataset, version); err != nil {
+		return err
+	}
+
+	// a dataset that's never been reloaded serves its original index, later versions are swapped in by
+	// `PromoteVersion` w. FT.ALIASUPDATE
+	if version == 0 {
+		_, err := b.client.Do(ctx, "FT.ALIASADD", redisAddressAlias(dataset), redisAddressIndex(dataset, 0)).Result()
+		if (err != nil) && !strings.Contains(strings.ToLower(err.Error()), "already exists") {
+			return err
+		}
+		if err := b.client.SAdd(ctx, prefix+redisVersionsKey, 0).Err(); err != nil {
+			return err
+		}
+	}
+
+	if err := b.createIndex(ctx, prefix+redisSearchSegmentIndex, prefix+"segment:", redisSearchSegmentSchema); err != nil {
+		return err
+	}
+
+	if err := b.client.SAdd(ctx, redisDatasetsKey, dataset).Err(); err != nil {
+		return err
+	}
+	b.datasets.Store(dataset, struct{}{})
+	return nil
+}
+
+// DatasetExists - checks the SET of datasets, datasets known to exist are cached as they're never dropped
+func (b *RedisSearchBackend) DatasetExists(ctx context.Context, dataset string) (bool, error) {
+	if _, ok := b.datasets.Load(dataset); ok || (dataset == DefaultDataset) {
+		return true, nil
+	}
+
+	ok, err := b.client.SIsMember(ctx, redisDatasetsKey, dataset).Result()
+	if err != nil {
+		return false, ErrRedisClient
+	}
+	if ok {
+		b.datasets.Store(dataset, struct{}{})
+	}
+	return ok, nil
+}
+
+// createAddressIndex - Indexes the 'location', `composite_street_address` and component fields of all hashes of a
+// version of a dataset's addresses, elects for LOW MEMORY options where possible.
+func (b *RedisSearchBackend) createAddressIndex(ctx context.Context, dataset string, version int64) error {
+	args := []interface{}{
+		"FT.CREATE", redisAddressIndex(dataset, version), "ON", "HASH", "PREFIX", "1", redisAddressKey(dataset, version, ""),
+		"NOHL", "NOOFFSETS", "LANGUAGE", "english", "SCHEMA", "location", "GEO", "composite_street_address", "TEXT", "SORTABLE",
+	}
+	for _, field := range redisSearchComponentSchema {
+		args = append(args, field...)
+	}
+
+	_, err := b.client.Do(ctx, args...).Result()
+
+	if err != nil {
+		// expected error -> will throw on all server starts after the first unless db wiped
+		if err.Error() != "Index already exists" {
+			return err
+		}
+		log.WithFields(log.Fields{
+			"dataset": dataset,
+			"version": version,
+			"err":     err.Error(),
+		}).Debug("failed to create FT index, index exists")
+
+		return b.alterComponentSchema(ctx, redisAddressIndex(dataset, version))
+	}
+	return nil
+}
+
+// liveVersion - the version of a dataset's addresses being served
+func (b *RedisSearchBackend) liveVersion(ctx context.Context, dataset string) (int64, error) {
+	version, err := b.client.Get(ctx, datasetPrefix(dataset)+redisLiveVersionKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return version, err
+}
+
+// resolveVersion - resolves `LiveVersion` to the version being served, other versions must exist
+func (b *RedisSearchBackend) resolveVersion(ctx context.Context, dataset string, version int64) (int64, error) {
+	if version == LiveVersion {
+		return b.liveVersion(ctx, dataset)
+	}
+
+	ok, err := b.client.SIsMember(ctx, datasetPrefix(dataset)+redisVersionsKey, version).Result()
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrDatasetVersionNotFound
+	}
+	return version, nil
+}
+
+// CreateVersion - creates the index of a new version of a dataset's addresses, versioned keys look like
+// `<dataset>:address@v<version>:*`
+func (b *RedisSearchBackend) CreateVersion(ctx context.Context, dataset string) (int64, error) {
+	version, err := b.client.Incr(ctx, redisVersionSequenceKey).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := b.createAddressIndex(ctx, dataset, version); err != nil {
+		return 0, err
+	}
+	if err := b.client.SAdd(ctx, datasetPrefix(dataset)+redisVersionsKey, version).Err(); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// CountAddresses - `num_docs` from FT.INFO on a version's index; HSETs are indexed synchronously so the count
+// includes every address written
+func (b *RedisSearchBackend) CountAddresses(ctx context.Context, dataset string, version int64) (int, error) {
+	version, err := b.resolveVersion(ctx, dataset, version)
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := b.indexInfo(ctx, redisAddressIndex(dataset, version))
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.ParseFloat(info["num_docs"], 64)
+	if err != nil {
+		return 0, ErrRedisClient
+	}
+	return int(n), nil
+}
+
+// indexInfo - the scalar fields of FT.INFO on an index
+func (b *RedisSearchBackend) indexInfo(ctx context.Context, index string) (map[string]string, error) {
+	res, err := b.client.Do(ctx, "FT.INFO", index).Result()
+	if err != nil {
+		return nil, ErrRedisClient
+	}
+
+	info, _ := SafeCast[[]interface{}](res)
+	return redisFieldMap(info), nil
+}
+
+// PromoteVersion - repoints the dataset's alias to a version's index w. FT.ALIASUPDATE, searches move to the new
+// index atomically; suggestions follow once the live version is set
+func (b *RedisSearchBackend) PromoteVersion(ctx context.Context, dataset string, version int64) error {
+	if version == LiveVersion {
+		return nil
+	}
+
+	version, err := b.resolveVersion(ctx, dataset, version)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.client.Do(ctx, "FT.ALIASUPDATE", redisAddressAlias(dataset), redisAddressIndex(dataset, version)).Result()
+	if err != nil {
+		return err
+	}
+	return b.client.Set(ctx, datasetPrefix(dataset)+redisLiveVersionKey, version, 0).Err()
+}
+
+// HasPendingVersion - checks the dataset's versions for one newer than the one being served, versions are numbered
+// in the order they're created so older versions are ones replaced (&& not yet dropped)
+func (b *RedisSearchBackend) HasPendingVersion(ctx context.Context, dataset string) (bool, error) {
+	live, err := b.liveVersion(ctx, dataset)
+	if err != nil {
+		return false, err
+	}
+
+	versions, err := b.client.SMembers(ctx, datasetPrefix(dataset)+redisVersionsKey).Result()
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range versions {
+		if version, err := strconv.ParseInt(v, 10, 64); (err == nil) && (version > live) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DropStaleVersions - drops the index (w. `DD`, deleting its addresses) && the suggestions of each version other
+// than the one being served
+//
+// WARN: FT.DROPINDEX ... DD deletes all of an index's hashes before returning, dropping a large version blocks
+// redis for a few seconds
+func (b *RedisSearchBackend) DropStaleVersions(ctx context.Context, dataset string) (int, error) {
+	live, err := b.liveVersion(ctx, dataset)
+	if err != nil {
+		return 0, err
+	}
+
+	versions, err := b.client.SMembers(ctx, datasetPrefix(dataset)+redisVersionsKey).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, v := range versions {
+		version, err := strconv.ParseInt(v, 10, 64)
+		if (err != nil) || (version == live) {
+			continue
+		}
+
+		if err := b.dropVersion(ctx, dataset, version); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// DropVersion - drops the index (w. `DD`, deleting its addresses) && the suggestions of a single unserved version,
+// e.g. a reload that failed before it was promoted
+//
+// WARN: see `DropStaleVersions`, dropping a large version blocks redis for a few seconds
+func (b *RedisSearchBackend) DropVersion(ctx context.Context, dataset string, version int64) error {
+	if version == LiveVersion {
+		return ErrDatasetVersionLive
+	}
+
+	version, err := b.resolveVersion(ctx, dataset, version)
+	if err != nil {
+		return err
+	}
+
+	live, err := b.liveVersion(ctx, dataset)
+	if err != nil {
+		return err
+	}
+	if version == live {
+		return ErrDatasetVersionLive
+	}
+	return b.dropVersion(ctx, dataset, version)
+}
+
+// dropVersion - drops a version's index, addresses, suggestions && ingest time, then forgets the version; a
+// version whose index is already gone (e.g. an earlier drop that failed part way) is still forgotten
+func (b *RedisSearchBackend) dropVersion(ctx context.Context, dataset string, version int64) error {
+	_, err := b.client.Do(ctx, "FT.DROPINDEX", redisAddressIndex(dataset, version), "DD").Result()
+	if (err != nil) && !strings.Contains(strings.ToLower(err.Error()), "unknown index") {
+		return err
+	}
+
+	pipe := b.client.TxPipeline()
+	pipe.Del(ctx, redisSuggestionDict(dataset, version), redisIngestTime(dataset, version))
+	pipe.SRem(ctx, datasetPrefix(dataset)+redisVersionsKey, version)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// redisVersionSuffix - suffix of the keys && indexes of a version of a dataset's addresses, none for the original
+// (unversioned) keys; dataset names can't contain `@` so versioned keys never match another dataset's prefix
+func redisVersionSuffix(version int64) string {
+	if version == 0 {
+		return ""
+	}
+	return fmt.Sprintf("@v%d", version)
+}
+
+// redisAddressKey - the key a version of an address is stored under, results are returned w. the unversioned
+// key (see `addressKey`) so ids are stable across reloads
+func redisAddressKey(dataset string, version int64, id string) string {
+	return datasetPrefix(dataset) + "address" + redisVersionSuffix(version) + ":" + id
+}
+
+// redisAddressIndex - the index of a version of a dataset's addresses
+func redisAddressIndex(dataset string, version int64) string {
+	return datasetPrefix(dataset) + redisSearchAddressIndex + redisVersionSuffix(version)
+}
+
+// redisAddressAlias - the alias of the index being served
+func redisAddressAlias(dataset string) string {
+	return datasetPrefix(dataset) + redisSearchAddressAlias
+}
+
+// redisSuggestionDict - the suggestion dictionary of a version of a dataset's addresses
+func redisSuggestionDict(dataset string, version int64) string {
+	return datasetPrefix(dataset) + redisSearchSuggestionDict + redisVersionSuffix(version)
+}
+
+// redisIngestTime - the key of the last write to a version of a dataset's addresses
+func redisIngestTime(dataset string, version int64) string {
+	return datasetPrefix(dataset) + redisIngestTimeKey + redisVersionSuffix(version)
+}
+
+// createIndex - creates an index on all hashes w. a prefix, an existing index is not an error
+func (b *RedisSearchBackend) createIndex(ctx context.Context, name, prefix string, schema []interface{}) error {
+	args := append([]interface{}{
+		"FT.CREATE", name, "ON", "HASH", "PREFIX", "1", prefix, "NOHL", "NOOFFSETS",
+		"LANGUAGE", "english", "SCHEMA",
+	}, schema...)
+
+	_, err := b.client.Do(ctx, args...).Result()
+	if (err != nil) && (err.Error() != "Index already exists") {
+		return err
+	}
+	return nil
+}
+
+// alterComponentSchema - adds the component fields to an existing index, fields that already exist are skipped
+func (b *RedisSearchBackend) alterComponentSchema(ctx context.Context, index string) error {
+	for _, field := range redisSearchComponentSchema {
+		args := append([]interface{}{"FT.ALTER", index, "SCHEMA", "ADD"}, field...)
+		_, err := b.client.Do(ctx, args...).Result()
+		if (err != nil) && !strings.Contains(strings.ToLower(err.Error()), "duplicate") {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upsert - writes all addresses in a single transaction pipeline
+func (b *RedisSearchBackend) Upsert(ctx context.Context, dataset string, version int64, addresses []*pb.Address) error {
+	version, err := b.resolveVersion(ctx, dataset, version)
+	if err != nil {
+		return err
+	}
+
+	pipe := b.client.TxPipeline()
+	for _, address := range addresses {
+		// note: Redis uses Long, Lat...
+		args := []interface{}{
+			"HSET", redisAddressKey(dataset, version, address.Id),
+			"location", fmt.Sprintf("%.6f, %.6f", address.Location.Latitude, address.Location.Longitude),
+			"composite_street_address", address.CompositeStreetAddress,
+			"house_number", address.HouseNumber,
+			"street", address.Street,
+			"locality", address.Locality,
+			"postal_code", address.PostalCode,
+			"region", address.Region,
+			"latitude", address.Location.Latitude,
+			"longitude", address.Location.Longitude,
+		}
+
+		// WARN: a non-numeric value on a NUMERIC field fails indexing for the whole hash, only set when valid
+		if v, ok := HouseNumberValue(address.HouseNumber); ok {
+			args = append(args, "house_number_value", v)
+		}
+		pipe.Do(ctx, args...)
+
+		// WARN: replacing an address w. a new `composite_street_address` leaves the old suggestion in the dictionary
+		pipe.Do(ctx, "FT.SUGADD", redisSuggestionDict(dataset, version), address.CompositeStreetAddress, 1,
+			"PAYLOAD", addressKey(dataset, address.Id),
+		)
+	}
+	pipe.Set(ctx, redisIngestTime(dataset, version), time.Now().Unix(), 0)
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// UpsertSegments - writes all street segments in a single transaction pipeline
+func (b *RedisSearchBackend) UpsertSegments(ctx context.Context, dataset string, segments []*pb.StreetSegment) error {
+	pipe := b.client.TxPipeline()
+	for _, seg := range segments {
+		args := []interface{}{
+			"HSET", segmentKey(dataset, seg.Id),
+			"street", seg.Street,
+			"locality", seg.Locality,
+			"postal_code", seg.PostalCode,
+			"geometry", formatSegmentGeometry(seg.Geometry),
+			"left_from", seg.GetLeft().GetFromHouseNumber(),
+			"left_to", seg.GetLeft().GetToHouseNumber(),
+			"right_from", seg.GetRight().GetFromHouseNumber(),
+			"right_to", seg.GetRight().GetToHouseNumber(),
+		}
+
+		// WARN: same as `house_number_value`, only set NUMERIC fields when valid
+		if low, high, ok := addressRangeBounds(seg.Left); ok {
+			args = append(args, "left_low", low, "left_high", high)
+		}
+		if low, high, ok := addressRangeBounds(seg.Right); ok {
+			args = append(args, "right_low", low, "right_high", high)
+		}
+		pipe.Do(ctx, args...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// UpsertBoundaries - writes all boundaries in a single transaction pipeline
+func (b *RedisSearchBackend) UpsertBoundaries(ctx context.Context, boundaries []*pb.Boundary) error {
+	pipe := b.client.TxPipeline()
+	for _, bnd := range boundaries {
+		mp, err := parseBoundaryGeometry(bnd.Geojson)
+		if err != nil {
+			return err
+		}
+
+		minLat, minLng, maxLat, maxLng := mp.bounds()
+		pipe.HSet(ctx, boundaryKey(bnd.Layer, bnd.Id),
+			"id", bnd.Id,
+			"layer", bnd.Layer,
+			"name", bnd.Name,
+			"geojson", bnd.Geojson,
+			"min_lat", minLat,
+			"min_lng", minLng,
+			"max_lat", maxLat,
+			"max_lng", maxLng,
+		)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Delete - removes all addresses (and their suggestions) in a single call to DEL
+func (b *RedisSearchBackend) Delete(ctx context.Context, dataset string, ids []string) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	version, err := b.liveVersion(ctx, dataset)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = redisAddressKey(dataset, version, id)
+	}
+
+	// the suggestion dictionary is keyed on the address string -> look these up before they're deleted
+	pipe := b.client.Pipeline()
+	composites := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		composites[i] = pipe.HGet(ctx, key, "composite_street_address")
+	}
+	if _, err := pipe.Exec(ctx); (err != nil) && (err != redis.Nil) {
+		return 0, err
+	}
+
+	pipe = b.client.TxPipeline()
+	for _, c := range composites {
+		if c.Err() == nil {
+			pipe.Do(ctx, "FT.SUGDEL", redisSuggestionDict(dataset, version), c.Val())
+		}
+	}
+	del := pipe.Del(ctx, keys...)
+	pipe.Set(ctx, redisIngestTime(dataset, version), time.Now().Unix(), 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return int(del.Val()), nil
+}
+
+// redisIndexSizeFields - fields of FT.INFO summed into a dataset's `index_size_mb`
+var redisIndexSizeFields = []string{
+	"inverted_sz_mb", "offset_vectors_sz_mb", "doc_table_size_mb", "sortable_values_size_mb", "key_table_size_mb",
+}
+
+// Stats - FT.INFO on the index being served for size && index memory, FT.AGGREGATE for the bounding box && counts
+// by locality && postal code
+//
+// WARN: each FT.AGGREGATE reads every address in the index, this is an operator tool && not for the request path
+func (b *RedisSearchBackend) Stats(ctx context.Context, dataset string) (*pb.DatasetStats, error) {
+	version, err := b.liveVersion(ctx, dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	index := redisAddressIndex(dataset, version)
+	info, err := b.indexInfo(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+
+	numDocs, _ := strconv.ParseFloat(info["num_docs"], 64)
+	stats := &pb.DatasetStats{
+		Dataset:                  dataset,
+		Version:                  version,
+		NumAddresses:             int32(numDocs),
+		NumAddressesByLocality:   make(map[string]int32),
+		NumAddressesByPostalCode: make(map[string]int32),
+	}
+
+	for _, f := range redisIndexSizeFields {
+		if mb, err := strconv.ParseFloat(info[f], 64); err == nil {
+			stats.IndexSizeMb += mb
+		}
+	}
+
+	// ingest time is only recorded from the first write after upgrading, unset for older versions
+	ts, err := b.client.Get(ctx, redisIngestTime(dataset, version)).Int64()
+	if (err != nil) && (err != redis.Nil) {
+		return nil, err
+	}
+	if err == nil {
+		stats.LastIngestTime = timestamppb.New(time.Unix(ts, 0))
+	}
+
+	if stats.NumAddresses == 0 {
+		return stats, nil
+	}
+
+	rows, err := b.aggregate(ctx, index,
+		"LOAD", 2, "@latitude", "@longitude",
+		"GROUPBY", 0,
+		"REDUCE", "MIN", 1, "@latitude", "AS", "min_lat",
+		"REDUCE", "MIN", 1, "@longitude", "AS", "min_lng",
+		"REDUCE", "MAX", 1, "@latitude", "AS", "max_lat",
+		"REDUCE", "MAX", 1, "@longitude", "AS", "max_lng",
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		parse := func(k string) float32 {
+			v, _ := strconv.ParseFloat(rows[0][k], 32)
+			return float32(v)
+		}
+		stats.BoundingBox = &pb.BoundingBox{
+			Min: &pb.Point{Latitude: parse("min_lat"), Longitude: parse("min_lng")},
+			Max: &pb.Point{Latitude: parse("max_lat"), Longitude: parse("max_lng")},
+		}
+	}
+
+	for field, counts := range map[string]map[string]int32{
+		"locality":    stats.NumAddressesByLocality,
+		"postal_code": stats.NumAddressesByPostalCode,
+	} {
+		rows, err := b.aggregate(ctx, index,
+			"LOAD", 1, "@"+field,
+			"GROUPBY", 1, "@"+field,
+			"REDUCE", "COUNT", 0, "AS", "count",
+			"LIMIT", 0, redisSearchMaxStatsGroups,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			n, _ := strconv.ParseInt(row["count"], 10, 32)
+			counts[row[field]] = int32(n)
+		}
+	}
+	return stats, nil
+}
+
+// aggregate - runs FT.AGGREGATE over all documents in an index, returns each row as a map of field -> value
+func (b *RedisSearchBackend) aggregate(ctx context.Context, index string, args ...interface{}) ([]map[string]string, error) {
+	res, err := b.client.Do(ctx, append([]interface{}{"FT.AGGREGATE", index, "*"}, args...)...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// response is the number of rows followed by each row as a flat list of (field, value) pairs
+	resultSet, _ := SafeCast[[]interface{}](res)
+	if len(resultSet) == 0 {
+		return nil, nil
+	}
+
+	rows := make([]map[string]string, 0, len(resultSet)-1)
+	for _, r := range resultSet[1:] {
+		pairs, _ := SafeCast[[]interface{}](r)
+		rows = append(rows, redisFieldMap(pairs))
+	}
+	return rows, nil
+}
+
+// GetAddress - HGETALL on the version of an address being served, returned w. the same `Id` as in results
+func (b *RedisSearchBackend) GetAddress(ctx context.Context, dataset, id string) (*pb.Address, error) {
+	version, err := b.liveVersion(ctx, dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := b.client.HGetAll(ctx, redisAddressKey(dataset, version, id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrAddressNotFound
+	}
+	return addressFromFields(addressKey(dataset, id), fields), nil
+}
+
+// Export - SCANs the keys of the version of a dataset being served && fetches each batch w. HGETALL, keys are
+// matched on `IDPrefix` && the hashes filtered client-side
+//
+// WARN: SCAN may return a key more than once (e.g. while redis rehashes), duplicates are only skipped within a batch
+// so an address is exported at-least-once; holding every key exported would grow w. the size of the dataset
+func (b *RedisSearchBackend) Export(ctx context.Context, q *ExportQuery, fn func(*pb.Address) error) error {
+	version, err := b.liveVersion(ctx, q.Dataset)
+	if err != nil {
+		return err
+	}
+
+	prefix := redisAddressKey(q.Dataset, version, "")
+	match := escapeRedisGlob(prefix+q.IDPrefix) + "*"
+
+	var cursor uint64
+	for {
+		keys, next, err := b.client.Scan(ctx, cursor, match, redisScanBatchSize).Result()
+		if err != nil {
+			return err
+		}
+
+		pipe := b.client.Pipeline()
+		hashes := make([]*redis.StringStringMapCmd, len(keys))
+		for i, key := range keys {
+			hashes[i] = pipe.HGetAll(ctx, key)
+		}
+		if len(keys) > 0 {
+			if _, err := pipe.Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		seen := make(map[string]struct{}, len(keys))
+		for i, key := range keys {
+			// hashes deleted between SCAN && HGETALL come back empty
+			if _, ok := seen[key]; ok || (len(hashes[i].Val()) == 0) {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			address := addressFromFields(strings.TrimPrefix(key, prefix), hashes[i].Val())
+			address.Dataset = q.Dataset
+			if !q.Filter.Matches(address) {
+				continue
+			}
+			if err := fn(address); err != nil {
+				return err
+			}
+		}
+
+		if cursor = next; cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// escapeRedisGlob - escapes the special characters of a SCAN MATCH pattern
+func escapeRedisGlob(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// SaveIngestJob - writes the job to a hash && resets its TTL
+func (b *RedisSearchBackend) SaveIngestJob(ctx context.Context, job *pb.IngestJob) error {
+	key := redisIngestJobKeyPrefix + job.JobId
+
+	pipe := b.client.TxPipeline()
+	pipe.HSet(ctx, key,
+		"dataset", job.Dataset,
+		"version", job.Version,
+		"source", job.Source,
+		"committed_offset", job.CommittedOffset,
+		"total_objects_rejected", job.TotalObjectsRejected,
+		"create_time", job.GetCreateTime().AsTime().UnixMilli(),
+		"update_time", job.GetUpdateTime().AsTime().UnixMilli(),
+	)
+	pipe.Expire(ctx, key, redisIngestJobTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetIngestJob - reads a job's hash
+func (b *RedisSearchBackend) GetIngestJob(ctx context.Context, id string) (*pb.IngestJob, error) {
+	fields, err := b.client.HGetAll(ctx, redisIngestJobKeyPrefix+id).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrIngestJobNotFound
+	}
+
+	parseInt := func(k string) int64 {
+		v, _ := strconv.ParseInt(fields[k], 10, 64)
+		return v
+	}
+
+	return &pb.IngestJob{
+		JobId:                id,
+		Dataset:              fields["dataset"],
+		Version:              parseInt("version"),
+		Source:               fields["source"],
+		CommittedOffset:      parseInt("committed_offset"),
+		TotalObjectsRejected: parseInt("total_objects_rejected"),
+		CreateTime:           timestamppb.New(time.UnixMilli(parseInt("create_time"))),
+		UpdateTime:           timestamppb.New(time.UnixMilli(parseInt("update_time"))),
+	}, nil
+}
+
+// SearchText - forward geocode w. FT.SEARCH on `composite_street_address`, results are post-filtered w.
+// `SearchFilter.Matches` as the filter's radius clause is widened (see `redisGeoRadiusClause`)
+func (b *RedisSearchBackend) SearchText(ctx context.Context, q *TextQuery) ([]*pb.ScoredAddress, error) {
+	query := strings.Join(append([]string{buildRedisTextQuery(q)}, buildRedisFilterClauses(q.Filter)...), " ")
+
+	// the radius clause matches more than the filter's radius (see `redisGeoRadiusClause`), fetch enough candidates
+	// that the ones dropped by the filter don't cut the results short of `q.Limit`
+	limit := q.Limit
+	if (q.Filter != nil) && (q.Filter.Center != nil) && (limit < redisSearchMaxRadiusCandidates) {
+		limit = redisSearchMaxRadiusCandidates
+	}
+
+	res, err := b.client.Do(
+		ctx, "FT.SEARCH", redisAddressAlias(q.Dataset), query,
+		"WITHSCORES", "LANGUAGE", "english", "SCORER", "TFIDF.DOCNORM", "LIMIT", "0", limit,
+	).Result()
+
+	// some unknown error preventing results -> raise as internal :(
+	if err != nil {
+		return nil, ErrRedisClient
+	}
+
+	candidates, err := parseSearchResponse(res, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	var results = make([]*pb.ScoredAddress, 0, len(candidates))
+	for _, c := range candidates {
+		if len(results) == q.Limit {
+			break
+		}
+		if q.Filter.Matches(c.Address) {
+			results = append(results, c)
+		}
+	}
+	return results, nil
+}
+
+// SearchSegments - FT.SEARCH on `street` (&& `locality`, `postal_code`) intersected w. the segments where either
+// side's address range contains the house number
+func (b *RedisSearchBackend) SearchSegments(ctx context.Context, q *SegmentQuery) ([]*ScoredSegment, error) {
+	v, ok := HouseNumberValue(q.Address.HouseNumber)
+	if !ok || (q.Address.FullStreet() == "") {
+		return nil, ErrMalformedRedisQuery
+	}
+
+	clauses := []string{fmt.Sprintf("@street:(%s)", escapeRedisQuery(q.Address.FullStreet(), false))}
+	for _, tag := range [][2]string{
+		{"locality", q.Address.Locality},
+		{"postal_code", q.Address.PostalCode},
+	} {
+		if tag[1] != "" {
+			clauses = append(clauses, fmt.Sprintf("@%s:{%s}", tag[0], escapeRedisQuery(tag[1], true)))
+		}
+	}
+	clauses = append(clauses, fmt.Sprintf(
+		"((@left_low:[-inf %d] @left_high:[%d +inf]) | (@right_low:[-inf %d] @right_high:[%d +inf]))",
+		int64(v), int64(v), int64(v), int64(v),
+	))
+
+	res, err := b.client.Do(
+		ctx, "FT.SEARCH", datasetPrefix(q.Dataset)+redisSearchSegmentIndex, strings.Join(clauses, " "),
+		"WITHSCORES", "LANGUAGE", "english", "SCORER", "TFIDF.DOCNORM", "LIMIT", "0", q.Limit,
+	).Result()
+
+	// some uncaught error preventing results -> raise as internal :(
+	if err != nil {
+		return nil, ErrRedisClient
+	}
+
+	results := parseSearchResults(res, int64(q.Limit))
+	segments := make([]*ScoredSegment, len(results))
+	for i, r := range results {
+		segments[i] = &ScoredSegment{
+			Segment: &pb.StreetSegment{
+				Id:         strings.TrimPrefix(r.id, segmentKey(q.Dataset, "")),
+				Street:     r.fields["street"],
+				Locality:   r.fields["locality"],
+				PostalCode: r.fields["postal_code"],
+				Geometry:   ParseSegmentGeometry(r.fields["geometry"]),
+				Left:       &pb.AddressRange{FromHouseNumber: r.fields["left_from"], ToHouseNumber: r.fields["left_to"]},
+				Right:      &pb.AddressRange{FromHouseNumber: r.fields["right_from"], ToHouseNumber: r.fields["right_to"]},
+			},
+			NormedConfidence: r.normedScore,
+		}
+	}
+	return segments, nil
+}
+
+// SearchBoundaries - FT.SEARCH for the boundaries w. a bounding box containing the point, followed by a
+// point-in-polygon test on each candidate
+func (b *RedisSearchBackend) SearchBoundaries(ctx context.Context, q *BoundaryQuery) ([]*pb.Boundary, error) {
+	lat, lng := q.Point.Latitude, q.Point.Longitude
+
+	clauses := []string{fmt.Sprintf(
+		"@min_lat:[-inf %.8f] @max_lat:[%.8f +inf] @min_lng:[-inf %.8f] @max_lng:[%.8f +inf]", lat, lat, lng, lng,
+	)}
+	if len(q.Layers) > 0 {
+		escaped := make([]string, len(q.Layers))
+		for i, l := range q.Layers {
+			escaped[i] = escapeRedisQuery(l, true)
+		}
+		clauses = append(clauses, fmt.Sprintf("@layer:{%s}", strings.Join(escaped, " | ")))
+	}
+
+	res, err := b.client.Do(
+		ctx, "FT.SEARCH", redisSearchBoundaryIndex, strings.Join(clauses, " "),
+		"WITHSCORES", "LIMIT", "0", redisSearchMaxBoundaryCandidates,
+	).Result()
+
+	// some uncaught error preventing results -> raise as internal :(
+	if err != nil {
+		return nil, ErrRedisClient
+	}
+
+	var boundaries []*pb.Boundary
+	for _, r := range parseSearchResults(res, redisSearchMaxBoundaryCandidates) {
+		bnd := &pb.Boundary{
+			Id:      r.fields["id"],
+			Layer:   r.fields["layer"],
+			Name:    r.fields["name"],
+			Geojson: r.fields["geojson"],
+		}
+		if BoundaryContains(bnd, q.Point) {
+			bnd.Geojson = ""
+			boundaries = append(boundaries, bnd)
+		}
+	}
+
+	sortBoundaries(boundaries)
+	return boundaries, nil
+}
+
+// Suggest - FT.SUGGET on the suggestion dictionary populated by `Upsert`
+func (b *RedisSearchBackend) Suggest(ctx context.Context, q *SuggestQuery) ([]*pb.Suggestion, error) {
+	version, err := b.liveVersion(ctx, q.Dataset)
+	if err != nil {
+		return nil, ErrRedisClient
+	}
+
+	args := []interface{}{"FT.SUGGET", redisSuggestionDict(q.Dataset, version), q.Prefix}
+	if q.Fuzzy {
+		args = append(args, "FUZZY")
+	}
+	args = append(args, "WITHSCORES", "WITHPAYLOADS", "MAX", q.Limit)
+
+	res, err := b.client.Do(ctx, args...).Result()
+	if err == redis.Nil {
+		return []*pb.Suggestion{}, nil
+	}
+
+	// some uncaught error preventing results -> raise as internal :(
+	if err != nil {
+		return nil, ErrRedisClient
+	}
+
+	// response is a flat list of (suggestion, score, payload)
+	resultSet, _ := SafeCast[[]interface{}](res)
+	suggestions := make([]*pb.Suggestion, 0, len(resultSet)/3)
+	for i := 0; i+2 < len(resultSet); i += 3 {
+		text, _ := SafeCast[string](resultSet[i])
+		scoreStr, _ := SafeCast[string](resultSet[i+1])
+		score, _ := strconv.ParseFloat(scoreStr, 32)
+		id, _ := SafeCast[string](resultSet[i+2])
+
+		suggestions = append(suggestions, &pb.Suggestion{
+			Text:  text,
+			Score: float32(score),
+			Id:    id,
+		})
+	}
+	return suggestions, nil
+}
+
+// redisIndexableBounds - `location` is stored as (lat, lng) && Redis reads it as (lng, lat), so an address's longitude
+// is indexed as a latitude && GEO indexing fails (silently, the address is never matched by a radius) outside
+// the latitudes redis supports
+var redisIndexableBounds = &pb.BoundingBox{
+	Min: &pb.Point{Latitude: -90, Longitude: -85.05112878},
+	Max: &pb.Point{Latitude: 90, Longitude: 85.05112878},
+}
+
+// IndexableBounds - see `redisIndexableBounds`
+func (b *RedisSearchBackend) IndexableBounds() *pb.BoundingBox {
+	return redisIndexableBounds
+}
+
+// redisGeoRadiusClause - a clause on `location` matching at least every address within `meters` of `center`, results
+// must be filtered on their true (haversine) distance
+//
+// note: `location` is stored as (lat, lng), Redis reads it as (lng, lat) and measures distances along our latitude
+// too short && along our longitude too long by up to 1/cos(lat). Widen the search by 1/cos(lat) so that no
+// address within the true radius is missed.
+func redisGeoRadiusClause(center *pb.Point, meters float64) string {
+	searchRadius := meters / math.Max(math.Cos(float64(center.Latitude)*math.Pi/180), 1e-6)
+	return fmt.Sprintf("@location:[%.8f %.8f %d m]", center.Latitude, center.Longitude, int(math.Ceil(searchRadius)))
+}
+
+// SearchRadius - reverse geocode w. FT.SEARCH on `location`, candidates are sorted && filtered on their true
+// (haversine) distance from the query point && on the filter
+func (b *RedisSearchBackend) SearchRadius(ctx context.Context, q *RadiusQuery) ([]*pb.ScoredAddress, error) {
+	query := strings.Join(append(
+		[]string{redisGeoRadiusClause(q.Center, q.RadiusMeters)},
+		buildRedisFilterClauses(q.Filter)...,
+	), " ")
+
+	res, err := b.client.Do(
+		ctx, "FT.SEARCH", redisAddressAlias(q.Dataset), query,
+		"WITHSCORES", "LIMIT", "0", redisSearchMaxRadiusCandidates,
+	).Result()
+
+	// some uncaught error preventing results -> raise as internal :(
+	if err != nil {
+		return nil, ErrRedisClient
+	}
+
+	candidates, err := parseSearchResponse(res, redisSearchMaxRadiusCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == redisSearchMaxRadiusCandidates {
+		log.WithFields(log.Fields{
+			"radius_meters": q.RadiusMeters,
+		}).Warn("radius search hit max candidates, nearest results may be missing")
+	}
+
+	var results = make([]*pb.ScoredAddress, 0, len(candidates))
+	for _, c := range candidates {
+		if d := HaversineDistance(q.Center, c.Address.Location); (d <= q.RadiusMeters) && q.Filter.Matches(c.Address) {
+			c.DistanceMeters = float32(d)
+			results = append(results, c)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].DistanceMeters < results[j].DistanceMeters
+	})
+
+	if len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results, nil
+}
+
+// buildRedisTextQuery - free text queries are passed through as-is, structured queries get one
+// (escaped) clause per component on the component's own field
+func buildRedisTextQuery(q *TextQuery) string {
+	if q.Address == nil {
+		return fmt.Sprintf("@composite_street_address:(%s)", q.Text)
+	}
+
+	var clauses []string
+	if v, ok := HouseNumberValue(q.Address.HouseNumber); ok {
+		clauses = append(clauses, fmt.Sprintf("@house_number_value:[%d %d]", int64(v), int64(v)))
+	}
+	if street := q.Address.FullStreet(); street != "" {
+		clauses = append(clauses, fmt.Sprintf("@street:(%s)", escapeRedisQuery(street, false)))
+	}
+	for _, tag := range [][2]string{
+		{"locality", q.Address.Locality},
+		{"postal_code", q.Address.PostalCode},
+		{"region", q.Address.Region},
+	} {
+		if tag[1] != "" {
+			clauses = append(clauses, fmt.Sprintf("@%s:{%s}", tag[0], escapeRedisQuery(tag[1], true)))
+		}
+	}
+	return strings.Join(clauses, " ")
+}
+
+// buildRedisFilterClauses - one clause per set field of the filter, intersected w. the main query; the
+// bounding box uses the NUMERIC `latitude` && `longitude` fields as GEO fields only support a radius. The radius
+// clause is widened, results must be post-filtered w. `SearchFilter.Matches`
+func buildRedisFilterClauses(f *SearchFilter) []string {
+	if f == nil {
+		return nil
+	}
+
+	var clauses []string
+	if bb := f.BoundingBox; bb != nil {
+		clauses = append(clauses,
+			fmt.Sprintf("@latitude:[%.8f %.8f]", bb.Min.Latitude, bb.Max.Latitude),
+			fmt.Sprintf("@longitude:[%.8f %.8f]", bb.Min.Longitude, bb.Max.Longitude),
+		)
+	}
+
+	if f.Center != nil {
+		clauses = append(clauses, redisGeoRadiusClause(f.Center, f.RadiusMeters))
+	}
+
+	for _, tag := range []struct {
+		field  string
+		values []string
+	}{
+		{"postal_code", f.PostalCodes},
+		{"locality", f.Localities},
+	} {
+		if len(tag.values) == 0 {
+			continue
+		}
+		escaped := make([]string, len(tag.values))
+		for i, v := range tag.values {
+			escaped[i] = escapeRedisQuery(v, true)
+		}
+		clauses = append(clauses, fmt.Sprintf("@%s:{%s}", tag.field, strings.Join(escaped, " | ")))
+	}
+	return clauses
+}
+
+// escapeRedisQuery - escapes all punctuation in a query term, e.g. the hyphen in `107-15` would
+// otherwise be read as a negation; TAG values must also escape spaces
+func escapeRedisQuery(s string, isTag bool) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && (isTag || !unicode.IsSpace(r)) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// redisSearchResult - a single result of `FT.SEARCH ... WITHSCORES`
+type redisSearchResult struct {
+	id          string
+	normedScore float32
+	fields      map[string]string
+}
+
+// parseSearchResults - handles the generic format of a `FT.SEARCH ... WITHSCORES` response, limited to the
+// smaller of n results and `maxResults`
+//
+// WARN: makes extensive use of `SafeCast[T](someInterface)` to convert the []interface{}
+// we get back from the server to structs. For brevity -> no error checking here
+func parseSearchResults(res interface{}, maxResults int64) []redisSearchResult {
+
+	resultSet, _ := SafeCast[[]interface{}](res)
+	nResults, _ := SafeCast[int64](resultSet[0])
+
+	// parse the result array -> limit to smaller of n results and max results to pre-allocate size...
+	if maxResults < nResults {
+		nResults = maxResults
+	}
+
+	// FT.SEARCH may report more total results than it returns
+	if n := int64(len(resultSet)-1) / redisSearchNFieldsResponse; n < nResults {
+		nResults = n
+	}
+
+	var results = make([]redisSearchResult, nResults)
+
+	if cap(results) == 0 {
+		return results
+	}
+
+	// Grab the `maxConfidence` from the first result -> produce a normalized confidence for each
+	// result in the set...
+	//
+	// NOTE: assumes results sorted in order of best -> worst match
+	confidenceStr, _ := SafeCast[string](resultSet[2])
+	maxConfidence, _ := strconv.ParseFloat(confidenceStr, 32)
+
+	for i := int64(0); i < nResults; i++ {
+
+		resultStartPosition := (i * redisSearchNFieldsResponse) + 1
+
+		// extract ID
+		Id, _ := SafeCast[string](resultSet[resultStartPosition])
+
+		// extract confidence score
+		confScoreStr, _ := SafeCast[string](resultSet[resultStartPosition+1])
+		confScore, _ := strconv.ParseFloat(confScoreStr, 32)
+
+		// extract details (e.g. lat, lng, address...) - returned as a flat list of field, value pairs
+		details, _ := SafeCast[[]interface{}](resultSet[resultStartPosition+2])
+
+		results[i] = redisSearchResult{
+			id:          Id,
+			normedScore: float32(confScore / maxConfidence),
+			fields:      redisFieldMap(details),
+		}
+	}
+	return results
+}
+
+// parseSearchResponse - handles a *very specific* format of server response from both
+// forward and reverse geocoding, returns []*pb.scoredAddress
+func parseSearchResponse(res interface{}, maxResults int64) ([]*pb.ScoredAddress, error) {
+
+	results := parseSearchResults(res, maxResults)
+
+	var addressResults = make([]*pb.ScoredAddress, len(results))
+	for i, r := range results {
+
+		// append all results to addressresults...
+		addressResults[i] = &pb.ScoredAddress{
+			Address:          addressFromFields(redisVersionPattern.ReplaceAllString(r.id, "$1:"), r.fields),
+			NormedConfidence: r.normedScore,
+		}
+	}
+	return addressResults, nil
+}
+
+// addressFromFields - builds an address from the fields of an `address:*` hash
+func addressFromFields(id string, fields map[string]string) *pb.Address {
+	// TODO: WARN: NOTE: THIS IS A VERY SCARY FLAG
+	pt := PointFromLocationString(fields["location"], false)
+
+	return &pb.Address{
+		Location:               pt,
+		Id:                     id,
+		CompositeStreetAddress: fields["composite_street_address"],
+		HouseNumber:            fields["house_number"],
+		Street:                 fields["street"],
+		Locality:               fields["locality"],
+		PostalCode:             fields["postal_code"],
+		Region:                 fields["region"],
+	}
+}
+
+// redisFieldMap - a flat list of (field, value) pairs as a map, integer values are formatted && nested values
+// are skipped
+func redisFieldMap(pairs []interface{}) map[string]string {
+	fields := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		k, _ := SafeCast[string](pairs[i])
+		switch v := pairs[i+1].(type) {
+		case string:
+			fields[k] = v
+		case int64:
+			fields[k] = strconv.FormatInt(v, 10)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,44 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// TestRedisSearchTextRadiusFilter - candidates outside the filter's radius are matched by the widened radius clause,
+// dropping them mustn't leave fewer than `Limit` results
+func TestRedisSearchTextRadiusFilter(t *testing.T) {
+	client, fake := newFakeRedis(t)
+	b := NewRedisSearchBackend(client)
+
+	center := &pb.Point{Latitude: 40.6812, Longitude: -73.9479}
+	var results []fakeSearchResult
+	for i, lng := range []float32{-73.9300, -73.9479, -73.9480, -73.9481} { // the best match is ~1.5km east
+		results = append(results, fakeSearchResult{
+			id:    fmt.Sprintf("address:%d", i+1),
+			score: float64(10 - i),
+			fields: []string{
+				"location", fmt.Sprintf("%.6f, %.6f", center.Latitude, lng),
+				"composite_street_address", fmt.Sprintf("%d MACON ST BROOKLYN NEW YORK 11216", i+1),
+			},
+		})
+	}
+	fake.setSearchResults(redisAddressAlias(DefaultDataset), results)
+
+	got, err := b.SearchText(context.Background(), &TextQuery{
+		Text:   "MACON ST",
+		Filter: &SearchFilter{Center: center, RadiusMeters: 500},
+		Limit:  2,
+	})
+	if err != nil {
+		t.Fatalf("SearchText() = %v", err)
+	}
+	if (len(got) != 2) || (got[0].Address.Id != "address:2") || (got[1].Address.Id != "address:3") {
+		t.Errorf("SearchText() = %v, want address:2 && address:3", got)
+	}
+}
@@ -0,0 +1,209 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"regexp"
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+
+	// external
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SearchBackendRedis - RediSearch backed storage, the default for all deployments
+	SearchBackendRedis = "redis"
+
+	// SearchBackendMemory - pure-Go, in-process storage; no redis modules required
+	SearchBackendMemory = "memory"
+
+	// DefaultDataset - the dataset used when a request doesn't name one, stored w.o. a key prefix so deployments
+	// that predate datasets keep their data
+	DefaultDataset = "default"
+
+	// LiveVersion - the version of a dataset's addresses currently being served, see `SearchBackend.CreateVersion`
+	LiveVersion int64 = 0
+)
+
+// datasetNamePattern - dataset names are used in key prefixes && index names, keep them short && unambiguous
+var datasetNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// datasetReservedNames - names that would collide w. the key prefixes of the default dataset
+var datasetReservedNames = map[string]bool{"address": true, "segment": true, "boundary": true}
+
+// TextQuery - a backend-neutral forward (address -> point) query, set one of `Text` or `Address`
+type TextQuery struct {
+	Dataset string         // dataset to search, see `ParseDataset`
+	Text    string         // free text query, words wrapped in `%` are matched w. a levenshtein distance of 1
+	Address *ParsedAddress // structured query, each component must match
+	Filter  *SearchFilter  // optional constraints on results
+	Limit   int            // max number of results to return
+}
+
+// RadiusQuery - a backend-neutral reverse (point -> address) query
+type RadiusQuery struct {
+	Dataset      string        // dataset to search, see `ParseDataset`
+	Center       *pb.Point     // query point
+	RadiusMeters float64       // only addresses within `RadiusMeters` of `Center` are considered
+	Filter       *SearchFilter // optional constraints on results
+	Limit        int           // max number of results to return
+}
+
+// SuggestQuery - a backend-neutral autocomplete query
+type SuggestQuery struct {
+	Dataset string // dataset to search, see `ParseDataset`
+	Prefix  string // normalized partial address, see `NormalizeAddressPrefix`
+	Fuzzy   bool   // match prefixes w. a levenshtein distance of 1
+	Limit   int    // max number of results to return
+}
+
+// ExportQuery - a backend-neutral walk over a dataset's addresses, addresses must match all set fields
+type ExportQuery struct {
+	Dataset  string        // dataset to export, see `ParseDataset`
+	IDPrefix string        // only addresses w. an `Address.Id` starting w. `IDPrefix`
+	Filter   *SearchFilter // optional constraints on the addresses exported
+}
+
+// SearchBackend - the storage && search engine behind the geocoder and management services
+type SearchBackend interface {
+
+	// IndexesReady - creates || checks the existence of the indexes required for the application, including
+	// those of the default dataset
+	IndexesReady(ctx context.Context) error
+
+	// CreateDataset - creates the indexes of a dataset if they don't exist
+	CreateDataset(ctx context.Context, dataset string) error
+
+	// DatasetExists - checks a dataset has been created
+	DatasetExists(ctx context.Context, dataset string) (bool, error)
+
+	// CreateVersion - creates an empty version of a dataset's addresses, versions are loaded w. `Upsert` && aren't
+	// served until promoted w. `PromoteVersion`
+	CreateVersion(ctx context.Context, dataset string) (int64, error)
+
+	// CountAddresses - the number of addresses in a version of a dataset
+	CountAddresses(ctx context.Context, dataset string, version int64) (int, error)
+
+	// PromoteVersion - atomically replaces the version of a dataset's addresses being served
+	PromoteVersion(ctx context.Context, dataset string, version int64) error
+
+	// HasPendingVersion - checks for a version of a dataset newer than the one being served that hasn't been promoted
+	// || dropped, i.e. a reload in progress (|| abandoned, see `DropVersion`)
+	HasPendingVersion(ctx context.Context, dataset string) (bool, error)
+
+	// DropStaleVersions - deletes every version of a dataset's addresses other than the one being served (including
+	// versions that are still loading), returns the number of versions deleted
+	DropStaleVersions(ctx context.Context, dataset string) (int, error)
+
+	// DropVersion - deletes a single version of a dataset's addresses that isn't being served, e.g. a reload that
+	// failed before it was promoted
+	DropVersion(ctx context.Context, dataset string, version int64) error
+
+	// IndexableBounds - the locations the backend can index, nil if it can index any valid location; addresses
+	// outside it are rejected at ingest (see `ValidateAddress`)
+	IndexableBounds() *pb.BoundingBox
+
+	// Upsert - inserts or replaces a batch of addresses in a version of a dataset
+	Upsert(ctx context.Context, dataset string, version int64, addresses []*pb.Address) error
+
+	// Delete - removes a batch of addresses from a dataset by `Address.Id`, returns the number of addresses removed
+	Delete(ctx context.Context, dataset string, ids []string) (int, error)
+
+	// Stats - the size, extent && index memory of the version of a dataset being served
+	Stats(ctx context.Context, dataset string) (*pb.DatasetStats, error)
+
+	// GetAddress - a single address by `Address.Id` from the version of a dataset being served
+	GetAddress(ctx context.Context, dataset, id string) (*pb.Address, error)
+
+	// Export - calls `fn` on each address of the version of a dataset being served that matches the query, in no
+	// particular order && w. the `Address.Id` sent at ingest; stops at the first error returned by `fn`. An address
+	// may be passed to `fn` more than once
+	Export(ctx context.Context, q *ExportQuery, fn func(*pb.Address) error) error
+
+	// SaveIngestJob - creates || replaces the progress of an ingest job
+	SaveIngestJob(ctx context.Context, job *pb.IngestJob) error
+
+	// GetIngestJob - the progress of an ingest job by `IngestJob.JobId`
+	GetIngestJob(ctx context.Context, id string) (*pb.IngestJob, error)
+
+	// SearchText - full text search on `composite_street_address`, results sorted best -> worst match
+	SearchText(ctx context.Context, q *TextQuery) ([]*pb.ScoredAddress, error)
+
+	// UpsertSegments - inserts or replaces a batch of street segments in a dataset
+	UpsertSegments(ctx context.Context, dataset string, segments []*pb.StreetSegment) error
+
+	// SearchSegments - segments on the query's street w. an address range containing the query's house number,
+	// results sorted best -> worst match
+	SearchSegments(ctx context.Context, q *SegmentQuery) ([]*ScoredSegment, error)
+
+	// UpsertBoundaries - inserts or replaces a batch of boundaries, geometries must be valid (see `ValidateBoundary`)
+	UpsertBoundaries(ctx context.Context, boundaries []*pb.Boundary) error
+
+	// SearchBoundaries - all boundaries containing a point, sorted by layer && id; geometries are omitted
+	SearchBoundaries(ctx context.Context, q *BoundaryQuery) ([]*pb.Boundary, error)
+
+	// Suggest - completions of a partial address, sorted best -> worst match
+	Suggest(ctx context.Context, q *SuggestQuery) ([]*pb.Suggestion, error)
+
+	// SearchRadius - geo search for addresses within a radius of a point, results sorted nearest -> farthest
+	// w. `DistanceMeters` set
+	SearchRadius(ctx context.Context, q *RadiusQuery) ([]*pb.ScoredAddress, error)
+}
+
+// MustSearchBackend - initialize a new search backend by name -> panic on err out; `r` is ignored for
+// backends that don't use redis, `snapshot` is optional && only used by the memory backend
+func MustSearchBackend(ctx context.Context, backend string, r *RedisClientOptions, snapshot *MemorySnapshotOptions) SearchBackend {
+	switch backend {
+	case SearchBackendRedis:
+		return NewRedisSearchBackend(MustRedisClient(ctx, r))
+	case SearchBackendMemory:
+		if (snapshot == nil) || (snapshot.Path == "") {
+			return NewMemorySearchBackend()
+		}
+		b, err := NewSnapshotMemorySearchBackend(ctx, snapshot)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err":      err,
+				"snapshot": snapshot.Path,
+			}).Panic("failed to load memory snapshot")
+		}
+		return b
+	}
+
+	log.WithFields(log.Fields{
+		"search_backend": backend,
+	}).Panic("unknown search backend")
+	return nil
+}
+
+// ParseDataset - validates a dataset name, an empty name refers to `DefaultDataset`
+func ParseDataset(dataset string) (string, error) {
+	if dataset == "" {
+		return DefaultDataset, nil
+	}
+	if !datasetNamePattern.MatchString(dataset) || datasetReservedNames[dataset] {
+		return "", ErrInvalidDataset
+	}
+	return dataset, nil
+}
+
+// datasetPrefix - the prefix of all keys && indexes of a dataset, e.g. `nyc:address:1`; none for the default
+func datasetPrefix(dataset string) string {
+	if (dataset == "") || (dataset == DefaultDataset) {
+		return ""
+	}
+	return dataset + ":"
+}
+
+// addressKey - the key an address is stored under, doubles as the `Id` returned in results
+func addressKey(dataset, id string) string {
+	return datasetPrefix(dataset) + "address:" + id
+}
+
+// ParseAddressID - the `Address.Id` sent at ingest from either itself || the `Id` returned in results
+func ParseAddressID(dataset, id string) string {
+	return strings.TrimPrefix(id, addressKey(dataset, ""))
+}
@@ -0,0 +1,39 @@
+package srv
+
+import (
+	// standard lib
+	"strings"
+	"testing"
+)
+
+func TestParseDataset(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want string
+		err  error
+	}{
+		{"", DefaultDataset, nil},
+		{"nj", "nj", nil},
+		{"nyc-2023_q4", "nyc-2023_q4", nil},
+		{"NJ", "", ErrInvalidDataset},
+		{"-nj", "", ErrInvalidDataset},
+		{"nj:address", "", ErrInvalidDataset},
+		{strings.Repeat("a", 65), "", ErrInvalidDataset},
+		{"address", "", ErrInvalidDataset}, // collides w. the default dataset's keys
+	}
+
+	for _, tt := range tests {
+		if got, err := ParseDataset(tt.in); (got != tt.want) || (err != tt.err) {
+			t.Errorf("ParseDataset(%q) = %q, %v, want %q, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestAddressKeyPrefix(t *testing.T) {
+	if got, want := addressKey(DefaultDataset, "1"), "address:1"; got != want {
+		t.Errorf("addressKey(default) = %q, want %q", got, want)
+	}
+	if got, want := addressKey("nj", "1"), "nj:address:1"; got != want {
+		t.Errorf("addressKey(nj) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package srv
+
+import (
+	// standard lib
+	"math"
+	"strconv"
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// searchFilterMaxRadiusMeters - upper bound on a filter's radius, larger areas should use a bounding box
+const searchFilterMaxRadiusMeters = 50000
+
+// SearchFilter - a backend-neutral set of constraints on results, all set fields must match
+type SearchFilter struct {
+	BoundingBox  *pb.BoundingBox // results must be within the box
+	Center       *pb.Point       // results must be within `RadiusMeters` of `Center`
+	RadiusMeters float64
+	PostalCodes  []string // results must match any of `PostalCodes`
+	Localities   []string // results must match any of `Localities`
+}
+
+// ParseGeocodeFilter - validates a request's filter && normalizes its TAG values w. the same rules applied at
+// ingest (e.g. `bklyn` -> `BROOKLYN`); returns nil for a nil || empty filter
+func ParseGeocodeFilter(f *pb.GeocodeFilter) (*SearchFilter, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	var sf = &SearchFilter{}
+
+	if bb := f.BoundingBox; bb != nil {
+		if !validBoundingBox(bb) {
+			return nil, ErrInvalidGeocodeFilter
+		}
+		sf.BoundingBox = bb
+	}
+
+	if f.Center != nil {
+		if !validPoint(f.Center) || (f.RadiusMeters <= 0) || (f.RadiusMeters > searchFilterMaxRadiusMeters) {
+			return nil, ErrInvalidGeocodeFilter
+		}
+		sf.Center, sf.RadiusMeters = f.Center, float64(f.RadiusMeters)
+	}
+
+	for _, pc := range f.PostalCodes {
+		if p := ParseStructuredAddress("", "", "", "", pc).PostalCode; p != "" {
+			sf.PostalCodes = append(sf.PostalCodes, p)
+		}
+	}
+
+	for _, l := range f.Localities {
+		if p := ParseStructuredAddress("", "", l, "", "").Locality; p != "" {
+			sf.Localities = append(sf.Localities, p)
+		}
+	}
+
+	if (sf.BoundingBox == nil) && (sf.Center == nil) && (len(sf.PostalCodes) == 0) && (len(sf.Localities) == 0) {
+		return nil, nil
+	}
+	return sf, nil
+}
+
+// validPoint - checks a point is set && within (-90, 90), (-180, 180)
+func validPoint(pt *pb.Point) bool {
+	return (pt != nil) && (math.Abs(float64(pt.Latitude)) <= 90) && (math.Abs(float64(pt.Longitude)) <= 180)
+}
+
+// validBoundingBox - checks both corners are valid points && min <= max
+func validBoundingBox(bb *pb.BoundingBox) bool {
+	return validPoint(bb.Min) && validPoint(bb.Max) &&
+		(bb.Min.Latitude <= bb.Max.Latitude) && (bb.Min.Longitude <= bb.Max.Longitude)
+}
+
+// ParseBoundingBox - parses a bounding box from `min_lat,min_lng,max_lat,max_lng`
+func ParseBoundingBox(s string) (*pb.BoundingBox, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return nil, ErrInvalidBoundingBox
+	}
+
+	var v [4]float32
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, ErrInvalidBoundingBox
+		}
+		v[i] = float32(f)
+	}
+
+	bb := &pb.BoundingBox{
+		Min: &pb.Point{Latitude: v[0], Longitude: v[1]},
+		Max: &pb.Point{Latitude: v[2], Longitude: v[3]},
+	}
+	if !validBoundingBox(bb) {
+		return nil, ErrInvalidBoundingBox
+	}
+	return bb, nil
+}
+
+// containsPoint - checks a point is within the box, edges included
+func containsPoint(bb *pb.BoundingBox, pt *pb.Point) bool {
+	return (pt.Latitude >= bb.Min.Latitude) && (pt.Latitude <= bb.Max.Latitude) &&
+		(pt.Longitude >= bb.Min.Longitude) && (pt.Longitude <= bb.Max.Longitude)
+}
+
+// Matches - checks an address against all set fields of the filter; both backends apply it to results, the clauses
+// used by RediSearch only narrow the candidates (e.g. the radius is widened, see `redisGeoRadiusClause`)
+func (f *SearchFilter) Matches(address *pb.Address) bool {
+	if f == nil {
+		return true
+	}
+
+	if (f.BoundingBox != nil) && !containsPoint(f.BoundingBox, address.Location) {
+		return false
+	}
+
+	if (f.Center != nil) && (HaversineDistance(f.Center, address.Location) > f.RadiusMeters) {
+		return false
+	}
+
+	return matchesAnyTag(f.PostalCodes, address.PostalCode) && matchesAnyTag(f.Localities, address.Locality)
+}
+
+// matchesAnyTag - an empty set of tags matches all values; case-insensitive, same as RediSearch TAG fields
+func matchesAnyTag(tags []string, v string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, t := range tags {
+		if strings.EqualFold(t, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package srv
+
+import (
+	// standard lib
+	"fmt"
+	"math"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+func TestSearchFilterMatches(t *testing.T) {
+	address := &pb.Address{
+		Location:   &pb.Point{Latitude: 40.6812, Longitude: -73.9479},
+		PostalCode: "11216",
+		Locality:   "BROOKLYN",
+	}
+
+	var tests = []struct {
+		name string
+		f    *SearchFilter
+		want bool
+	}{
+		{"nil filter", nil, true},
+		{"empty filter", &SearchFilter{}, true},
+		{
+			name: "inside bounding box",
+			f:    &SearchFilter{BoundingBox: &pb.BoundingBox{Min: &pb.Point{Latitude: 40.6, Longitude: -74.0}, Max: &pb.Point{Latitude: 40.7, Longitude: -73.9}}},
+			want: true,
+		},
+		{
+			name: "on bounding box edge",
+			f:    &SearchFilter{BoundingBox: &pb.BoundingBox{Min: &pb.Point{Latitude: 40.6812, Longitude: -74.0}, Max: &pb.Point{Latitude: 40.7, Longitude: -73.9}}},
+			want: true,
+		},
+		{
+			name: "outside bounding box",
+			f:    &SearchFilter{BoundingBox: &pb.BoundingBox{Min: &pb.Point{Latitude: 40.7, Longitude: -74.0}, Max: &pb.Point{Latitude: 40.8, Longitude: -73.9}}},
+			want: false,
+		},
+		{
+			name: "within radius",
+			f:    &SearchFilter{Center: &pb.Point{Latitude: 40.6812, Longitude: -73.9500}, RadiusMeters: 250},
+			want: true,
+		},
+		{
+			name: "outside radius",
+			f:    &SearchFilter{Center: &pb.Point{Latitude: 40.6812, Longitude: -73.9500}, RadiusMeters: 100},
+			want: false,
+		},
+		{"any postal code", &SearchFilter{PostalCodes: []string{"10005", "11216"}}, true},
+		{"other postal code", &SearchFilter{PostalCodes: []string{"10005"}}, false},
+		{"locality case-insensitive", &SearchFilter{Localities: []string{"brooklyn"}}, true},
+		{"other locality", &SearchFilter{Localities: []string{"QUEENS"}}, false},
+		{"all fields must match", &SearchFilter{PostalCodes: []string{"11216"}, Localities: []string{"QUEENS"}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.f.Matches(address); got != tt.want {
+			t.Errorf("%s: Matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseGeocodeFilter(t *testing.T) {
+	sf, err := ParseGeocodeFilter(&pb.GeocodeFilter{
+		PostalCodes: []string{" 11216 ", ""},
+		Localities:  []string{"bklyn", "the bronx"},
+	})
+	if err != nil {
+		t.Fatalf("ParseGeocodeFilter() = %v", err)
+	}
+	if (len(sf.PostalCodes) != 1) || (sf.PostalCodes[0] != "11216") {
+		t.Errorf("PostalCodes = %q, want [11216]", sf.PostalCodes)
+	}
+	if (len(sf.Localities) != 2) || (sf.Localities[0] != "BROOKLYN") || (sf.Localities[1] != "BRONX") {
+		t.Errorf("Localities = %q, want [BROOKLYN BRONX]", sf.Localities)
+	}
+
+	for _, f := range []*pb.GeocodeFilter{nil, {}, {PostalCodes: []string{" "}}} {
+		if sf, err := ParseGeocodeFilter(f); (sf != nil) || (err != nil) {
+			t.Errorf("ParseGeocodeFilter(%v) = %v, %v, want nil, nil", f, sf, err)
+		}
+	}
+
+	for _, f := range []*pb.GeocodeFilter{
+		{Center: &pb.Point{Latitude: 40.7, Longitude: -74.0}},
+		{Center: &pb.Point{Latitude: 40.7, Longitude: -74.0}, RadiusMeters: searchFilterMaxRadiusMeters + 1},
+		{Center: &pb.Point{Latitude: 91, Longitude: -74.0}, RadiusMeters: 100},
+		{BoundingBox: &pb.BoundingBox{Min: &pb.Point{Latitude: 40.8}, Max: &pb.Point{Latitude: 40.7}}},
+	} {
+		if _, err := ParseGeocodeFilter(f); err != ErrInvalidGeocodeFilter {
+			t.Errorf("ParseGeocodeFilter(%v) = %v, want %v", f, err, ErrInvalidGeocodeFilter)
+		}
+	}
+}
+
+func TestParseBoundingBox(t *testing.T) {
+	bb, err := ParseBoundingBox("40.6, -74.0, 40.7,-73.9")
+	if err != nil {
+		t.Fatalf("ParseBoundingBox() = %v", err)
+	}
+	if (bb.Min.Latitude != 40.6) || (bb.Min.Longitude != -74.0) || (bb.Max.Latitude != 40.7) || (bb.Max.Longitude != -73.9) {
+		t.Errorf("ParseBoundingBox() = %v", bb)
+	}
+
+	for _, s := range []string{"", "40.6,-74.0,40.7", "40.7,-74.0,40.6,-73.9", "a,b,c,d", "91,-74.0,92,-73.9"} {
+		if _, err := ParseBoundingBox(s); err != ErrInvalidBoundingBox {
+			t.Errorf("ParseBoundingBox(%q) = %v, want %v", s, err, ErrInvalidBoundingBox)
+		}
+	}
+}
+
+// TestRedisGeoRadiusClauseCoversRadius - the widened clause must cover the true radius along both axes, see
+// `redisGeoRadiusClause`
+func TestRedisGeoRadiusClauseCoversRadius(t *testing.T) {
+	for _, lat := range []float32{0, 40.7, -60} {
+		center := &pb.Point{Latitude: lat, Longitude: -74.0}
+
+		var cLat, cLng float64
+		var radius int
+		if _, err := fmt.Sscanf(redisGeoRadiusClause(center, 1000), "@location:[%f %f %d m]", &cLat, &cLng, &radius); err != nil {
+			t.Fatalf("redisGeoRadiusClause() = %v", err)
+		}
+		if want := 1000 / math.Cos(float64(lat)*math.Pi/180); float64(radius) < want {
+			t.Errorf("redisGeoRadiusClause() at lat %v = %dm, want at least %.1fm", lat, radius, want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package srv
+
+import (
+	// standard lib
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// SegmentQuery - a backend-neutral query for the street segments containing an address
+type SegmentQuery struct {
+	Dataset string         // dataset to search, see `ParseDataset`
+	Address *ParsedAddress // house number && street are required, locality && postal code are optional
+	Limit   int            // max number of results to return
+}
+
+// ScoredSegment - a street segment matching a `SegmentQuery`
+type ScoredSegment struct {
+	Segment          *pb.StreetSegment
+	NormedConfidence float32
+}
+
+// segmentKey - the key a street segment is stored under
+func segmentKey(dataset, id string) string {
+	return datasetPrefix(dataset) + "segment:" + id
+}
+
+// NormalizeStreetSegment - normalizes the street, locality && postal code of a segment w. the same rules
+// applied to addresses at ingest
+func NormalizeStreetSegment(seg *pb.StreetSegment) {
+	p := ParseStructuredAddress("", seg.Street, seg.Locality, "", seg.PostalCode)
+	seg.Street, seg.Locality, seg.PostalCode = p.FullStreet(), p.Locality, p.PostalCode
+}
+
+// addressRangeBounds - numeric (low, high) of an address range, ranges may be ordered either way along a segment
+func addressRangeBounds(r *pb.AddressRange) (float64, float64, bool) {
+	if r == nil {
+		return 0, 0, false
+	}
+	from, fok := HouseNumberValue(r.FromHouseNumber)
+	to, tok := HouseNumberValue(r.ToHouseNumber)
+	if !fok || !tok {
+		return 0, 0, false
+	}
+	if from > to {
+		return to, from, true
+	}
+	return from, to, true
+}
+
+// rangeContains - checks a range contains a house number; when `matchParity` is set the house number must
+// also be on the same (odd || even) side as the range
+func rangeContains(r *pb.AddressRange, v float64, matchParity bool) bool {
+	low, high, ok := addressRangeBounds(r)
+	if !ok || (v < low) || (v > high) {
+		return false
+	}
+	return !matchParity || (math.Mod(low, 2) == math.Mod(v, 2))
+}
+
+// SegmentContains - checks either side of a segment contains a house number
+func SegmentContains(seg *pb.StreetSegment, houseNumber string) bool {
+	v, ok := HouseNumberValue(houseNumber)
+	return ok && (rangeContains(seg.Left, v, false) || rangeContains(seg.Right, v, false))
+}
+
+// InterpolateStreetSegment - estimates the location of a house number along a segment; the side is chosen by
+// parity (e.g. odd numbers on the left) where possible, the position is linear in the house number along the
+// length of the segment
+func InterpolateStreetSegment(seg *pb.StreetSegment, houseNumber string) (*pb.Point, bool) {
+	v, ok := HouseNumberValue(houseNumber)
+	if !ok || len(seg.Geometry) == 0 {
+		return nil, false
+	}
+
+	var side *pb.AddressRange
+	for _, matchParity := range []bool{true, false} {
+		if rangeContains(seg.Left, v, matchParity) {
+			side = seg.Left
+		} else if rangeContains(seg.Right, v, matchParity) {
+			side = seg.Right
+		}
+		if side != nil {
+			break
+		}
+	}
+	if side == nil {
+		return nil, false
+	}
+
+	// fraction of the way from `from` -> `to`, a single-address range sits at the midpoint
+	from, _ := HouseNumberValue(side.FromHouseNumber)
+	to, _ := HouseNumberValue(side.ToHouseNumber)
+	var t = 0.5
+	if from != to {
+		t = (v - from) / (to - from)
+	}
+	return pointAlongLine(seg.Geometry, t), true
+}
+
+// pointAlongLine - the point a fraction `t` (in [0, 1]) of the way along a polyline
+func pointAlongLine(line []*pb.Point, t float64) *pb.Point {
+	var total float64
+	for i := 1; i < len(line); i++ {
+		total += HaversineDistance(line[i-1], line[i])
+	}
+
+	var remaining = total * t
+	for i := 1; i < len(line); i++ {
+		d := HaversineDistance(line[i-1], line[i])
+		if (remaining <= d) && (d > 0) {
+			f := float32(remaining / d)
+			return &pb.Point{
+				Latitude:  line[i-1].Latitude + f*(line[i].Latitude-line[i-1].Latitude),
+				Longitude: line[i-1].Longitude + f*(line[i].Longitude-line[i-1].Longitude),
+			}
+		}
+		remaining -= d
+	}
+	return &pb.Point{Latitude: line[len(line)-1].Latitude, Longitude: line[len(line)-1].Longitude}
+}
+
+// InterpolatedAddress - the result for a house number interpolated along a segment
+func InterpolatedAddress(dataset string, seg *pb.StreetSegment, p *ParsedAddress, pt *pb.Point) *pb.Address {
+	composite := &ParsedAddress{
+		HouseNumber: p.HouseNumber,
+		Street:      seg.Street,
+		Locality:    seg.Locality,
+		PostalCode:  seg.PostalCode,
+	}
+	return &pb.Address{
+		Id:                     segmentKey(dataset, seg.Id),
+		CompositeStreetAddress: composite.String(),
+		Location:               pt,
+		HouseNumber:            p.HouseNumber,
+		Street:                 seg.Street,
+		Locality:               seg.Locality,
+		PostalCode:             seg.PostalCode,
+	}
+}
+
+// formatSegmentGeometry - stores a polyline as `lat lng;lat lng;...`
+func formatSegmentGeometry(line []*pb.Point) string {
+	pts := make([]string, len(line))
+	for i, pt := range line {
+		pts[i] = fmt.Sprintf("%.6f %.6f", pt.Latitude, pt.Longitude)
+	}
+	return strings.Join(pts, ";")
+}
+
+// ParseSegmentGeometry - parses a polyline stored as `lat lng;lat lng;...`, invalid points are skipped
+func ParseSegmentGeometry(s string) []*pb.Point {
+	var line []*pb.Point
+	for _, pair := range strings.Split(s, ";") {
+		coords := strings.Fields(pair)
+		if len(coords) != 2 {
+			continue
+		}
+		lat, xerr := strconv.ParseFloat(coords[0], 32)
+		lng, yerr := strconv.ParseFloat(coords[1], 32)
+		if (xerr != nil) || (yerr != nil) {
+			continue
+		}
+		line = append(line, &pb.Point{Latitude: float32(lat), Longitude: float32(lng)})
+	}
+	return line
+}
@@ -0,0 +1,135 @@
+package srv
+
+import (
+	// standard lib
+	"context"
+	"math"
+	"testing"
+
+	// internal
+	pb "github.com/dmw2151/geocoder/geocoder-svc/proto"
+)
+
+// testSegment - a straight segment running due east w. odd numbers on the left && even numbers on the right
+func testSegment() *pb.StreetSegment {
+	return &pb.StreetSegment{
+		Id:       "macon-1",
+		Street:   "MACON ST",
+		Locality: "BROOKLYN",
+		Geometry: []*pb.Point{{Latitude: 40.68, Longitude: -73.95}, {Latitude: 40.68, Longitude: -73.94}},
+		Left:     &pb.AddressRange{FromHouseNumber: "1", ToHouseNumber: "99"},
+		Right:    &pb.AddressRange{FromHouseNumber: "2", ToHouseNumber: "100"},
+	}
+}
+
+func TestSegmentContains(t *testing.T) {
+	seg := testSegment()
+	for _, tt := range []struct {
+		houseNumber string
+		want        bool
+	}{
+		{"1", true}, {"50", true}, {"100", true}, {"51A", true}, {"101", false}, {"0", false}, {"", false},
+	} {
+		if got := SegmentContains(seg, tt.houseNumber); got != tt.want {
+			t.Errorf("SegmentContains(%q) = %v, want %v", tt.houseNumber, got, tt.want)
+		}
+	}
+
+	// ranges may run in either direction
+	seg.Left = &pb.AddressRange{FromHouseNumber: "99", ToHouseNumber: "1"}
+	if !SegmentContains(seg, "51") {
+		t.Errorf("SegmentContains(51) on a descending range = false, want true")
+	}
+}
+
+func TestInterpolateStreetSegment(t *testing.T) {
+	seg := testSegment()
+
+	var tests = []struct {
+		houseNumber string
+		wantLng     float64
+	}{
+		{"1", -73.95},
+		{"99", -73.94},
+		{"50", -73.945},
+		{"2", -73.95},
+		{"100", -73.94},
+	}
+	for _, tt := range tests {
+		pt, ok := InterpolateStreetSegment(seg, tt.houseNumber)
+		if !ok {
+			t.Fatalf("InterpolateStreetSegment(%q) = not ok", tt.houseNumber)
+		}
+		if (math.Abs(float64(pt.Longitude)-tt.wantLng) > 1e-4) || (math.Abs(float64(pt.Latitude)-40.68) > 1e-4) {
+			t.Errorf("InterpolateStreetSegment(%q) = (%v, %v), want (40.68, %v)", tt.houseNumber, pt.Latitude, pt.Longitude, tt.wantLng)
+		}
+	}
+
+	if _, ok := InterpolateStreetSegment(seg, "150"); ok {
+		t.Errorf("InterpolateStreetSegment(150) = ok, want outside the segment's ranges")
+	}
+
+	// a single-address range sits at the midpoint
+	seg.Left = &pb.AddressRange{FromHouseNumber: "7", ToHouseNumber: "7"}
+	if pt, _ := InterpolateStreetSegment(seg, "7"); math.Abs(float64(pt.Longitude)+73.945) > 1e-4 {
+		t.Errorf("InterpolateStreetSegment(7) on a single-address range = %v, want the midpoint", pt)
+	}
+}
+
+func TestInterpolateStreetSegmentHyphenated(t *testing.T) {
+	seg := testSegment()
+	seg.Left = &pb.AddressRange{FromHouseNumber: "107-01", ToHouseNumber: "107-99"}
+	seg.Right = &pb.AddressRange{FromHouseNumber: "107-02", ToHouseNumber: "107-100"}
+
+	pt, ok := InterpolateStreetSegment(seg, "107-50")
+	if !ok {
+		t.Fatalf("InterpolateStreetSegment(107-50) = not ok")
+	}
+	if math.Abs(float64(pt.Longitude)+73.945) > 1e-4 {
+		t.Errorf("InterpolateStreetSegment(107-50) = %v, want the midpoint", pt)
+	}
+}
+
+func TestPointAlongLine(t *testing.T) {
+	// an L-shaped line, both legs of (nearly) equal length
+	line := []*pb.Point{{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 0.01}, {Latitude: 0.01, Longitude: 0.01}}
+
+	for _, tt := range []struct {
+		t        float64
+		lat, lng float64
+	}{
+		{0, 0, 0}, {0.25, 0, 0.005}, {0.5, 0, 0.01}, {0.75, 0.005, 0.01}, {1, 0.01, 0.01},
+	} {
+		pt := pointAlongLine(line, tt.t)
+		if (math.Abs(float64(pt.Latitude)-tt.lat) > 1e-4) || (math.Abs(float64(pt.Longitude)-tt.lng) > 1e-4) {
+			t.Errorf("pointAlongLine(%v) = (%v, %v), want (%v, %v)", tt.t, pt.Latitude, pt.Longitude, tt.lat, tt.lng)
+		}
+	}
+}
+
+func TestMemorySearchSegments(t *testing.T) {
+	b := NewMemorySearchBackend()
+	ctx := context.Background()
+
+	seg := testSegment()
+	seg.Street = "Macon Street"
+	NormalizeStreetSegment(seg)
+	if err := b.UpsertSegments(ctx, DefaultDataset, []*pb.StreetSegment{seg}); err != nil {
+		t.Fatalf("UpsertSegments() = %v", err)
+	}
+
+	results, err := b.SearchSegments(ctx, &SegmentQuery{Address: ParseAddress("51 Macon St, Brooklyn"), Limit: 5})
+	if (err != nil) || (len(results) != 1) {
+		t.Fatalf("SearchSegments(51 MACON ST) = %v, %v, want 1 segment", results, err)
+	}
+
+	for _, q := range []string{"151 Macon St, Brooklyn", "51 Macon St, Queens", "51 Halsey St, Brooklyn"} {
+		if results, _ := b.SearchSegments(ctx, &SegmentQuery{Address: ParseAddress(q), Limit: 5}); len(results) != 0 {
+			t.Errorf("SearchSegments(%q) = %v, want none", q, results)
+		}
+	}
+
+	if _, err := b.SearchSegments(ctx, &SegmentQuery{Address: ParseAddress("Macon St"), Limit: 5}); err == nil {
+		t.Errorf("SearchSegments(no house number) = nil, want an error")
+	}
+}
@@ -28,8 +28,9 @@ const (
 type Method int32
 
 const (
-	Method_FWD_FUZZY   Method = 0
-	Method_REV_NEAREST Method = 1
+	Method_FWD_FUZZY        Method = 0
+	Method_REV_NEAREST      Method = 1
+	Method_FWD_INTERPOLATED Method = 2 // exact address match, falls back to interpolating along street segments
 )
 
 // Enum value maps for Method.
@@ -37,10 +38,12 @@ var (
 	Method_name = map[int32]string{
 		0: "FWD_FUZZY",
 		1: "REV_NEAREST",
+		2: "FWD_INTERPOLATED",
 	}
 	Method_value = map[string]int32{
-		"FWD_FUZZY":   0,
-		"REV_NEAREST": 1,
+		"FWD_FUZZY":        0,
+		"REV_NEAREST":      1,
+		"FWD_INTERPOLATED": 2,
 	}
 )
 
@@ -71,6 +74,53 @@ func (Method) EnumDescriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{0}
 }
 
+// LocationType defines how the location of a result was determined
+type LocationType int32
+
+const (
+	LocationType_ROOFTOP      LocationType = 0 // location of an address point
+	LocationType_INTERPOLATED LocationType = 1 // location interpolated from the address range of a street segment
+)
+
+// Enum value maps for LocationType.
+var (
+	LocationType_name = map[int32]string{
+		0: "ROOFTOP",
+		1: "INTERPOLATED",
+	}
+	LocationType_value = map[string]int32{
+		"ROOFTOP":      0,
+		"INTERPOLATED": 1,
+	}
+)
+
+func (x LocationType) Enum() *LocationType {
+	p := new(LocationType)
+	*p = x
+	return p
+}
+
+func (x LocationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_geocoder_proto_enumTypes[1].Descriptor()
+}
+
+func (LocationType) Type() protoreflect.EnumType {
+	return &file_proto_geocoder_proto_enumTypes[1]
+}
+
+func (x LocationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocationType.Descriptor instead.
+func (LocationType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{1}
+}
+
 // BatchGeocodeStatus -
 type BatchGeocodeStatus int32
 
@@ -81,6 +131,8 @@ const (
 	BatchGeocodeStatus_IN_QUEUE         BatchGeocodeStatus = 3
 	BatchGeocodeStatus_SUCCESS          BatchGeocodeStatus = 4
 	BatchGeocodeStatus_FAILED           BatchGeocodeStatus = 5
+	BatchGeocodeStatus_RETRYING         BatchGeocodeStatus = 6 // failed, waiting to be retried after a backoff
+	BatchGeocodeStatus_CANCELED         BatchGeocodeStatus = 7 // canceled before it finished, no results are saved
 )
 
 // Enum value maps for BatchGeocodeStatus.
@@ -92,6 +144,8 @@ var (
 		3: "IN_QUEUE",
 		4: "SUCCESS",
 		5: "FAILED",
+		6: "RETRYING",
+		7: "CANCELED",
 	}
 	BatchGeocodeStatus_value = map[string]int32{
 		"UNDEFINED_STATUS": 0,
@@ -100,6 +154,8 @@ var (
 		"IN_QUEUE":         3,
 		"SUCCESS":          4,
 		"FAILED":           5,
+		"RETRYING":         6,
+		"CANCELED":         7,
 	}
 )
 
@@ -114,11 +170,11 @@ func (x BatchGeocodeStatus) String() string {
 }
 
 func (BatchGeocodeStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_geocoder_proto_enumTypes[1].Descriptor()
+	return file_proto_geocoder_proto_enumTypes[2].Descriptor()
 }
 
 func (BatchGeocodeStatus) Type() protoreflect.EnumType {
-	return &file_proto_geocoder_proto_enumTypes[1]
+	return &file_proto_geocoder_proto_enumTypes[2]
 }
 
 func (x BatchGeocodeStatus) Number() protoreflect.EnumNumber {
@@ -127,7 +183,7 @@ func (x BatchGeocodeStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BatchGeocodeStatus.Descriptor instead.
 func (BatchGeocodeStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_geocoder_proto_rawDescGZIP(), []int{1}
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{2}
 }
 
 // Point represents latitude-longitude pairs
@@ -186,7 +242,9 @@ func (x *Point) GetLongitude() float32 {
 	return 0
 }
 
-// Address represents a composite address object with both a location and a full street address
+// Address represents a composite address object with both a location and a full street address, the
+// components of the address (house_number, street, ...) are optional on ingest and parsed from
+// `composite_street_address` when not set
 type Address struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -195,6 +253,14 @@ type Address struct {
 	Id                     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	CompositeStreetAddress string `protobuf:"bytes,2,opt,name=composite_street_address,json=compositeStreetAddress,proto3" json:"composite_street_address,omitempty"`
 	Location               *Point `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	HouseNumber            string `protobuf:"bytes,4,opt,name=house_number,json=houseNumber,proto3" json:"house_number,omitempty"`
+	Street                 string `protobuf:"bytes,5,opt,name=street,proto3" json:"street,omitempty"`     // street name w. suffix, e.g. `ATLANTIC AVE`
+	Locality               string `protobuf:"bytes,6,opt,name=locality,proto3" json:"locality,omitempty"` // borough or city
+	PostalCode             string `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Region                 string `protobuf:"bytes,8,opt,name=region,proto3" json:"region,omitempty"`
+	Dataset                string `protobuf:"bytes,9,opt,name=dataset,proto3" json:"dataset,omitempty"`               // ingest only, see `GeocodeRequest.dataset`
+	Version                int64  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`             // ingest only, see `CreateDatasetVersion`; writes to the version being served if unset
+	DryRun                 bool   `protobuf:"varint,11,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // ingest only, validate the stream w.o. writing; read from the first address of a stream
 }
 
 func (x *Address) Reset() {
@@ -250,6 +316,62 @@ func (x *Address) GetLocation() *Point {
 	return nil
 }
 
+func (x *Address) GetHouseNumber() string {
+	if x != nil {
+		return x.HouseNumber
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetLocality() string {
+	if x != nil {
+		return x.Locality
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *Address) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Address) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 // ScoredAddress attaches a confidence score to an `Address` in order to compare the viablity from a set
 // of multiple responses
 type ScoredAddress struct {
@@ -257,8 +379,10 @@ type ScoredAddress struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address          *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	NormedConfidence float32  `protobuf:"fixed32,2,opt,name=normed_confidence,json=normedConfidence,proto3" json:"normed_confidence,omitempty"`
+	Address          *Address     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	NormedConfidence float32      `protobuf:"fixed32,2,opt,name=normed_confidence,json=normedConfidence,proto3" json:"normed_confidence,omitempty"`
+	DistanceMeters   float32      `protobuf:"fixed32,3,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"` // distance from the query point, only set for reverse geocoding
+	LocationType     LocationType `protobuf:"varint,4,opt,name=location_type,json=locationType,proto3,enum=geocoder.LocationType" json:"location_type,omitempty"`
 }
 
 func (x *ScoredAddress) Reset() {
@@ -307,20 +431,33 @@ func (x *ScoredAddress) GetNormedConfidence() float32 {
 	return 0
 }
 
-// Query -
-type Query struct {
+func (x *ScoredAddress) GetDistanceMeters() float32 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *ScoredAddress) GetLocationType() LocationType {
+	if x != nil {
+		return x.LocationType
+	}
+	return LocationType_ROOFTOP
+}
+
+// AddressRange represents the house numbers on one side of a street segment, `from_house_number` is at the
+// first point of the segment's geometry and `to_house_number` at the last
+type AddressRange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Query:
-	//	*Query_AddressQuery
-	//	*Query_PointQuery
-	Query isQuery_Query `protobuf_oneof:"query"`
+	FromHouseNumber string `protobuf:"bytes,1,opt,name=from_house_number,json=fromHouseNumber,proto3" json:"from_house_number,omitempty"`
+	ToHouseNumber   string `protobuf:"bytes,2,opt,name=to_house_number,json=toHouseNumber,proto3" json:"to_house_number,omitempty"`
 }
 
-func (x *Query) Reset() {
-	*x = Query{}
+func (x *AddressRange) Reset() {
+	*x = AddressRange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -328,13 +465,13 @@ func (x *Query) Reset() {
 	}
 }
 
-func (x *Query) String() string {
+func (x *AddressRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Query) ProtoMessage() {}
+func (*AddressRange) ProtoMessage() {}
 
-func (x *Query) ProtoReflect() protoreflect.Message {
+func (x *AddressRange) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -346,61 +483,44 @@ func (x *Query) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Query.ProtoReflect.Descriptor instead.
-func (*Query) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddressRange.ProtoReflect.Descriptor instead.
+func (*AddressRange) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{3}
 }
 
-func (m *Query) GetQuery() isQuery_Query {
-	if m != nil {
-		return m.Query
-	}
-	return nil
-}
-
-func (x *Query) GetAddressQuery() string {
-	if x, ok := x.GetQuery().(*Query_AddressQuery); ok {
-		return x.AddressQuery
+func (x *AddressRange) GetFromHouseNumber() string {
+	if x != nil {
+		return x.FromHouseNumber
 	}
 	return ""
 }
 
-func (x *Query) GetPointQuery() *Point {
-	if x, ok := x.GetQuery().(*Query_PointQuery); ok {
-		return x.PointQuery
+func (x *AddressRange) GetToHouseNumber() string {
+	if x != nil {
+		return x.ToHouseNumber
 	}
-	return nil
-}
-
-type isQuery_Query interface {
-	isQuery_Query()
-}
-
-type Query_AddressQuery struct {
-	AddressQuery string `protobuf:"bytes,1,opt,name=address_query,json=addressQuery,proto3,oneof"`
-}
-
-type Query_PointQuery struct {
-	PointQuery *Point `protobuf:"bytes,2,opt,name=point_query,json=pointQuery,proto3,oneof"`
+	return ""
 }
 
-func (*Query_AddressQuery) isQuery_Query() {}
-
-func (*Query_PointQuery) isQuery_Query() {}
-
-// GeocodeRequest represents a request to Geocoder.Geocode
-type GeocodeRequest struct {
+// StreetSegment represents a section of a street (e.g. between two intersections) w. the address ranges
+// on its left and right sides
+type StreetSegment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query      *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Method     Method `protobuf:"varint,2,opt,name=method,proto3,enum=geocoder.Method" json:"method,omitempty"`
-	MaxResults uint32 `protobuf:"varint,3,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	Id         string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Street     string        `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	Locality   string        `protobuf:"bytes,3,opt,name=locality,proto3" json:"locality,omitempty"`
+	PostalCode string        `protobuf:"bytes,4,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Geometry   []*Point      `protobuf:"bytes,5,rep,name=geometry,proto3" json:"geometry,omitempty"` // polyline, ordered from -> to
+	Left       *AddressRange `protobuf:"bytes,6,opt,name=left,proto3" json:"left,omitempty"`
+	Right      *AddressRange `protobuf:"bytes,7,opt,name=right,proto3" json:"right,omitempty"`
+	Dataset    string        `protobuf:"bytes,8,opt,name=dataset,proto3" json:"dataset,omitempty"` // ingest only, see `GeocodeRequest.dataset`
 }
 
-func (x *GeocodeRequest) Reset() {
-	*x = GeocodeRequest{}
+func (x *StreetSegment) Reset() {
+	*x = StreetSegment{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -408,13 +528,13 @@ func (x *GeocodeRequest) Reset() {
 	}
 }
 
-func (x *GeocodeRequest) String() string {
+func (x *StreetSegment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GeocodeRequest) ProtoMessage() {}
+func (*StreetSegment) ProtoMessage() {}
 
-func (x *GeocodeRequest) ProtoReflect() protoreflect.Message {
+func (x *StreetSegment) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -426,45 +546,83 @@ func (x *GeocodeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GeocodeRequest.ProtoReflect.Descriptor instead.
-func (*GeocodeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreetSegment.ProtoReflect.Descriptor instead.
+func (*StreetSegment) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GeocodeRequest) GetQuery() *Query {
+func (x *StreetSegment) GetId() string {
 	if x != nil {
-		return x.Query
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StreetSegment) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *StreetSegment) GetLocality() string {
+	if x != nil {
+		return x.Locality
+	}
+	return ""
+}
+
+func (x *StreetSegment) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *StreetSegment) GetGeometry() []*Point {
+	if x != nil {
+		return x.Geometry
 	}
 	return nil
 }
 
-func (x *GeocodeRequest) GetMethod() Method {
+func (x *StreetSegment) GetLeft() *AddressRange {
 	if x != nil {
-		return x.Method
+		return x.Left
 	}
-	return Method_FWD_FUZZY
+	return nil
 }
 
-func (x *GeocodeRequest) GetMaxResults() uint32 {
+func (x *StreetSegment) GetRight() *AddressRange {
 	if x != nil {
-		return x.MaxResults
+		return x.Right
 	}
-	return 0
+	return nil
 }
 
-// GeocodeResponse represents a response from Geocoder.Geocode
-type GeocodeResponse struct {
+func (x *StreetSegment) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// StructuredAddress represents an address query that is already split into its components, all fields
+// are optional but at least one must be set
+type StructuredAddress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query      *Query           `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Result     []*ScoredAddress `protobuf:"bytes,2,rep,name=result,proto3" json:"result,omitempty"`
-	NumResults uint32           `protobuf:"varint,3,opt,name=num_results,json=numResults,proto3" json:"num_results,omitempty"`
+	HouseNumber string `protobuf:"bytes,1,opt,name=house_number,json=houseNumber,proto3" json:"house_number,omitempty"`
+	Street      string `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	Locality    string `protobuf:"bytes,3,opt,name=locality,proto3" json:"locality,omitempty"` // borough or city
+	PostalCode  string `protobuf:"bytes,4,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Region      string `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
 }
 
-func (x *GeocodeResponse) Reset() {
-	*x = GeocodeResponse{}
+func (x *StructuredAddress) Reset() {
+	*x = StructuredAddress{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -472,13 +630,13 @@ func (x *GeocodeResponse) Reset() {
 	}
 }
 
-func (x *GeocodeResponse) String() string {
+func (x *StructuredAddress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GeocodeResponse) ProtoMessage() {}
+func (*StructuredAddress) ProtoMessage() {}
 
-func (x *GeocodeResponse) ProtoReflect() protoreflect.Message {
+func (x *StructuredAddress) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -490,45 +648,61 @@ func (x *GeocodeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GeocodeResponse.ProtoReflect.Descriptor instead.
-func (*GeocodeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StructuredAddress.ProtoReflect.Descriptor instead.
+func (*StructuredAddress) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GeocodeResponse) GetQuery() *Query {
+func (x *StructuredAddress) GetHouseNumber() string {
 	if x != nil {
-		return x.Query
+		return x.HouseNumber
 	}
-	return nil
+	return ""
 }
 
-func (x *GeocodeResponse) GetResult() []*ScoredAddress {
+func (x *StructuredAddress) GetStreet() string {
 	if x != nil {
-		return x.Result
+		return x.Street
 	}
-	return nil
+	return ""
 }
 
-func (x *GeocodeResponse) GetNumResults() uint32 {
+func (x *StructuredAddress) GetLocality() string {
 	if x != nil {
-		return x.NumResults
+		return x.Locality
 	}
-	return 0
+	return ""
 }
 
-// CreateBatchRequest - represents a request to Batch.CreateBatch
-type CreateBatchRequest struct {
+func (x *StructuredAddress) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *StructuredAddress) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+// Query -
+type Query struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Method    Method   `protobuf:"varint,1,opt,name=method,proto3,enum=geocoder.Method" json:"method,omitempty"`
-	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
-	Points    []*Point `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	// Types that are assignable to Query:
+	//	*Query_AddressQuery
+	//	*Query_PointQuery
+	//	*Query_StructuredQuery
+	Query isQuery_Query `protobuf_oneof:"query"`
 }
 
-func (x *CreateBatchRequest) Reset() {
-	*x = CreateBatchRequest{}
+func (x *Query) Reset() {
+	*x = Query{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -536,13 +710,13 @@ func (x *CreateBatchRequest) Reset() {
 	}
 }
 
-func (x *CreateBatchRequest) String() string {
+func (x *Query) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBatchRequest) ProtoMessage() {}
+func (*Query) ProtoMessage() {}
 
-func (x *CreateBatchRequest) ProtoReflect() protoreflect.Message {
+func (x *Query) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -554,43 +728,73 @@ func (x *CreateBatchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBatchRequest.ProtoReflect.Descriptor instead.
-func (*CreateBatchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Query.ProtoReflect.Descriptor instead.
+func (*Query) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateBatchRequest) GetMethod() Method {
-	if x != nil {
-		return x.Method
+func (m *Query) GetQuery() isQuery_Query {
+	if m != nil {
+		return m.Query
 	}
-	return Method_FWD_FUZZY
+	return nil
 }
 
-func (x *CreateBatchRequest) GetAddresses() []string {
-	if x != nil {
-		return x.Addresses
+func (x *Query) GetAddressQuery() string {
+	if x, ok := x.GetQuery().(*Query_AddressQuery); ok {
+		return x.AddressQuery
+	}
+	return ""
+}
+
+func (x *Query) GetPointQuery() *Point {
+	if x, ok := x.GetQuery().(*Query_PointQuery); ok {
+		return x.PointQuery
 	}
 	return nil
 }
 
-func (x *CreateBatchRequest) GetPoints() []*Point {
-	if x != nil {
-		return x.Points
+func (x *Query) GetStructuredQuery() *StructuredAddress {
+	if x, ok := x.GetQuery().(*Query_StructuredQuery); ok {
+		return x.StructuredQuery
 	}
 	return nil
 }
 
-// StatusBatchRequest -
-type BatchStatusRequest struct {
+type isQuery_Query interface {
+	isQuery_Query()
+}
+
+type Query_AddressQuery struct {
+	AddressQuery string `protobuf:"bytes,1,opt,name=address_query,json=addressQuery,proto3,oneof"`
+}
+
+type Query_PointQuery struct {
+	PointQuery *Point `protobuf:"bytes,2,opt,name=point_query,json=pointQuery,proto3,oneof"`
+}
+
+type Query_StructuredQuery struct {
+	StructuredQuery *StructuredAddress `protobuf:"bytes,3,opt,name=structured_query,json=structuredQuery,proto3,oneof"`
+}
+
+func (*Query_AddressQuery) isQuery_Query() {}
+
+func (*Query_PointQuery) isQuery_Query() {}
+
+func (*Query_StructuredQuery) isQuery_Query() {}
+
+// BoundingBox represents a rectangle by its south-west (min) and north-east (max) corners
+type BoundingBox struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // uuid
+	Min *Point `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max *Point `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
 }
 
-func (x *BatchStatusRequest) Reset() {
-	*x = BatchStatusRequest{}
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -598,13 +802,13 @@ func (x *BatchStatusRequest) Reset() {
 	}
 }
 
-func (x *BatchStatusRequest) String() string {
+func (x *BoundingBox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchStatusRequest) ProtoMessage() {}
+func (*BoundingBox) ProtoMessage() {}
 
-func (x *BatchStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -616,32 +820,40 @@ func (x *BatchStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchStatusRequest.ProtoReflect.Descriptor instead.
-func (*BatchStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BatchStatusRequest) GetId() string {
+func (x *BoundingBox) GetMin() *Point {
 	if x != nil {
-		return x.Id
+		return x.Min
 	}
-	return ""
+	return nil
 }
 
-// BatchStatusResponse -
-type BatchStatusResponse struct {
+func (x *BoundingBox) GetMax() *Point {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+// GeocodeFilter represents optional constraints on the results of a GeocodeRequest, all set filters must match
+type GeocodeFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // uuid
-	Status       BatchGeocodeStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=geocoder.BatchGeocodeStatus" json:"status,omitempty"`
-	DownloadPath string                 `protobuf:"bytes,3,opt,name=download_path,json=downloadPath,proto3" json:"download_path,omitempty"`
-	UpdateTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	BoundingBox  *BoundingBox `protobuf:"bytes,1,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	Center       *Point       `protobuf:"bytes,2,opt,name=center,proto3" json:"center,omitempty"` // results must be within `radius_meters` of `center`
+	RadiusMeters float32      `protobuf:"fixed32,3,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	PostalCodes  []string     `protobuf:"bytes,4,rep,name=postal_codes,json=postalCodes,proto3" json:"postal_codes,omitempty"` // results must match any of `postal_codes`
+	Localities   []string     `protobuf:"bytes,5,rep,name=localities,proto3" json:"localities,omitempty"`                      // results must match any of `localities`
 }
 
-func (x *BatchStatusResponse) Reset() {
-	*x = BatchStatusResponse{}
+func (x *GeocodeFilter) Reset() {
+	*x = GeocodeFilter{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -649,13 +861,13 @@ func (x *BatchStatusResponse) Reset() {
 	}
 }
 
-func (x *BatchStatusResponse) String() string {
+func (x *GeocodeFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchStatusResponse) ProtoMessage() {}
+func (*GeocodeFilter) ProtoMessage() {}
 
-func (x *BatchStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *GeocodeFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,51 +879,61 @@ func (x *BatchStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchStatusResponse.ProtoReflect.Descriptor instead.
-func (*BatchStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GeocodeFilter.ProtoReflect.Descriptor instead.
+func (*GeocodeFilter) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *BatchStatusResponse) GetId() string {
+func (x *GeocodeFilter) GetBoundingBox() *BoundingBox {
 	if x != nil {
-		return x.Id
+		return x.BoundingBox
 	}
-	return ""
+	return nil
 }
 
-func (x *BatchStatusResponse) GetStatus() BatchGeocodeStatus {
+func (x *GeocodeFilter) GetCenter() *Point {
 	if x != nil {
-		return x.Status
+		return x.Center
 	}
-	return BatchGeocodeStatus_UNDEFINED_STATUS
+	return nil
 }
 
-func (x *BatchStatusResponse) GetDownloadPath() string {
+func (x *GeocodeFilter) GetRadiusMeters() float32 {
 	if x != nil {
-		return x.DownloadPath
+		return x.RadiusMeters
 	}
-	return ""
+	return 0
 }
 
-func (x *BatchStatusResponse) GetUpdateTime() *timestamppb.Timestamp {
+func (x *GeocodeFilter) GetPostalCodes() []string {
 	if x != nil {
-		return x.UpdateTime
+		return x.PostalCodes
 	}
 	return nil
 }
 
-// ResolvedAddress -
-type ResolvedAddress struct {
+func (x *GeocodeFilter) GetLocalities() []string {
+	if x != nil {
+		return x.Localities
+	}
+	return nil
+}
+
+// Boundary represents an administrative area, e.g. a borough, community district, census tract or ZIP code
+// tabulation area
+type Boundary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query  *Query   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Result *Address `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // unique within a layer
+	Layer   string `protobuf:"bytes,2,opt,name=layer,proto3" json:"layer,omitempty"` // e.g. `borough`, `community_district`, `census_tract`, `zcta`
+	Name    string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Geojson string `protobuf:"bytes,4,opt,name=geojson,proto3" json:"geojson,omitempty"` // GeoJSON Polygon or MultiPolygon; omitted from `BoundariesResponse`
 }
 
-func (x *ResolvedAddress) Reset() {
-	*x = ResolvedAddress{}
+func (x *Boundary) Reset() {
+	*x = Boundary{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -719,13 +941,13 @@ func (x *ResolvedAddress) Reset() {
 	}
 }
 
-func (x *ResolvedAddress) String() string {
+func (x *Boundary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolvedAddress) ProtoMessage() {}
+func (*Boundary) ProtoMessage() {}
 
-func (x *ResolvedAddress) ProtoReflect() protoreflect.Message {
+func (x *Boundary) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -737,36 +959,55 @@ func (x *ResolvedAddress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolvedAddress.ProtoReflect.Descriptor instead.
-func (*ResolvedAddress) Descriptor() ([]byte, []int) {
+// Deprecated: Use Boundary.ProtoReflect.Descriptor instead.
+func (*Boundary) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ResolvedAddress) GetQuery() *Query {
+func (x *Boundary) GetId() string {
 	if x != nil {
-		return x.Query
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *ResolvedAddress) GetResult() *Address {
+func (x *Boundary) GetLayer() string {
 	if x != nil {
-		return x.Result
+		return x.Layer
 	}
-	return nil
+	return ""
 }
 
-// ResolvedBatch -
-type ResolvedBatch struct {
+func (x *Boundary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Boundary) GetGeojson() string {
+	if x != nil {
+		return x.Geojson
+	}
+	return ""
+}
+
+// GeocodeRequest represents a request to Geocoder.Geocode
+type GeocodeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Batch []*ResolvedAddress `protobuf:"bytes,1,rep,name=batch,proto3" json:"batch,omitempty"`
+	Query             *Query         `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Method            Method         `protobuf:"varint,2,opt,name=method,proto3,enum=geocoder.Method" json:"method,omitempty"`
+	MaxResults        uint32         `protobuf:"varint,3,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	Filter            *GeocodeFilter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	MaxDistanceMeters float32        `protobuf:"fixed32,5,opt,name=max_distance_meters,json=maxDistanceMeters,proto3" json:"max_distance_meters,omitempty"` // reverse geocoding only, defaults to 64m if unset
+	Dataset           string         `protobuf:"bytes,6,opt,name=dataset,proto3" json:"dataset,omitempty"`                                                  // e.g. `nyc`, defaults to `default` if unset
 }
 
-func (x *ResolvedBatch) Reset() {
-	*x = ResolvedBatch{}
+func (x *GeocodeRequest) Reset() {
+	*x = GeocodeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -774,13 +1015,13 @@ func (x *ResolvedBatch) Reset() {
 	}
 }
 
-func (x *ResolvedBatch) String() string {
+func (x *GeocodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolvedBatch) ProtoMessage() {}
+func (*GeocodeRequest) ProtoMessage() {}
 
-func (x *ResolvedBatch) ProtoReflect() protoreflect.Message {
+func (x *GeocodeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -792,30 +1033,66 @@ func (x *ResolvedBatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolvedBatch.ProtoReflect.Descriptor instead.
-func (*ResolvedBatch) Descriptor() ([]byte, []int) {
+// Deprecated: Use GeocodeRequest.ProtoReflect.Descriptor instead.
+func (*GeocodeRequest) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ResolvedBatch) GetBatch() []*ResolvedAddress {
+func (x *GeocodeRequest) GetQuery() *Query {
 	if x != nil {
-		return x.Batch
+		return x.Query
 	}
 	return nil
 }
 
-// IOResponse is a status indicator for management service jobs - not used often
-type IOResponse struct {
+func (x *GeocodeRequest) GetMethod() Method {
+	if x != nil {
+		return x.Method
+	}
+	return Method_FWD_FUZZY
+}
+
+func (x *GeocodeRequest) GetMaxResults() uint32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *GeocodeRequest) GetFilter() *GeocodeFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GeocodeRequest) GetMaxDistanceMeters() float32 {
+	if x != nil {
+		return x.MaxDistanceMeters
+	}
+	return 0
+}
+
+func (x *GeocodeRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// GeocodeResponse represents a response from Geocoder.Geocode
+type GeocodeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success             bool  `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	TotalObjectsWritten int32 `protobuf:"varint,2,opt,name=total_objects_written,json=totalObjectsWritten,proto3" json:"total_objects_written,omitempty"`
+	Query      *Query           `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Result     []*ScoredAddress `protobuf:"bytes,2,rep,name=result,proto3" json:"result,omitempty"`
+	NumResults uint32           `protobuf:"varint,3,opt,name=num_results,json=numResults,proto3" json:"num_results,omitempty"`
 }
 
-func (x *IOResponse) Reset() {
-	*x = IOResponse{}
+func (x *GeocodeResponse) Reset() {
+	*x = GeocodeResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_geocoder_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -823,13 +1100,13 @@ func (x *IOResponse) Reset() {
 	}
 }
 
-func (x *IOResponse) String() string {
+func (x *GeocodeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IOResponse) ProtoMessage() {}
+func (*GeocodeResponse) ProtoMessage() {}
 
-func (x *IOResponse) ProtoReflect() protoreflect.Message {
+func (x *GeocodeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geocoder_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -841,215 +1118,2903 @@ func (x *IOResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IOResponse.ProtoReflect.Descriptor instead.
-func (*IOResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GeocodeResponse.ProtoReflect.Descriptor instead.
+func (*GeocodeResponse) Descriptor() ([]byte, []int) {
 	return file_proto_geocoder_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *IOResponse) GetSuccess() bool {
+func (x *GeocodeResponse) GetQuery() *Query {
 	if x != nil {
-		return x.Success
+		return x.Query
 	}
-	return false
+	return nil
 }
 
-func (x *IOResponse) GetTotalObjectsWritten() int32 {
+func (x *GeocodeResponse) GetResult() []*ScoredAddress {
 	if x != nil {
-		return x.TotalObjectsWritten
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GeocodeResponse) GetNumResults() uint32 {
+	if x != nil {
+		return x.NumResults
 	}
 	return 0
 }
 
-var File_proto_geocoder_proto protoreflect.FileDescriptor
+// BoundariesRequest represents a request to Geocoder.Boundaries, `layers` limits the result to the listed
+// layers (all layers if empty)
+type BoundariesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_proto_geocoder_proto_rawDesc = []byte{
-	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
-	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x41, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
-	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x6c, 0x61,
-	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
-	0x74, 0x75, 0x64, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x38, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x74,
-	0x72, 0x65, 0x65, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72,
-	0x65, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
-	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x69, 0x0a, 0x0d, 0x53, 0x63, 0x6f, 0x72, 0x65,
-	0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x65, 0x6f, 0x63,
-	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6e, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02,
-	0x52, 0x10, 0x6e, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e,
-	0x63, 0x65, 0x22, 0x6b, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0d, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x12, 0x32, 0x0a, 0x0b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
-	0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22,
-	0x82, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x28, 0x0a, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x6f, 0x63,
-	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
-	0x65, 0x72, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
-	0x2f, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65,
-	0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x22, 0x85, 0x01, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68,
-	0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f,
-	0x64, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68,
-	0x6f, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
-	0x12, 0x27, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e,
-	0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x24, 0x0a, 0x12, 0x42, 0x61, 0x74,
-	0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0xbd, 0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
-	0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a,
-	0x0d, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61,
-	0x74, 0x68, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22,
-	0x63, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x65, 0x6f, 0x63,
-	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x22, 0x40, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2f, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e,
-	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
-	0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x22, 0x5a, 0x0a, 0x0a, 0x49, 0x4f, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x32,
-	0x0a, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f,
-	0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74,
-	0x65, 0x6e, 0x2a, 0x28, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x0d, 0x0a, 0x09,
-	0x46, 0x57, 0x44, 0x5f, 0x46, 0x55, 0x5a, 0x5a, 0x59, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52,
-	0x45, 0x56, 0x5f, 0x4e, 0x45, 0x41, 0x52, 0x45, 0x53, 0x54, 0x10, 0x01, 0x2a, 0x6d, 0x0a, 0x12,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x5f,
-	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x43, 0x43, 0x45,
-	0x50, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54,
-	0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45,
-	0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x04, 0x12,
-	0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x32, 0x97, 0x01, 0x0a, 0x08,
-	0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x6f, 0x63,
-	0x6f, 0x64, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x47,
-	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x47, 0x65,
-	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x67, 0x65, 0x6f,
-	0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x28, 0x01, 0x30, 0x01, 0x32, 0xa6, 0x01, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12,
-	0x4c, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1c,
-	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67,
-	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a,
-	0x0e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x57,
-	0x0a, 0x0a, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x49, 0x0a, 0x1a,
-	0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x44, 0x61, 0x74, 0x61, 0x12, 0x11, 0x2e, 0x67, 0x65, 0x6f,
-	0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x14, 0x2e,
-	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x49, 0x4f, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x65, 0x6f, 0x63, 0x6f,
-	0x64, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
-	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Point  *Point   `protobuf:"bytes,1,opt,name=point,proto3" json:"point,omitempty"`
+	Layers []string `protobuf:"bytes,2,rep,name=layers,proto3" json:"layers,omitempty"`
 }
 
-var (
-	file_proto_geocoder_proto_rawDescOnce sync.Once
-	file_proto_geocoder_proto_rawDescData = file_proto_geocoder_proto_rawDesc
-)
+func (x *BoundariesRequest) Reset() {
+	*x = BoundariesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_proto_geocoder_proto_rawDescGZIP() []byte {
-	file_proto_geocoder_proto_rawDescOnce.Do(func() {
-		file_proto_geocoder_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_geocoder_proto_rawDescData)
-	})
-	return file_proto_geocoder_proto_rawDescData
+func (x *BoundariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_proto_geocoder_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_geocoder_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_proto_geocoder_proto_goTypes = []interface{}{
-	(Method)(0),                   // 0: geocoder.Method
-	(BatchGeocodeStatus)(0),       // 1: geocoder.BatchGeocodeStatus
-	(*Point)(nil),                 // 2: geocoder.Point
-	(*Address)(nil),               // 3: geocoder.Address
-	(*ScoredAddress)(nil),         // 4: geocoder.ScoredAddress
-	(*Query)(nil),                 // 5: geocoder.Query
-	(*GeocodeRequest)(nil),        // 6: geocoder.GeocodeRequest
-	(*GeocodeResponse)(nil),       // 7: geocoder.GeocodeResponse
-	(*CreateBatchRequest)(nil),    // 8: geocoder.CreateBatchRequest
-	(*BatchStatusRequest)(nil),    // 9: geocoder.BatchStatusRequest
-	(*BatchStatusResponse)(nil),   // 10: geocoder.BatchStatusResponse
-	(*ResolvedAddress)(nil),       // 11: geocoder.ResolvedAddress
-	(*ResolvedBatch)(nil),         // 12: geocoder.ResolvedBatch
-	(*IOResponse)(nil),            // 13: geocoder.IOResponse
-	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+func (*BoundariesRequest) ProtoMessage() {}
+
+func (x *BoundariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_proto_geocoder_proto_depIdxs = []int32{
-	2,  // 0: geocoder.Address.location:type_name -> geocoder.Point
-	3,  // 1: geocoder.ScoredAddress.address:type_name -> geocoder.Address
-	2,  // 2: geocoder.Query.point_query:type_name -> geocoder.Point
-	5,  // 3: geocoder.GeocodeRequest.query:type_name -> geocoder.Query
-	0,  // 4: geocoder.GeocodeRequest.method:type_name -> geocoder.Method
-	5,  // 5: geocoder.GeocodeResponse.query:type_name -> geocoder.Query
-	4,  // 6: geocoder.GeocodeResponse.result:type_name -> geocoder.ScoredAddress
-	0,  // 7: geocoder.CreateBatchRequest.method:type_name -> geocoder.Method
-	2,  // 8: geocoder.CreateBatchRequest.points:type_name -> geocoder.Point
-	1,  // 9: geocoder.BatchStatusResponse.status:type_name -> geocoder.BatchGeocodeStatus
-	14, // 10: geocoder.BatchStatusResponse.update_time:type_name -> google.protobuf.Timestamp
-	5,  // 11: geocoder.ResolvedAddress.query:type_name -> geocoder.Query
-	3,  // 12: geocoder.ResolvedAddress.result:type_name -> geocoder.Address
-	11, // 13: geocoder.ResolvedBatch.batch:type_name -> geocoder.ResolvedAddress
-	6,  // 14: geocoder.Geocoder.Geocode:input_type -> geocoder.GeocodeRequest
-	6,  // 15: geocoder.Geocoder.GeocodeBatch:input_type -> geocoder.GeocodeRequest
-	8,  // 16: geocoder.Batch.CreateBatch:input_type -> geocoder.CreateBatchRequest
-	9,  // 17: geocoder.Batch.GetBatchStatus:input_type -> geocoder.BatchStatusRequest
-	3,  // 18: geocoder.Management.InsertorReplaceAddressData:input_type -> geocoder.Address
-	7,  // 19: geocoder.Geocoder.Geocode:output_type -> geocoder.GeocodeResponse
-	7,  // 20: geocoder.Geocoder.GeocodeBatch:output_type -> geocoder.GeocodeResponse
-	10, // 21: geocoder.Batch.CreateBatch:output_type -> geocoder.BatchStatusResponse
-	10, // 22: geocoder.Batch.GetBatchStatus:output_type -> geocoder.BatchStatusResponse
-	13, // 23: geocoder.Management.InsertorReplaceAddressData:output_type -> geocoder.IOResponse
-	19, // [19:24] is the sub-list for method output_type
-	14, // [14:19] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+
+// Deprecated: Use BoundariesRequest.ProtoReflect.Descriptor instead.
+func (*BoundariesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BoundariesRequest) GetPoint() *Point {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+func (x *BoundariesRequest) GetLayers() []string {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+// BoundariesResponse represents a response from Geocoder.Boundaries, all boundaries containing `point`
+type BoundariesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Boundaries []*Boundary `protobuf:"bytes,1,rep,name=boundaries,proto3" json:"boundaries,omitempty"`
+	Point      *Point      `protobuf:"bytes,2,opt,name=point,proto3" json:"point,omitempty"`
+}
+
+func (x *BoundariesResponse) Reset() {
+	*x = BoundariesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoundariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundariesResponse) ProtoMessage() {}
+
+func (x *BoundariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundariesResponse.ProtoReflect.Descriptor instead.
+func (*BoundariesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BoundariesResponse) GetBoundaries() []*Boundary {
+	if x != nil {
+		return x.Boundaries
+	}
+	return nil
+}
+
+func (x *BoundariesResponse) GetPoint() *Point {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+// SuggestRequest represents a request to Geocoder.Suggest, `prefix` is the partial address typed so far
+type SuggestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix     string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	MaxResults uint32 `protobuf:"varint,2,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	Fuzzy      bool   `protobuf:"varint,3,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"` // match prefixes w. a levenshtein distance of 1
+	Dataset    string `protobuf:"bytes,4,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRequest) ProtoMessage() {}
+
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SuggestRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetMaxResults() uint32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *SuggestRequest) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *SuggestRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// Suggestion represents a single address completion
+type Suggestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text  string  `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"` // normalized `composite_street_address`
+	Score float32 `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	Id    string  `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"` // address key, e.g. `address:${ADDRESSID}`
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Suggestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// SuggestResponse represents a response from Geocoder.Suggest, suggestions are sorted best -> worst
+type SuggestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Suggestions []*Suggestion `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	Prefix      string        `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (x *SuggestResponse) Reset() {
+	*x = SuggestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestResponse) ProtoMessage() {}
+
+func (x *SuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResponse.ProtoReflect.Descriptor instead.
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SuggestResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *SuggestResponse) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+// CreateBatchRequest - represents a request to Batch.CreateBatch
+type CreateBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method    Method   `protobuf:"varint,1,opt,name=method,proto3,enum=geocoder.Method" json:"method,omitempty"`
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Points    []*Point `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	Dataset   string   `protobuf:"bytes,4,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Owner     string   `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"` // set by the edge, a fingerprint of the client's API key
+}
+
+func (x *CreateBatchRequest) Reset() {
+	*x = CreateBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBatchRequest) ProtoMessage() {}
+
+func (x *CreateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateBatchRequest) GetMethod() Method {
+	if x != nil {
+		return x.Method
+	}
+	return Method_FWD_FUZZY
+}
+
+func (x *CreateBatchRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *CreateBatchRequest) GetPoints() []*Point {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *CreateBatchRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *CreateBatchRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+// StatusBatchRequest -
+type BatchStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // uuid
+}
+
+func (x *BatchStatusRequest) Reset() {
+	*x = BatchStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchStatusRequest) ProtoMessage() {}
+
+func (x *BatchStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*BatchStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// RetryBatchRequest - requeues a FAILED batch (e.g. one in the dead-letter stream) w. a fresh set of attempts
+type RetryBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // uuid
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"` // see `CreateBatchRequest.owner`, only the batch's owner && operators can retry it
+}
+
+func (x *RetryBatchRequest) Reset() {
+	*x = RetryBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetryBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryBatchRequest) ProtoMessage() {}
+
+func (x *RetryBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryBatchRequest.ProtoReflect.Descriptor instead.
+func (*RetryBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RetryBatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RetryBatchRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+// CancelBatchRequest - stops a batch that hasn't finished, a batch being geocoded is stopped before its results are saved
+type CancelBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // uuid
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"` // see `CreateBatchRequest.owner`, only the batch's owner && operators can cancel it
+}
+
+func (x *CancelBatchRequest) Reset() {
+	*x = CancelBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBatchRequest) ProtoMessage() {}
+
+func (x *CancelBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBatchRequest.ProtoReflect.Descriptor instead.
+func (*CancelBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CancelBatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelBatchRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+// BatchStatusResponse -
+type BatchStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // uuid
+	Status       BatchGeocodeStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=geocoder.BatchGeocodeStatus" json:"status,omitempty"`
+	DownloadPath string                 `protobuf:"bytes,3,opt,name=download_path,json=downloadPath,proto3" json:"download_path,omitempty"`
+	UpdateTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	Attempts     int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`                   // attempts made by the workers, including the one in progress
+	LastError    string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // reason the last attempt failed, if any
+	Total        int32                  `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`                         // addresses and points in the batch, set once a worker starts geocoding it
+	Sent         int32                  `protobuf:"varint,8,opt,name=sent,proto3" json:"sent,omitempty"`                           // sent to the geocoder by the attempt in progress
+	Resolved     int32                  `protobuf:"varint,9,opt,name=resolved,proto3" json:"resolved,omitempty"`                   // geocoded w. a result
+	Unmatched    int32                  `protobuf:"varint,10,opt,name=unmatched,proto3" json:"unmatched,omitempty"`                // geocoded w.o. a result
+	Eta          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=eta,proto3" json:"eta,omitempty"`                             // estimated time geocoding finishes, only set while a batch is being geocoded
+	Owner        string                 `protobuf:"bytes,12,opt,name=owner,proto3" json:"owner,omitempty"`
+	CreateTime   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+}
+
+func (x *BatchStatusResponse) Reset() {
+	*x = BatchStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchStatusResponse) ProtoMessage() {}
+
+func (x *BatchStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchStatusResponse.ProtoReflect.Descriptor instead.
+func (*BatchStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BatchStatusResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchStatusResponse) GetStatus() BatchGeocodeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchGeocodeStatus_UNDEFINED_STATUS
+}
+
+func (x *BatchStatusResponse) GetDownloadPath() string {
+	if x != nil {
+		return x.DownloadPath
+	}
+	return ""
+}
+
+func (x *BatchStatusResponse) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *BatchStatusResponse) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *BatchStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *BatchStatusResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BatchStatusResponse) GetSent() int32 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *BatchStatusResponse) GetResolved() int32 {
+	if x != nil {
+		return x.Resolved
+	}
+	return 0
+}
+
+func (x *BatchStatusResponse) GetUnmatched() int32 {
+	if x != nil {
+		return x.Unmatched
+	}
+	return 0
+}
+
+func (x *BatchStatusResponse) GetEta() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Eta
+	}
+	return nil
+}
+
+func (x *BatchStatusResponse) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *BatchStatusResponse) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// ListBatchesRequest - lists batches newest first, batches must match every filter that's set
+type ListBatchesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Statuses      []BatchGeocodeStatus   `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=geocoder.BatchGeocodeStatus" json:"statuses,omitempty"` // batches w. any of `statuses`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`  // max. batches per page, default 50 and at most 500
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"` // `next_cursor` of the previous page
+}
+
+func (x *ListBatchesRequest) Reset() {
+	*x = ListBatchesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBatchesRequest) ProtoMessage() {}
+
+func (x *ListBatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListBatchesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListBatchesRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ListBatchesRequest) GetStatuses() []BatchGeocodeStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListBatchesRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListBatchesRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListBatchesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListBatchesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// ListBatchesResponse - a page of batches, a page may have fewer than `limit` batches (e.g. few batches match
+// `statuses`), keep reading while `next_cursor` is set
+type ListBatchesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Batches    []*BatchStatusResponse `protobuf:"bytes,1,rep,name=batches,proto3" json:"batches,omitempty"`
+	NextCursor string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (x *ListBatchesResponse) Reset() {
+	*x = ListBatchesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBatchesResponse) ProtoMessage() {}
+
+func (x *ListBatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListBatchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListBatchesResponse) GetBatches() []*BatchStatusResponse {
+	if x != nil {
+		return x.Batches
+	}
+	return nil
+}
+
+func (x *ListBatchesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// ResolvedAddress -
+type ResolvedAddress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query  *Query   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Result *Address `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *ResolvedAddress) Reset() {
+	*x = ResolvedAddress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolvedAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedAddress) ProtoMessage() {}
+
+func (x *ResolvedAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedAddress.ProtoReflect.Descriptor instead.
+func (*ResolvedAddress) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ResolvedAddress) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *ResolvedAddress) GetResult() *Address {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// ResolvedBatch -
+type ResolvedBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Batch []*ResolvedAddress `protobuf:"bytes,1,rep,name=batch,proto3" json:"batch,omitempty"`
+}
+
+func (x *ResolvedBatch) Reset() {
+	*x = ResolvedBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolvedBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedBatch) ProtoMessage() {}
+
+func (x *ResolvedBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedBatch.ProtoReflect.Descriptor instead.
+func (*ResolvedBatch) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResolvedBatch) GetBatch() []*ResolvedAddress {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+// IOResponse is a status indicator for management service jobs - not used often
+type IOResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success              bool              `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TotalObjectsWritten  int32             `protobuf:"varint,2,opt,name=total_objects_written,json=totalObjectsWritten,proto3" json:"total_objects_written,omitempty"`
+	TotalObjectsUpserted int32             `protobuf:"varint,3,opt,name=total_objects_upserted,json=totalObjectsUpserted,proto3" json:"total_objects_upserted,omitempty"`
+	TotalObjectsDeleted  int32             `protobuf:"varint,4,opt,name=total_objects_deleted,json=totalObjectsDeleted,proto3" json:"total_objects_deleted,omitempty"`
+	TotalObjectsNotFound int32             `protobuf:"varint,5,opt,name=total_objects_not_found,json=totalObjectsNotFound,proto3" json:"total_objects_not_found,omitempty"` // deletions of ids that didn't exist
+	TotalObjectsRejected int32             `protobuf:"varint,6,opt,name=total_objects_rejected,json=totalObjectsRejected,proto3" json:"total_objects_rejected,omitempty"`   // objects that failed validation && weren't written
+	RejectedSamples      []*RejectedObject `protobuf:"bytes,7,rep,name=rejected_samples,json=rejectedSamples,proto3" json:"rejected_samples,omitempty"`                     // the first rejected objects of the stream
+	DryRun               bool              `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                               // nothing was written, counts are of the objects that would have been
+}
+
+func (x *IOResponse) Reset() {
+	*x = IOResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IOResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IOResponse) ProtoMessage() {}
+
+func (x *IOResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IOResponse.ProtoReflect.Descriptor instead.
+func (*IOResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *IOResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *IOResponse) GetTotalObjectsWritten() int32 {
+	if x != nil {
+		return x.TotalObjectsWritten
+	}
+	return 0
+}
+
+func (x *IOResponse) GetTotalObjectsUpserted() int32 {
+	if x != nil {
+		return x.TotalObjectsUpserted
+	}
+	return 0
+}
+
+func (x *IOResponse) GetTotalObjectsDeleted() int32 {
+	if x != nil {
+		return x.TotalObjectsDeleted
+	}
+	return 0
+}
+
+func (x *IOResponse) GetTotalObjectsNotFound() int32 {
+	if x != nil {
+		return x.TotalObjectsNotFound
+	}
+	return 0
+}
+
+func (x *IOResponse) GetTotalObjectsRejected() int32 {
+	if x != nil {
+		return x.TotalObjectsRejected
+	}
+	return 0
+}
+
+func (x *IOResponse) GetRejectedSamples() []*RejectedObject {
+	if x != nil {
+		return x.RejectedSamples
+	}
+	return nil
+}
+
+func (x *IOResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// RejectedObject identifies an object rejected at ingest && the reason it was rejected
+type RejectedObject struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RejectedObject) Reset() {
+	*x = RejectedObject{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedObject) ProtoMessage() {}
+
+func (x *RejectedObject) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedObject.ProtoReflect.Descriptor instead.
+func (*RejectedObject) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RejectedObject) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RejectedObject) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CreateIngestJobRequest represents a request to Management.CreateIngestJob
+type CreateIngestJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset string `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // see `Address.version`
+	Source  string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`    // informational, e.g. the name of the file being loaded
+}
+
+func (x *CreateIngestJobRequest) Reset() {
+	*x = CreateIngestJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateIngestJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIngestJobRequest) ProtoMessage() {}
+
+func (x *CreateIngestJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIngestJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateIngestJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateIngestJobRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *CreateIngestJobRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CreateIngestJobRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// GetIngestJobRequest represents a request to Management.GetIngestJob
+type GetIngestJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *GetIngestJobRequest) Reset() {
+	*x = GetIngestJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetIngestJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIngestJobRequest) ProtoMessage() {}
+
+func (x *GetIngestJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIngestJobRequest.ProtoReflect.Descriptor instead.
+func (*GetIngestJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetIngestJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// IngestJob represents the progress of a resumable load of addresses, a job's addresses are sent in chunks w.
+// Management.IngestAddresses && a restarted client resumes from `committed_offset`
+type IngestJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId                string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Dataset              string                 `protobuf:"bytes,2,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Version              int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Source               string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	CommittedOffset      int64                  `protobuf:"varint,5,opt,name=committed_offset,json=committedOffset,proto3" json:"committed_offset,omitempty"` // number of addresses (from the start of the source) written || rejected
+	TotalObjectsRejected int64                  `protobuf:"varint,6,opt,name=total_objects_rejected,json=totalObjectsRejected,proto3" json:"total_objects_rejected,omitempty"`
+	CreateTime           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+}
+
+func (x *IngestJob) Reset() {
+	*x = IngestJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestJob) ProtoMessage() {}
+
+func (x *IngestJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestJob.ProtoReflect.Descriptor instead.
+func (*IngestJob) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IngestJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *IngestJob) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *IngestJob) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *IngestJob) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *IngestJob) GetCommittedOffset() int64 {
+	if x != nil {
+		return x.CommittedOffset
+	}
+	return 0
+}
+
+func (x *IngestJob) GetTotalObjectsRejected() int64 {
+	if x != nil {
+		return x.TotalObjectsRejected
+	}
+	return 0
+}
+
+func (x *IngestJob) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *IngestJob) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+// IngestChunk represents a sequential chunk of a job's addresses, `offset` is the position of the first address
+// in the source; the dataset && version of the job apply to all addresses
+type IngestChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId     string     `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Offset    int64      `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Addresses []*Address `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *IngestChunk) Reset() {
+	*x = IngestChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestChunk) ProtoMessage() {}
+
+func (x *IngestChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestChunk.ProtoReflect.Descriptor instead.
+func (*IngestChunk) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *IngestChunk) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *IngestChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *IngestChunk) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+// IngestAck acknowledges a chunk once all of its addresses are committed
+type IngestAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId                string            `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	CommittedOffset      int64             `protobuf:"varint,2,opt,name=committed_offset,json=committedOffset,proto3" json:"committed_offset,omitempty"`
+	TotalObjectsWritten  int32             `protobuf:"varint,3,opt,name=total_objects_written,json=totalObjectsWritten,proto3" json:"total_objects_written,omitempty"`    // in this chunk
+	TotalObjectsRejected int32             `protobuf:"varint,4,opt,name=total_objects_rejected,json=totalObjectsRejected,proto3" json:"total_objects_rejected,omitempty"` // in this chunk
+	RejectedSamples      []*RejectedObject `protobuf:"bytes,5,rep,name=rejected_samples,json=rejectedSamples,proto3" json:"rejected_samples,omitempty"`                   // in this chunk
+}
+
+func (x *IngestAck) Reset() {
+	*x = IngestAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestAck) ProtoMessage() {}
+
+func (x *IngestAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestAck.ProtoReflect.Descriptor instead.
+func (*IngestAck) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *IngestAck) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *IngestAck) GetCommittedOffset() int64 {
+	if x != nil {
+		return x.CommittedOffset
+	}
+	return 0
+}
+
+func (x *IngestAck) GetTotalObjectsWritten() int32 {
+	if x != nil {
+		return x.TotalObjectsWritten
+	}
+	return 0
+}
+
+func (x *IngestAck) GetTotalObjectsRejected() int32 {
+	if x != nil {
+		return x.TotalObjectsRejected
+	}
+	return 0
+}
+
+func (x *IngestAck) GetRejectedSamples() []*RejectedObject {
+	if x != nil {
+		return x.RejectedSamples
+	}
+	return nil
+}
+
+// DatasetStatsRequest represents a request to Management.GetDatasetStats
+type DatasetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset string `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *DatasetStatsRequest) Reset() {
+	*x = DatasetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetStatsRequest) ProtoMessage() {}
+
+func (x *DatasetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetStatsRequest.ProtoReflect.Descriptor instead.
+func (*DatasetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DatasetStatsRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// DatasetStats represents a response from Management.GetDatasetStats, all stats describe the version being served
+type DatasetStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset                  string                 `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Version                  int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	NumAddresses             int32                  `protobuf:"varint,3,opt,name=num_addresses,json=numAddresses,proto3" json:"num_addresses,omitempty"`
+	IndexSizeMb              float64                `protobuf:"fixed64,4,opt,name=index_size_mb,json=indexSizeMb,proto3" json:"index_size_mb,omitempty"`        // memory used by the address index, excl. the addresses themselves
+	LastIngestTime           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_ingest_time,json=lastIngestTime,proto3" json:"last_ingest_time,omitempty"` // last write to the version, unset if unknown
+	BoundingBox              *BoundingBox           `protobuf:"bytes,6,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`            // of all addresses, unset if there are none
+	NumAddressesByLocality   map[string]int32       `protobuf:"bytes,7,rep,name=num_addresses_by_locality,json=numAddressesByLocality,proto3" json:"num_addresses_by_locality,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	NumAddressesByPostalCode map[string]int32       `protobuf:"bytes,8,rep,name=num_addresses_by_postal_code,json=numAddressesByPostalCode,proto3" json:"num_addresses_by_postal_code,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *DatasetStats) Reset() {
+	*x = DatasetStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetStats) ProtoMessage() {}
+
+func (x *DatasetStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetStats.ProtoReflect.Descriptor instead.
+func (*DatasetStats) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DatasetStats) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *DatasetStats) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DatasetStats) GetNumAddresses() int32 {
+	if x != nil {
+		return x.NumAddresses
+	}
+	return 0
+}
+
+func (x *DatasetStats) GetIndexSizeMb() float64 {
+	if x != nil {
+		return x.IndexSizeMb
+	}
+	return 0
+}
+
+func (x *DatasetStats) GetLastIngestTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastIngestTime
+	}
+	return nil
+}
+
+func (x *DatasetStats) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+func (x *DatasetStats) GetNumAddressesByLocality() map[string]int32 {
+	if x != nil {
+		return x.NumAddressesByLocality
+	}
+	return nil
+}
+
+func (x *DatasetStats) GetNumAddressesByPostalCode() map[string]int32 {
+	if x != nil {
+		return x.NumAddressesByPostalCode
+	}
+	return nil
+}
+
+// GetAddressRequest represents a request to Management.GetAddress, `id` is either the `Address.id` sent at ingest
+// or the `id` returned in results (e.g. `address:3066687`)
+type GetAddressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dataset string `protobuf:"bytes,2,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *GetAddressRequest) Reset() {
+	*x = GetAddressRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressRequest) ProtoMessage() {}
+
+func (x *GetAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetAddressRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetAddressRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// ExportAddressesRequest represents a request to Management.ExportAddresses, addresses must match all set filters;
+// exported addresses keep the `id` sent at ingest so an export can be re-ingested as-is
+type ExportAddressesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset     string       `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	BoundingBox *BoundingBox `protobuf:"bytes,2,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"` // only addresses within the box
+	IdPrefix    string       `protobuf:"bytes,3,opt,name=id_prefix,json=idPrefix,proto3" json:"id_prefix,omitempty"`          // only addresses w. an `id` starting w. `id_prefix`
+}
+
+func (x *ExportAddressesRequest) Reset() {
+	*x = ExportAddressesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAddressesRequest) ProtoMessage() {}
+
+func (x *ExportAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ExportAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ExportAddressesRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *ExportAddressesRequest) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+func (x *ExportAddressesRequest) GetIdPrefix() string {
+	if x != nil {
+		return x.IdPrefix
+	}
+	return ""
+}
+
+// AddressDeletion identifies an address to remove by `Address.id`
+type AddressDeletion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dataset string `protobuf:"bytes,2,opt,name=dataset,proto3" json:"dataset,omitempty"` // see `GeocodeRequest.dataset`
+}
+
+func (x *AddressDeletion) Reset() {
+	*x = AddressDeletion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressDeletion) ProtoMessage() {}
+
+func (x *AddressDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressDeletion.ProtoReflect.Descriptor instead.
+func (*AddressDeletion) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AddressDeletion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddressDeletion) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// AddressChange is a single operation in a stream of changes to a dataset, changes are applied in order to the
+// version being served
+type AddressChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Change:
+	//	*AddressChange_Upsert
+	//	*AddressChange_Delete
+	Change isAddressChange_Change `protobuf_oneof:"change"`
+}
+
+func (x *AddressChange) Reset() {
+	*x = AddressChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressChange) ProtoMessage() {}
+
+func (x *AddressChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressChange.ProtoReflect.Descriptor instead.
+func (*AddressChange) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{38}
+}
+
+func (m *AddressChange) GetChange() isAddressChange_Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+func (x *AddressChange) GetUpsert() *Address {
+	if x, ok := x.GetChange().(*AddressChange_Upsert); ok {
+		return x.Upsert
+	}
+	return nil
+}
+
+func (x *AddressChange) GetDelete() *AddressDeletion {
+	if x, ok := x.GetChange().(*AddressChange_Delete); ok {
+		return x.Delete
+	}
+	return nil
+}
+
+type isAddressChange_Change interface {
+	isAddressChange_Change()
+}
+
+type AddressChange_Upsert struct {
+	Upsert *Address `protobuf:"bytes,1,opt,name=upsert,proto3,oneof"`
+}
+
+type AddressChange_Delete struct {
+	Delete *AddressDeletion `protobuf:"bytes,2,opt,name=delete,proto3,oneof"`
+}
+
+func (*AddressChange_Upsert) isAddressChange_Change() {}
+
+func (*AddressChange_Delete) isAddressChange_Change() {}
+
+// CreateDatasetVersionRequest represents a request to Management.CreateDatasetVersion, a new version is an empty
+// copy of a dataset's addresses that isn't served until it's promoted
+type CreateDatasetVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset string `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *CreateDatasetVersionRequest) Reset() {
+	*x = CreateDatasetVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDatasetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDatasetVersionRequest) ProtoMessage() {}
+
+func (x *CreateDatasetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDatasetVersionRequest.ProtoReflect.Descriptor instead.
+func (*CreateDatasetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateDatasetVersionRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// PromoteDatasetVersionRequest represents a request to Management.PromoteDatasetVersion, the version is only
+// served if its address count passes validation
+type PromoteDatasetVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset       string `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Version       int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	ExpectedCount int32  `protobuf:"varint,3,opt,name=expected_count,json=expectedCount,proto3" json:"expected_count,omitempty"` // if set, the version must have exactly `expected_count` addresses
+	AllowShrink   bool   `protobuf:"varint,4,opt,name=allow_shrink,json=allowShrink,proto3" json:"allow_shrink,omitempty"`       // allow a version w. far fewer addresses than the version being served
+}
+
+func (x *PromoteDatasetVersionRequest) Reset() {
+	*x = PromoteDatasetVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromoteDatasetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteDatasetVersionRequest) ProtoMessage() {}
+
+func (x *PromoteDatasetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteDatasetVersionRequest.ProtoReflect.Descriptor instead.
+func (*PromoteDatasetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PromoteDatasetVersionRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *PromoteDatasetVersionRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PromoteDatasetVersionRequest) GetExpectedCount() int32 {
+	if x != nil {
+		return x.ExpectedCount
+	}
+	return 0
+}
+
+func (x *PromoteDatasetVersionRequest) GetAllowShrink() bool {
+	if x != nil {
+		return x.AllowShrink
+	}
+	return false
+}
+
+// DropDatasetVersionRequest represents a request to Management.DropDatasetVersion, only unserved versions can be
+// dropped
+type DropDatasetVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset string `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Version int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *DropDatasetVersionRequest) Reset() {
+	*x = DropDatasetVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DropDatasetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DropDatasetVersionRequest) ProtoMessage() {}
+
+func (x *DropDatasetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DropDatasetVersionRequest.ProtoReflect.Descriptor instead.
+func (*DropDatasetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DropDatasetVersionRequest) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *DropDatasetVersionRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// DatasetVersionResponse represents a response from Management.CreateDatasetVersion, Management.PromoteDatasetVersion
+// or Management.DropDatasetVersion
+type DatasetVersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset              string `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Version              int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	NumAddresses         int32  `protobuf:"varint,3,opt,name=num_addresses,json=numAddresses,proto3" json:"num_addresses,omitempty"`                           // addresses in `version`
+	PreviousNumAddresses int32  `protobuf:"varint,4,opt,name=previous_num_addresses,json=previousNumAddresses,proto3" json:"previous_num_addresses,omitempty"` // addresses in the version served before `version` was promoted
+	NumVersionsDropped   int32  `protobuf:"varint,5,opt,name=num_versions_dropped,json=numVersionsDropped,proto3" json:"num_versions_dropped,omitempty"`       // unserved versions deleted after `version` was promoted (or `version` itself, when dropped)
+}
+
+func (x *DatasetVersionResponse) Reset() {
+	*x = DatasetVersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_geocoder_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetVersionResponse) ProtoMessage() {}
+
+func (x *DatasetVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geocoder_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetVersionResponse.ProtoReflect.Descriptor instead.
+func (*DatasetVersionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geocoder_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DatasetVersionResponse) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *DatasetVersionResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DatasetVersionResponse) GetNumAddresses() int32 {
+	if x != nil {
+		return x.NumAddresses
+	}
+	return 0
+}
+
+func (x *DatasetVersionResponse) GetPreviousNumAddresses() int32 {
+	if x != nil {
+		return x.PreviousNumAddresses
+	}
+	return 0
+}
+
+func (x *DatasetVersionResponse) GetNumVersionsDropped() int32 {
+	if x != nil {
+		return x.NumVersionsDropped
+	}
+	return 0
+}
+
+var File_proto_geocoder_proto protoreflect.FileDescriptor
+
+var file_proto_geocoder_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x41, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x22, 0xdd, 0x02, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x38, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x74,
+	0x72, 0x65, 0x65, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72,
+	0x65, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f, 0x75, 0x73, 0x65,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x68,
+	0x6f, 0x75, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x72, 0x65, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65,
+	0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x22, 0xcf, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6e, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10,
+	0x6e, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x16, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x22, 0x62, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x68,
+	0x6f, 0x75, 0x73, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x48, 0x6f, 0x75, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6f, 0x5f, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6f, 0x48,
+	0x6f, 0x75, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x95, 0x02, 0x0a, 0x0d, 0x53,
+	0x74, 0x72, 0x65, 0x65, 0x74, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x72, 0x65, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x2b, 0x0a, 0x08, 0x67, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x67, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x12, 0x2a,
+	0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x22, 0xa3, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f, 0x75, 0x73,
+	0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x68, 0x6f, 0x75, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x72, 0x65, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72,
+	0x65, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x22, 0xb5, 0x01, 0x0a, 0x05, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x25, 0x0a, 0x0d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x0b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x0a, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x48, 0x0a,
+	0x10, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x22, 0x53, 0x0a, 0x0b, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12,
+	0x21, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x6d,
+	0x69, 0x6e, 0x12, 0x21, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0xda, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f,
+	0x78, 0x12, 0x27, 0x0a, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61,
+	0x64, 0x69, 0x75, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x0c, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x22, 0x5e, 0x0a, 0x08, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x65, 0x6f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x65, 0x6f, 0x6a, 0x73,
+	0x6f, 0x6e, 0x22, 0xfd, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x28, 0x0a, 0x06,
+	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06,
+	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x72, 0x2e, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2f, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22,
+	0x52, 0x0a, 0x11, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x22, 0x6f, 0x0a, 0x12, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x62, 0x6f, 0x75,
+	0x6e, 0x64, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72,
+	0x79, 0x52, 0x0a, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x25, 0x0a,
+	0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x22, 0x79, 0x0a, 0x0e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x75, 0x7a, 0x7a, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x75, 0x7a, 0x7a, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x22,
+	0x46, 0x0a, 0x0a, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x61, 0x0a, 0x0f, 0x53, 0x75, 0x67, 0x67, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x73, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0xb5, 0x01, 0x0a, 0x12, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x28, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x22, 0x24, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x39, 0x0a, 0x11, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x22, 0x3a, 0x0a, 0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22,
+	0xdd, 0x03, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x73, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x75, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2c,
+	0x0a, 0x03, 0x65, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x65, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x96, 0x02, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x08,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1c,
+	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x6f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x37, 0x0a, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
+	0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e,
+	0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x63, 0x0a, 0x0f, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65,
+	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x40,
+	0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x2f, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x22, 0x8f, 0x03, 0x0a, 0x0a, 0x49, 0x4f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x74,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x34, 0x0a,
+	0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f, 0x75,
+	0x70, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x55, 0x70, 0x73, 0x65, 0x72,
+	0x74, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x17, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x34,
+	0x0a, 0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f,
+	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x10, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79,
+	0x5f, 0x72, 0x75, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x22, 0x38, 0x0a, 0x0e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x64, 0x0a, 0x16,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x22, 0x2c, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64,
+	0x22, 0xc9, 0x02, 0x0a, 0x09, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x15,
+	0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x16,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f, 0x72, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x6d, 0x0a, 0x0b,
+	0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x15, 0x0a, 0x06, 0x6a,
+	0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x09, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0xfc, 0x01, 0x0a, 0x09,
+	0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64,
+	0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x74, 0x65, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f, 0x77, 0x72, 0x69,
+	0x74, 0x74, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12,
+	0x34, 0x0a, 0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x10, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0x2f, 0x0a, 0x13, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x22, 0x88, 0x05, 0x0a, 0x0c,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x5f, 0x6d, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x4d, 0x62, 0x12, 0x44, 0x0a, 0x10, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0e, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x38, 0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x6d, 0x0a, 0x19, 0x6e, 0x75, 0x6d,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x2e, 0x4e, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x42, 0x79, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x16, 0x6e, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x42, 0x79,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x74, 0x0a, 0x1c, 0x6e, 0x75, 0x6d, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x70, 0x6f, 0x73,
+	0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34,
+	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x4e, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x18, 0x6e, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x49,
+	0x0a, 0x1b, 0x4e, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x42, 0x79,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4b, 0x0a, 0x1d, 0x4e, 0x75, 0x6d,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x61,
+	0x6c, 0x43, 0x6f, 0x64, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3d, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x22, 0x89, 0x01, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x38, 0x0a, 0x0c, 0x62, 0x6f,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x6f, 0x75, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x42, 0x6f, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x22, 0x3b, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x22, 0x7b,
+	0x0a, 0x0d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x2b, 0x0a, 0x06, 0x75, 0x70, 0x73, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x48, 0x00, 0x52, 0x06, 0x75, 0x70, 0x73, 0x65, 0x72, 0x74, 0x12, 0x33, 0x0a, 0x06,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x37, 0x0a, 0x1b, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x22, 0x9c, 0x01, 0x0a, 0x1c, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x68, 0x72, 0x69, 0x6e, 0x6b,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x68, 0x72,
+	0x69, 0x6e, 0x6b, 0x22, 0x4f, 0x0a, 0x19, 0x44, 0x72, 0x6f, 0x70, 0x44, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0xd9, 0x01, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x4e, 0x75, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x30,
+	0x0a, 0x14, 0x6e, 0x75, 0x6d, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x64,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6e, 0x75,
+	0x6d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x2a, 0x3e, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x57,
+	0x44, 0x5f, 0x46, 0x55, 0x5a, 0x5a, 0x59, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x56,
+	0x5f, 0x4e, 0x45, 0x41, 0x52, 0x45, 0x53, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x57,
+	0x44, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x50, 0x4f, 0x4c, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x2a, 0x2d, 0x0a, 0x0c, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0b, 0x0a, 0x07, 0x52, 0x4f, 0x4f, 0x46, 0x54, 0x4f, 0x50, 0x10, 0x00, 0x12, 0x10, 0x0a,
+	0x0c, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x50, 0x4f, 0x4c, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x2a,
+	0x89, 0x01, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49,
+	0x4e, 0x45, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08,
+	0x41, 0x43, 0x43, 0x45, 0x50, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45,
+	0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x5f, 0x51,
+	0x55, 0x45, 0x55, 0x45, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53,
+	0x53, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12,
+	0x0c, 0x0a, 0x08, 0x52, 0x45, 0x54, 0x52, 0x59, 0x49, 0x4e, 0x47, 0x10, 0x06, 0x12, 0x0c, 0x0a,
+	0x08, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x07, 0x32, 0xa4, 0x02, 0x0a, 0x08,
+	0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x47, 0x65,
+	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x67, 0x65, 0x6f,
+	0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e,
+	0x47, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x49, 0x0a, 0x0a, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42,
+	0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x6f, 0x75, 0x6e,
+	0x64, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x40, 0x0a, 0x07, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x67, 0x65,
+	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x32, 0x8e, 0x03, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x4c, 0x0a, 0x0b,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1c, 0x2e, 0x67, 0x65,
+	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x65, 0x6f,
+	0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0a, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x67, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65,
+	0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65,
+	0x72, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x32, 0xe3, 0x08, 0x0a, 0x0a, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x49, 0x0a, 0x1a, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x11, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x1a, 0x14, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x49,
+	0x4f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x46, 0x0a,
+	0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x12, 0x19, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x14, 0x2e, 0x67, 0x65,
+	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x49, 0x4f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x48, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x14, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x49, 0x4f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12,
+	0x55, 0x0a, 0x20, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x65, 0x74, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x65, 0x74, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x14, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x49, 0x4f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x4b, 0x0a, 0x1b, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74,
+	0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72,
+	0x79, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x1a, 0x14, 0x2e, 0x67, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x49, 0x4f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x28, 0x01, 0x12, 0x61, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x67, 0x65,
+	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x26, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x12, 0x44,
+	0x72, 0x6f, 0x70, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x23, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x44, 0x72, 0x6f,
+	0x70, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65,
+	0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1d, 0x2e,
+	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x11, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x67, 0x65,
+	0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x67, 0x65,
+	0x73, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x72, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x44,
+	0x0a, 0x0c, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x1d,
+	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x67,
+	0x65, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4a,
+	0x6f, 0x62, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0f, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64,
+	0x65, 0x72, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x13,
+	0x2e, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74,
+	0x41, 0x63, 0x6b, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x65, 0x6f,
+	0x63, 0x6f, 0x64, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6f, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_proto_geocoder_proto_rawDescOnce sync.Once
+	file_proto_geocoder_proto_rawDescData = file_proto_geocoder_proto_rawDesc
+)
+
+func file_proto_geocoder_proto_rawDescGZIP() []byte {
+	file_proto_geocoder_proto_rawDescOnce.Do(func() {
+		file_proto_geocoder_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_geocoder_proto_rawDescData)
+	})
+	return file_proto_geocoder_proto_rawDescData
+}
+
+var file_proto_geocoder_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_geocoder_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_proto_geocoder_proto_goTypes = []interface{}{
+	(Method)(0),                          // 0: geocoder.Method
+	(LocationType)(0),                    // 1: geocoder.LocationType
+	(BatchGeocodeStatus)(0),              // 2: geocoder.BatchGeocodeStatus
+	(*Point)(nil),                        // 3: geocoder.Point
+	(*Address)(nil),                      // 4: geocoder.Address
+	(*ScoredAddress)(nil),                // 5: geocoder.ScoredAddress
+	(*AddressRange)(nil),                 // 6: geocoder.AddressRange
+	(*StreetSegment)(nil),                // 7: geocoder.StreetSegment
+	(*StructuredAddress)(nil),            // 8: geocoder.StructuredAddress
+	(*Query)(nil),                        // 9: geocoder.Query
+	(*BoundingBox)(nil),                  // 10: geocoder.BoundingBox
+	(*GeocodeFilter)(nil),                // 11: geocoder.GeocodeFilter
+	(*Boundary)(nil),                     // 12: geocoder.Boundary
+	(*GeocodeRequest)(nil),               // 13: geocoder.GeocodeRequest
+	(*GeocodeResponse)(nil),              // 14: geocoder.GeocodeResponse
+	(*BoundariesRequest)(nil),            // 15: geocoder.BoundariesRequest
+	(*BoundariesResponse)(nil),           // 16: geocoder.BoundariesResponse
+	(*SuggestRequest)(nil),               // 17: geocoder.SuggestRequest
+	(*Suggestion)(nil),                   // 18: geocoder.Suggestion
+	(*SuggestResponse)(nil),              // 19: geocoder.SuggestResponse
+	(*CreateBatchRequest)(nil),           // 20: geocoder.CreateBatchRequest
+	(*BatchStatusRequest)(nil),           // 21: geocoder.BatchStatusRequest
+	(*RetryBatchRequest)(nil),            // 22: geocoder.RetryBatchRequest
+	(*CancelBatchRequest)(nil),           // 23: geocoder.CancelBatchRequest
+	(*BatchStatusResponse)(nil),          // 24: geocoder.BatchStatusResponse
+	(*ListBatchesRequest)(nil),           // 25: geocoder.ListBatchesRequest
+	(*ListBatchesResponse)(nil),          // 26: geocoder.ListBatchesResponse
+	(*ResolvedAddress)(nil),              // 27: geocoder.ResolvedAddress
+	(*ResolvedBatch)(nil),                // 28: geocoder.ResolvedBatch
+	(*IOResponse)(nil),                   // 29: geocoder.IOResponse
+	(*RejectedObject)(nil),               // 30: geocoder.RejectedObject
+	(*CreateIngestJobRequest)(nil),       // 31: geocoder.CreateIngestJobRequest
+	(*GetIngestJobRequest)(nil),          // 32: geocoder.GetIngestJobRequest
+	(*IngestJob)(nil),                    // 33: geocoder.IngestJob
+	(*IngestChunk)(nil),                  // 34: geocoder.IngestChunk
+	(*IngestAck)(nil),                    // 35: geocoder.IngestAck
+	(*DatasetStatsRequest)(nil),          // 36: geocoder.DatasetStatsRequest
+	(*DatasetStats)(nil),                 // 37: geocoder.DatasetStats
+	(*GetAddressRequest)(nil),            // 38: geocoder.GetAddressRequest
+	(*ExportAddressesRequest)(nil),       // 39: geocoder.ExportAddressesRequest
+	(*AddressDeletion)(nil),              // 40: geocoder.AddressDeletion
+	(*AddressChange)(nil),                // 41: geocoder.AddressChange
+	(*CreateDatasetVersionRequest)(nil),  // 42: geocoder.CreateDatasetVersionRequest
+	(*PromoteDatasetVersionRequest)(nil), // 43: geocoder.PromoteDatasetVersionRequest
+	(*DropDatasetVersionRequest)(nil),    // 44: geocoder.DropDatasetVersionRequest
+	(*DatasetVersionResponse)(nil),       // 45: geocoder.DatasetVersionResponse
+	nil,                                  // 46: geocoder.DatasetStats.NumAddressesByLocalityEntry
+	nil,                                  // 47: geocoder.DatasetStats.NumAddressesByPostalCodeEntry
+	(*timestamppb.Timestamp)(nil),        // 48: google.protobuf.Timestamp
+}
+var file_proto_geocoder_proto_depIdxs = []int32{
+	3,  // 0: geocoder.Address.location:type_name -> geocoder.Point
+	4,  // 1: geocoder.ScoredAddress.address:type_name -> geocoder.Address
+	1,  // 2: geocoder.ScoredAddress.location_type:type_name -> geocoder.LocationType
+	3,  // 3: geocoder.StreetSegment.geometry:type_name -> geocoder.Point
+	6,  // 4: geocoder.StreetSegment.left:type_name -> geocoder.AddressRange
+	6,  // 5: geocoder.StreetSegment.right:type_name -> geocoder.AddressRange
+	3,  // 6: geocoder.Query.point_query:type_name -> geocoder.Point
+	8,  // 7: geocoder.Query.structured_query:type_name -> geocoder.StructuredAddress
+	3,  // 8: geocoder.BoundingBox.min:type_name -> geocoder.Point
+	3,  // 9: geocoder.BoundingBox.max:type_name -> geocoder.Point
+	10, // 10: geocoder.GeocodeFilter.bounding_box:type_name -> geocoder.BoundingBox
+	3,  // 11: geocoder.GeocodeFilter.center:type_name -> geocoder.Point
+	9,  // 12: geocoder.GeocodeRequest.query:type_name -> geocoder.Query
+	0,  // 13: geocoder.GeocodeRequest.method:type_name -> geocoder.Method
+	11, // 14: geocoder.GeocodeRequest.filter:type_name -> geocoder.GeocodeFilter
+	9,  // 15: geocoder.GeocodeResponse.query:type_name -> geocoder.Query
+	5,  // 16: geocoder.GeocodeResponse.result:type_name -> geocoder.ScoredAddress
+	3,  // 17: geocoder.BoundariesRequest.point:type_name -> geocoder.Point
+	12, // 18: geocoder.BoundariesResponse.boundaries:type_name -> geocoder.Boundary
+	3,  // 19: geocoder.BoundariesResponse.point:type_name -> geocoder.Point
+	18, // 20: geocoder.SuggestResponse.suggestions:type_name -> geocoder.Suggestion
+	0,  // 21: geocoder.CreateBatchRequest.method:type_name -> geocoder.Method
+	3,  // 22: geocoder.CreateBatchRequest.points:type_name -> geocoder.Point
+	2,  // 23: geocoder.BatchStatusResponse.status:type_name -> geocoder.BatchGeocodeStatus
+	48, // 24: geocoder.BatchStatusResponse.update_time:type_name -> google.protobuf.Timestamp
+	48, // 25: geocoder.BatchStatusResponse.eta:type_name -> google.protobuf.Timestamp
+	48, // 26: geocoder.BatchStatusResponse.create_time:type_name -> google.protobuf.Timestamp
+	2,  // 27: geocoder.ListBatchesRequest.statuses:type_name -> geocoder.BatchGeocodeStatus
+	48, // 28: geocoder.ListBatchesRequest.created_after:type_name -> google.protobuf.Timestamp
+	48, // 29: geocoder.ListBatchesRequest.created_before:type_name -> google.protobuf.Timestamp
+	24, // 30: geocoder.ListBatchesResponse.batches:type_name -> geocoder.BatchStatusResponse
+	9,  // 31: geocoder.ResolvedAddress.query:type_name -> geocoder.Query
+	4,  // 32: geocoder.ResolvedAddress.result:type_name -> geocoder.Address
+	27, // 33: geocoder.ResolvedBatch.batch:type_name -> geocoder.ResolvedAddress
+	30, // 34: geocoder.IOResponse.rejected_samples:type_name -> geocoder.RejectedObject
+	48, // 35: geocoder.IngestJob.create_time:type_name -> google.protobuf.Timestamp
+	48, // 36: geocoder.IngestJob.update_time:type_name -> google.protobuf.Timestamp
+	4,  // 37: geocoder.IngestChunk.addresses:type_name -> geocoder.Address
+	30, // 38: geocoder.IngestAck.rejected_samples:type_name -> geocoder.RejectedObject
+	48, // 39: geocoder.DatasetStats.last_ingest_time:type_name -> google.protobuf.Timestamp
+	10, // 40: geocoder.DatasetStats.bounding_box:type_name -> geocoder.BoundingBox
+	46, // 41: geocoder.DatasetStats.num_addresses_by_locality:type_name -> geocoder.DatasetStats.NumAddressesByLocalityEntry
+	47, // 42: geocoder.DatasetStats.num_addresses_by_postal_code:type_name -> geocoder.DatasetStats.NumAddressesByPostalCodeEntry
+	10, // 43: geocoder.ExportAddressesRequest.bounding_box:type_name -> geocoder.BoundingBox
+	4,  // 44: geocoder.AddressChange.upsert:type_name -> geocoder.Address
+	40, // 45: geocoder.AddressChange.delete:type_name -> geocoder.AddressDeletion
+	13, // 46: geocoder.Geocoder.Geocode:input_type -> geocoder.GeocodeRequest
+	13, // 47: geocoder.Geocoder.GeocodeBatch:input_type -> geocoder.GeocodeRequest
+	15, // 48: geocoder.Geocoder.Boundaries:input_type -> geocoder.BoundariesRequest
+	17, // 49: geocoder.Geocoder.Suggest:input_type -> geocoder.SuggestRequest
+	20, // 50: geocoder.Batch.CreateBatch:input_type -> geocoder.CreateBatchRequest
+	21, // 51: geocoder.Batch.GetBatchStatus:input_type -> geocoder.BatchStatusRequest
+	22, // 52: geocoder.Batch.RetryBatch:input_type -> geocoder.RetryBatchRequest
+	23, // 53: geocoder.Batch.CancelBatch:input_type -> geocoder.CancelBatchRequest
+	25, // 54: geocoder.Batch.ListBatches:input_type -> geocoder.ListBatchesRequest
+	4,  // 55: geocoder.Management.InsertorReplaceAddressData:input_type -> geocoder.Address
+	40, // 56: geocoder.Management.DeleteAddresses:input_type -> geocoder.AddressDeletion
+	41, // 57: geocoder.Management.ApplyAddressChanges:input_type -> geocoder.AddressChange
+	7,  // 58: geocoder.Management.InsertorReplaceStreetSegmentData:input_type -> geocoder.StreetSegment
+	12, // 59: geocoder.Management.InsertorReplaceBoundaryData:input_type -> geocoder.Boundary
+	42, // 60: geocoder.Management.CreateDatasetVersion:input_type -> geocoder.CreateDatasetVersionRequest
+	43, // 61: geocoder.Management.PromoteDatasetVersion:input_type -> geocoder.PromoteDatasetVersionRequest
+	44, // 62: geocoder.Management.DropDatasetVersion:input_type -> geocoder.DropDatasetVersionRequest
+	36, // 63: geocoder.Management.GetDatasetStats:input_type -> geocoder.DatasetStatsRequest
+	38, // 64: geocoder.Management.GetAddress:input_type -> geocoder.GetAddressRequest
+	39, // 65: geocoder.Management.ExportAddresses:input_type -> geocoder.ExportAddressesRequest
+	31, // 66: geocoder.Management.CreateIngestJob:input_type -> geocoder.CreateIngestJobRequest
+	32, // 67: geocoder.Management.GetIngestJob:input_type -> geocoder.GetIngestJobRequest
+	34, // 68: geocoder.Management.IngestAddresses:input_type -> geocoder.IngestChunk
+	14, // 69: geocoder.Geocoder.Geocode:output_type -> geocoder.GeocodeResponse
+	14, // 70: geocoder.Geocoder.GeocodeBatch:output_type -> geocoder.GeocodeResponse
+	16, // 71: geocoder.Geocoder.Boundaries:output_type -> geocoder.BoundariesResponse
+	19, // 72: geocoder.Geocoder.Suggest:output_type -> geocoder.SuggestResponse
+	24, // 73: geocoder.Batch.CreateBatch:output_type -> geocoder.BatchStatusResponse
+	24, // 74: geocoder.Batch.GetBatchStatus:output_type -> geocoder.BatchStatusResponse
+	24, // 75: geocoder.Batch.RetryBatch:output_type -> geocoder.BatchStatusResponse
+	24, // 76: geocoder.Batch.CancelBatch:output_type -> geocoder.BatchStatusResponse
+	26, // 77: geocoder.Batch.ListBatches:output_type -> geocoder.ListBatchesResponse
+	29, // 78: geocoder.Management.InsertorReplaceAddressData:output_type -> geocoder.IOResponse
+	29, // 79: geocoder.Management.DeleteAddresses:output_type -> geocoder.IOResponse
+	29, // 80: geocoder.Management.ApplyAddressChanges:output_type -> geocoder.IOResponse
+	29, // 81: geocoder.Management.InsertorReplaceStreetSegmentData:output_type -> geocoder.IOResponse
+	29, // 82: geocoder.Management.InsertorReplaceBoundaryData:output_type -> geocoder.IOResponse
+	45, // 83: geocoder.Management.CreateDatasetVersion:output_type -> geocoder.DatasetVersionResponse
+	45, // 84: geocoder.Management.PromoteDatasetVersion:output_type -> geocoder.DatasetVersionResponse
+	45, // 85: geocoder.Management.DropDatasetVersion:output_type -> geocoder.DatasetVersionResponse
+	37, // 86: geocoder.Management.GetDatasetStats:output_type -> geocoder.DatasetStats
+	4,  // 87: geocoder.Management.GetAddress:output_type -> geocoder.Address
+	4,  // 88: geocoder.Management.ExportAddresses:output_type -> geocoder.Address
+	33, // 89: geocoder.Management.CreateIngestJob:output_type -> geocoder.IngestJob
+	33, // 90: geocoder.Management.GetIngestJob:output_type -> geocoder.IngestJob
+	35, // 91: geocoder.Management.IngestAddresses:output_type -> geocoder.IngestAck
+	69, // [69:92] is the sub-list for method output_type
+	46, // [46:69] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_proto_geocoder_proto_init() }
@@ -1070,8 +4035,104 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Address); i {
+		file_proto_geocoder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScoredAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreetSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StructuredAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Query); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoundingBox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeocodeFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Boundary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1082,8 +4143,8 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScoredAddress); i {
+		file_proto_geocoder_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeocodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1094,8 +4155,8 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Query); i {
+		file_proto_geocoder_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeocodeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1106,8 +4167,8 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GeocodeRequest); i {
+		file_proto_geocoder_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoundariesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1118,8 +4179,8 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GeocodeResponse); i {
+		file_proto_geocoder_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoundariesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1130,7 +4191,43 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_geocoder_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Suggestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateBatchRequest); i {
 			case 0:
 				return &v.state
@@ -1142,7 +4239,7 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_geocoder_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BatchStatusRequest); i {
 			case 0:
 				return &v.state
@@ -1154,7 +4251,31 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_geocoder_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BatchStatusResponse); i {
 			case 0:
 				return &v.state
@@ -1166,7 +4287,31 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_geocoder_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBatchesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBatchesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResolvedAddress); i {
 			case 0:
 				return &v.state
@@ -1178,7 +4323,7 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_geocoder_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResolvedBatch); i {
 			case 0:
 				return &v.state
@@ -1190,7 +4335,7 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
-		file_proto_geocoder_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_geocoder_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IOResponse); i {
 			case 0:
 				return &v.state
@@ -1202,18 +4347,215 @@ func file_proto_geocoder_proto_init() {
 				return nil
 			}
 		}
+		file_proto_geocoder_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectedObject); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateIngestJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetIngestJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportAddressesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressDeletion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDatasetVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoteDatasetVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DropDatasetVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_geocoder_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetVersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_proto_geocoder_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_proto_geocoder_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*Query_AddressQuery)(nil),
 		(*Query_PointQuery)(nil),
+		(*Query_StructuredQuery)(nil),
+	}
+	file_proto_geocoder_proto_msgTypes[38].OneofWrappers = []interface{}{
+		(*AddressChange_Upsert)(nil),
+		(*AddressChange_Delete)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_geocoder_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
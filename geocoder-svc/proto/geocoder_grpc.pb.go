@@ -24,6 +24,8 @@ const _ = grpc.SupportPackageIsVersion7
 type GeocoderClient interface {
 	Geocode(ctx context.Context, in *GeocodeRequest, opts ...grpc.CallOption) (*GeocodeResponse, error)
 	GeocodeBatch(ctx context.Context, opts ...grpc.CallOption) (Geocoder_GeocodeBatchClient, error)
+	Boundaries(ctx context.Context, in *BoundariesRequest, opts ...grpc.CallOption) (*BoundariesResponse, error)
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
 }
 
 type geocoderClient struct {
@@ -74,12 +76,32 @@ func (x *geocoderGeocodeBatchClient) Recv() (*GeocodeResponse, error) {
 	return m, nil
 }
 
+func (c *geocoderClient) Boundaries(ctx context.Context, in *BoundariesRequest, opts ...grpc.CallOption) (*BoundariesResponse, error) {
+	out := new(BoundariesResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Geocoder/Boundaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geocoderClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Geocoder/Suggest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GeocoderServer is the server API for Geocoder service.
 // All implementations must embed UnimplementedGeocoderServer
 // for forward compatibility
 type GeocoderServer interface {
 	Geocode(context.Context, *GeocodeRequest) (*GeocodeResponse, error)
 	GeocodeBatch(Geocoder_GeocodeBatchServer) error
+	Boundaries(context.Context, *BoundariesRequest) (*BoundariesResponse, error)
+	Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
 	mustEmbedUnimplementedGeocoderServer()
 }
 
@@ -93,6 +115,12 @@ func (UnimplementedGeocoderServer) Geocode(context.Context, *GeocodeRequest) (*G
 func (UnimplementedGeocoderServer) GeocodeBatch(Geocoder_GeocodeBatchServer) error {
 	return status.Errorf(codes.Unimplemented, "method GeocodeBatch not implemented")
 }
+func (UnimplementedGeocoderServer) Boundaries(context.Context, *BoundariesRequest) (*BoundariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Boundaries not implemented")
+}
+func (UnimplementedGeocoderServer) Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
 func (UnimplementedGeocoderServer) mustEmbedUnimplementedGeocoderServer() {}
 
 // UnsafeGeocoderServer may be embedded to opt out of forward compatibility for this service.
@@ -150,6 +178,42 @@ func (x *geocoderGeocodeBatchServer) Recv() (*GeocodeRequest, error) {
 	return m, nil
 }
 
+func _Geocoder_Boundaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoundariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeocoderServer).Boundaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Geocoder/Boundaries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeocoderServer).Boundaries(ctx, req.(*BoundariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geocoder_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeocoderServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Geocoder/Suggest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeocoderServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Geocoder_ServiceDesc is the grpc.ServiceDesc for Geocoder service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +225,14 @@ var Geocoder_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Geocode",
 			Handler:    _Geocoder_Geocode_Handler,
 		},
+		{
+			MethodName: "Boundaries",
+			Handler:    _Geocoder_Boundaries_Handler,
+		},
+		{
+			MethodName: "Suggest",
+			Handler:    _Geocoder_Suggest_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -179,6 +251,9 @@ var Geocoder_ServiceDesc = grpc.ServiceDesc{
 type BatchClient interface {
 	CreateBatch(ctx context.Context, in *CreateBatchRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error)
 	GetBatchStatus(ctx context.Context, in *BatchStatusRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error)
+	RetryBatch(ctx context.Context, in *RetryBatchRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error)
+	CancelBatch(ctx context.Context, in *CancelBatchRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error)
+	ListBatches(ctx context.Context, in *ListBatchesRequest, opts ...grpc.CallOption) (*ListBatchesResponse, error)
 }
 
 type batchClient struct {
@@ -207,12 +282,42 @@ func (c *batchClient) GetBatchStatus(ctx context.Context, in *BatchStatusRequest
 	return out, nil
 }
 
+func (c *batchClient) RetryBatch(ctx context.Context, in *RetryBatchRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error) {
+	out := new(BatchStatusResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Batch/RetryBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *batchClient) CancelBatch(ctx context.Context, in *CancelBatchRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error) {
+	out := new(BatchStatusResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Batch/CancelBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *batchClient) ListBatches(ctx context.Context, in *ListBatchesRequest, opts ...grpc.CallOption) (*ListBatchesResponse, error) {
+	out := new(ListBatchesResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Batch/ListBatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BatchServer is the server API for Batch service.
 // All implementations must embed UnimplementedBatchServer
 // for forward compatibility
 type BatchServer interface {
 	CreateBatch(context.Context, *CreateBatchRequest) (*BatchStatusResponse, error)
 	GetBatchStatus(context.Context, *BatchStatusRequest) (*BatchStatusResponse, error)
+	RetryBatch(context.Context, *RetryBatchRequest) (*BatchStatusResponse, error)
+	CancelBatch(context.Context, *CancelBatchRequest) (*BatchStatusResponse, error)
+	ListBatches(context.Context, *ListBatchesRequest) (*ListBatchesResponse, error)
 	mustEmbedUnimplementedBatchServer()
 }
 
@@ -226,6 +331,15 @@ func (UnimplementedBatchServer) CreateBatch(context.Context, *CreateBatchRequest
 func (UnimplementedBatchServer) GetBatchStatus(context.Context, *BatchStatusRequest) (*BatchStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBatchStatus not implemented")
 }
+func (UnimplementedBatchServer) RetryBatch(context.Context, *RetryBatchRequest) (*BatchStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryBatch not implemented")
+}
+func (UnimplementedBatchServer) CancelBatch(context.Context, *CancelBatchRequest) (*BatchStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBatch not implemented")
+}
+func (UnimplementedBatchServer) ListBatches(context.Context, *ListBatchesRequest) (*ListBatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBatches not implemented")
+}
 func (UnimplementedBatchServer) mustEmbedUnimplementedBatchServer() {}
 
 // UnsafeBatchServer may be embedded to opt out of forward compatibility for this service.
@@ -275,6 +389,60 @@ func _Batch_GetBatchStatus_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Batch_RetryBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServer).RetryBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Batch/RetryBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServer).RetryBatch(ctx, req.(*RetryBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Batch_CancelBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServer).CancelBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Batch/CancelBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServer).CancelBatch(ctx, req.(*CancelBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Batch_ListBatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServer).ListBatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Batch/ListBatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServer).ListBatches(ctx, req.(*ListBatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Batch_ServiceDesc is the grpc.ServiceDesc for Batch service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -290,6 +458,18 @@ var Batch_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBatchStatus",
 			Handler:    _Batch_GetBatchStatus_Handler,
 		},
+		{
+			MethodName: "RetryBatch",
+			Handler:    _Batch_RetryBatch_Handler,
+		},
+		{
+			MethodName: "CancelBatch",
+			Handler:    _Batch_CancelBatch_Handler,
+		},
+		{
+			MethodName: "ListBatches",
+			Handler:    _Batch_ListBatches_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/geocoder.proto",
@@ -300,6 +480,19 @@ var Batch_ServiceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ManagementClient interface {
 	InsertorReplaceAddressData(ctx context.Context, opts ...grpc.CallOption) (Management_InsertorReplaceAddressDataClient, error)
+	DeleteAddresses(ctx context.Context, opts ...grpc.CallOption) (Management_DeleteAddressesClient, error)
+	ApplyAddressChanges(ctx context.Context, opts ...grpc.CallOption) (Management_ApplyAddressChangesClient, error)
+	InsertorReplaceStreetSegmentData(ctx context.Context, opts ...grpc.CallOption) (Management_InsertorReplaceStreetSegmentDataClient, error)
+	InsertorReplaceBoundaryData(ctx context.Context, opts ...grpc.CallOption) (Management_InsertorReplaceBoundaryDataClient, error)
+	CreateDatasetVersion(ctx context.Context, in *CreateDatasetVersionRequest, opts ...grpc.CallOption) (*DatasetVersionResponse, error)
+	PromoteDatasetVersion(ctx context.Context, in *PromoteDatasetVersionRequest, opts ...grpc.CallOption) (*DatasetVersionResponse, error)
+	DropDatasetVersion(ctx context.Context, in *DropDatasetVersionRequest, opts ...grpc.CallOption) (*DatasetVersionResponse, error)
+	GetDatasetStats(ctx context.Context, in *DatasetStatsRequest, opts ...grpc.CallOption) (*DatasetStats, error)
+	GetAddress(ctx context.Context, in *GetAddressRequest, opts ...grpc.CallOption) (*Address, error)
+	ExportAddresses(ctx context.Context, in *ExportAddressesRequest, opts ...grpc.CallOption) (Management_ExportAddressesClient, error)
+	CreateIngestJob(ctx context.Context, in *CreateIngestJobRequest, opts ...grpc.CallOption) (*IngestJob, error)
+	GetIngestJob(ctx context.Context, in *GetIngestJobRequest, opts ...grpc.CallOption) (*IngestJob, error)
+	IngestAddresses(ctx context.Context, opts ...grpc.CallOption) (Management_IngestAddressesClient, error)
 }
 
 type managementClient struct {
@@ -344,71 +537,722 @@ func (x *managementInsertorReplaceAddressDataClient) CloseAndRecv() (*IOResponse
 	return m, nil
 }
 
-// ManagementServer is the server API for Management service.
-// All implementations must embed UnimplementedManagementServer
-// for forward compatibility
-type ManagementServer interface {
-	InsertorReplaceAddressData(Management_InsertorReplaceAddressDataServer) error
-	mustEmbedUnimplementedManagementServer()
+func (c *managementClient) DeleteAddresses(ctx context.Context, opts ...grpc.CallOption) (Management_DeleteAddressesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[1], "/geocoder.Management/DeleteAddresses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managementDeleteAddressesClient{stream}
+	return x, nil
 }
 
-// UnimplementedManagementServer must be embedded to have forward compatible implementations.
-type UnimplementedManagementServer struct {
+type Management_DeleteAddressesClient interface {
+	Send(*AddressDeletion) error
+	CloseAndRecv() (*IOResponse, error)
+	grpc.ClientStream
 }
 
-func (UnimplementedManagementServer) InsertorReplaceAddressData(Management_InsertorReplaceAddressDataServer) error {
-	return status.Errorf(codes.Unimplemented, "method InsertorReplaceAddressData not implemented")
+type managementDeleteAddressesClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedManagementServer) mustEmbedUnimplementedManagementServer() {}
 
-// UnsafeManagementServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ManagementServer will
-// result in compilation errors.
-type UnsafeManagementServer interface {
-	mustEmbedUnimplementedManagementServer()
+func (x *managementDeleteAddressesClient) Send(m *AddressDeletion) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func RegisterManagementServer(s grpc.ServiceRegistrar, srv ManagementServer) {
-	s.RegisterService(&Management_ServiceDesc, srv)
+func (x *managementDeleteAddressesClient) CloseAndRecv() (*IOResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IOResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _Management_InsertorReplaceAddressData_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ManagementServer).InsertorReplaceAddressData(&managementInsertorReplaceAddressDataServer{stream})
+func (c *managementClient) ApplyAddressChanges(ctx context.Context, opts ...grpc.CallOption) (Management_ApplyAddressChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[2], "/geocoder.Management/ApplyAddressChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managementApplyAddressChangesClient{stream}
+	return x, nil
 }
 
-type Management_InsertorReplaceAddressDataServer interface {
-	SendAndClose(*IOResponse) error
-	Recv() (*Address, error)
-	grpc.ServerStream
+type Management_ApplyAddressChangesClient interface {
+	Send(*AddressChange) error
+	CloseAndRecv() (*IOResponse, error)
+	grpc.ClientStream
 }
 
-type managementInsertorReplaceAddressDataServer struct {
-	grpc.ServerStream
+type managementApplyAddressChangesClient struct {
+	grpc.ClientStream
 }
 
-func (x *managementInsertorReplaceAddressDataServer) SendAndClose(m *IOResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (x *managementApplyAddressChangesClient) Send(m *AddressChange) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func (x *managementInsertorReplaceAddressDataServer) Recv() (*Address, error) {
-	m := new(Address)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func (x *managementApplyAddressChangesClient) CloseAndRecv() (*IOResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IOResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-// Management_ServiceDesc is the grpc.ServiceDesc for Management service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Management_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "geocoder.Management",
-	HandlerType: (*ManagementServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "InsertorReplaceAddressData",
-			Handler:       _Management_InsertorReplaceAddressData_Handler,
+func (c *managementClient) InsertorReplaceStreetSegmentData(ctx context.Context, opts ...grpc.CallOption) (Management_InsertorReplaceStreetSegmentDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[3], "/geocoder.Management/InsertorReplaceStreetSegmentData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managementInsertorReplaceStreetSegmentDataClient{stream}
+	return x, nil
+}
+
+type Management_InsertorReplaceStreetSegmentDataClient interface {
+	Send(*StreetSegment) error
+	CloseAndRecv() (*IOResponse, error)
+	grpc.ClientStream
+}
+
+type managementInsertorReplaceStreetSegmentDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *managementInsertorReplaceStreetSegmentDataClient) Send(m *StreetSegment) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *managementInsertorReplaceStreetSegmentDataClient) CloseAndRecv() (*IOResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IOResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managementClient) InsertorReplaceBoundaryData(ctx context.Context, opts ...grpc.CallOption) (Management_InsertorReplaceBoundaryDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[4], "/geocoder.Management/InsertorReplaceBoundaryData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managementInsertorReplaceBoundaryDataClient{stream}
+	return x, nil
+}
+
+type Management_InsertorReplaceBoundaryDataClient interface {
+	Send(*Boundary) error
+	CloseAndRecv() (*IOResponse, error)
+	grpc.ClientStream
+}
+
+type managementInsertorReplaceBoundaryDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *managementInsertorReplaceBoundaryDataClient) Send(m *Boundary) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *managementInsertorReplaceBoundaryDataClient) CloseAndRecv() (*IOResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IOResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managementClient) CreateDatasetVersion(ctx context.Context, in *CreateDatasetVersionRequest, opts ...grpc.CallOption) (*DatasetVersionResponse, error) {
+	out := new(DatasetVersionResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Management/CreateDatasetVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) PromoteDatasetVersion(ctx context.Context, in *PromoteDatasetVersionRequest, opts ...grpc.CallOption) (*DatasetVersionResponse, error) {
+	out := new(DatasetVersionResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Management/PromoteDatasetVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) DropDatasetVersion(ctx context.Context, in *DropDatasetVersionRequest, opts ...grpc.CallOption) (*DatasetVersionResponse, error) {
+	out := new(DatasetVersionResponse)
+	err := c.cc.Invoke(ctx, "/geocoder.Management/DropDatasetVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetDatasetStats(ctx context.Context, in *DatasetStatsRequest, opts ...grpc.CallOption) (*DatasetStats, error) {
+	out := new(DatasetStats)
+	err := c.cc.Invoke(ctx, "/geocoder.Management/GetDatasetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetAddress(ctx context.Context, in *GetAddressRequest, opts ...grpc.CallOption) (*Address, error) {
+	out := new(Address)
+	err := c.cc.Invoke(ctx, "/geocoder.Management/GetAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) ExportAddresses(ctx context.Context, in *ExportAddressesRequest, opts ...grpc.CallOption) (Management_ExportAddressesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[5], "/geocoder.Management/ExportAddresses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managementExportAddressesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Management_ExportAddressesClient interface {
+	Recv() (*Address, error)
+	grpc.ClientStream
+}
+
+type managementExportAddressesClient struct {
+	grpc.ClientStream
+}
+
+func (x *managementExportAddressesClient) Recv() (*Address, error) {
+	m := new(Address)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managementClient) CreateIngestJob(ctx context.Context, in *CreateIngestJobRequest, opts ...grpc.CallOption) (*IngestJob, error) {
+	out := new(IngestJob)
+	err := c.cc.Invoke(ctx, "/geocoder.Management/CreateIngestJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetIngestJob(ctx context.Context, in *GetIngestJobRequest, opts ...grpc.CallOption) (*IngestJob, error) {
+	out := new(IngestJob)
+	err := c.cc.Invoke(ctx, "/geocoder.Management/GetIngestJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) IngestAddresses(ctx context.Context, opts ...grpc.CallOption) (Management_IngestAddressesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[6], "/geocoder.Management/IngestAddresses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managementIngestAddressesClient{stream}
+	return x, nil
+}
+
+type Management_IngestAddressesClient interface {
+	Send(*IngestChunk) error
+	Recv() (*IngestAck, error)
+	grpc.ClientStream
+}
+
+type managementIngestAddressesClient struct {
+	grpc.ClientStream
+}
+
+func (x *managementIngestAddressesClient) Send(m *IngestChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *managementIngestAddressesClient) Recv() (*IngestAck, error) {
+	m := new(IngestAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ManagementServer is the server API for Management service.
+// All implementations must embed UnimplementedManagementServer
+// for forward compatibility
+type ManagementServer interface {
+	InsertorReplaceAddressData(Management_InsertorReplaceAddressDataServer) error
+	DeleteAddresses(Management_DeleteAddressesServer) error
+	ApplyAddressChanges(Management_ApplyAddressChangesServer) error
+	InsertorReplaceStreetSegmentData(Management_InsertorReplaceStreetSegmentDataServer) error
+	InsertorReplaceBoundaryData(Management_InsertorReplaceBoundaryDataServer) error
+	CreateDatasetVersion(context.Context, *CreateDatasetVersionRequest) (*DatasetVersionResponse, error)
+	PromoteDatasetVersion(context.Context, *PromoteDatasetVersionRequest) (*DatasetVersionResponse, error)
+	DropDatasetVersion(context.Context, *DropDatasetVersionRequest) (*DatasetVersionResponse, error)
+	GetDatasetStats(context.Context, *DatasetStatsRequest) (*DatasetStats, error)
+	GetAddress(context.Context, *GetAddressRequest) (*Address, error)
+	ExportAddresses(*ExportAddressesRequest, Management_ExportAddressesServer) error
+	CreateIngestJob(context.Context, *CreateIngestJobRequest) (*IngestJob, error)
+	GetIngestJob(context.Context, *GetIngestJobRequest) (*IngestJob, error)
+	IngestAddresses(Management_IngestAddressesServer) error
+	mustEmbedUnimplementedManagementServer()
+}
+
+// UnimplementedManagementServer must be embedded to have forward compatible implementations.
+type UnimplementedManagementServer struct {
+}
+
+func (UnimplementedManagementServer) InsertorReplaceAddressData(Management_InsertorReplaceAddressDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method InsertorReplaceAddressData not implemented")
+}
+func (UnimplementedManagementServer) DeleteAddresses(Management_DeleteAddressesServer) error {
+	return status.Errorf(codes.Unimplemented, "method DeleteAddresses not implemented")
+}
+func (UnimplementedManagementServer) ApplyAddressChanges(Management_ApplyAddressChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ApplyAddressChanges not implemented")
+}
+func (UnimplementedManagementServer) InsertorReplaceStreetSegmentData(Management_InsertorReplaceStreetSegmentDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method InsertorReplaceStreetSegmentData not implemented")
+}
+func (UnimplementedManagementServer) InsertorReplaceBoundaryData(Management_InsertorReplaceBoundaryDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method InsertorReplaceBoundaryData not implemented")
+}
+func (UnimplementedManagementServer) CreateDatasetVersion(context.Context, *CreateDatasetVersionRequest) (*DatasetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDatasetVersion not implemented")
+}
+func (UnimplementedManagementServer) PromoteDatasetVersion(context.Context, *PromoteDatasetVersionRequest) (*DatasetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteDatasetVersion not implemented")
+}
+func (UnimplementedManagementServer) DropDatasetVersion(context.Context, *DropDatasetVersionRequest) (*DatasetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropDatasetVersion not implemented")
+}
+func (UnimplementedManagementServer) GetDatasetStats(context.Context, *DatasetStatsRequest) (*DatasetStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDatasetStats not implemented")
+}
+func (UnimplementedManagementServer) GetAddress(context.Context, *GetAddressRequest) (*Address, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddress not implemented")
+}
+func (UnimplementedManagementServer) ExportAddresses(*ExportAddressesRequest, Management_ExportAddressesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAddresses not implemented")
+}
+func (UnimplementedManagementServer) CreateIngestJob(context.Context, *CreateIngestJobRequest) (*IngestJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIngestJob not implemented")
+}
+func (UnimplementedManagementServer) GetIngestJob(context.Context, *GetIngestJobRequest) (*IngestJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIngestJob not implemented")
+}
+func (UnimplementedManagementServer) IngestAddresses(Management_IngestAddressesServer) error {
+	return status.Errorf(codes.Unimplemented, "method IngestAddresses not implemented")
+}
+func (UnimplementedManagementServer) mustEmbedUnimplementedManagementServer() {}
+
+// UnsafeManagementServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ManagementServer will
+// result in compilation errors.
+type UnsafeManagementServer interface {
+	mustEmbedUnimplementedManagementServer()
+}
+
+func RegisterManagementServer(s grpc.ServiceRegistrar, srv ManagementServer) {
+	s.RegisterService(&Management_ServiceDesc, srv)
+}
+
+func _Management_InsertorReplaceAddressData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagementServer).InsertorReplaceAddressData(&managementInsertorReplaceAddressDataServer{stream})
+}
+
+type Management_InsertorReplaceAddressDataServer interface {
+	SendAndClose(*IOResponse) error
+	Recv() (*Address, error)
+	grpc.ServerStream
+}
+
+type managementInsertorReplaceAddressDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementInsertorReplaceAddressDataServer) SendAndClose(m *IOResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *managementInsertorReplaceAddressDataServer) Recv() (*Address, error) {
+	m := new(Address)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Management_DeleteAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagementServer).DeleteAddresses(&managementDeleteAddressesServer{stream})
+}
+
+type Management_DeleteAddressesServer interface {
+	SendAndClose(*IOResponse) error
+	Recv() (*AddressDeletion, error)
+	grpc.ServerStream
+}
+
+type managementDeleteAddressesServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementDeleteAddressesServer) SendAndClose(m *IOResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *managementDeleteAddressesServer) Recv() (*AddressDeletion, error) {
+	m := new(AddressDeletion)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Management_ApplyAddressChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagementServer).ApplyAddressChanges(&managementApplyAddressChangesServer{stream})
+}
+
+type Management_ApplyAddressChangesServer interface {
+	SendAndClose(*IOResponse) error
+	Recv() (*AddressChange, error)
+	grpc.ServerStream
+}
+
+type managementApplyAddressChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementApplyAddressChangesServer) SendAndClose(m *IOResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *managementApplyAddressChangesServer) Recv() (*AddressChange, error) {
+	m := new(AddressChange)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Management_InsertorReplaceStreetSegmentData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagementServer).InsertorReplaceStreetSegmentData(&managementInsertorReplaceStreetSegmentDataServer{stream})
+}
+
+type Management_InsertorReplaceStreetSegmentDataServer interface {
+	SendAndClose(*IOResponse) error
+	Recv() (*StreetSegment, error)
+	grpc.ServerStream
+}
+
+type managementInsertorReplaceStreetSegmentDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementInsertorReplaceStreetSegmentDataServer) SendAndClose(m *IOResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *managementInsertorReplaceStreetSegmentDataServer) Recv() (*StreetSegment, error) {
+	m := new(StreetSegment)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Management_InsertorReplaceBoundaryData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagementServer).InsertorReplaceBoundaryData(&managementInsertorReplaceBoundaryDataServer{stream})
+}
+
+type Management_InsertorReplaceBoundaryDataServer interface {
+	SendAndClose(*IOResponse) error
+	Recv() (*Boundary, error)
+	grpc.ServerStream
+}
+
+type managementInsertorReplaceBoundaryDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementInsertorReplaceBoundaryDataServer) SendAndClose(m *IOResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *managementInsertorReplaceBoundaryDataServer) Recv() (*Boundary, error) {
+	m := new(Boundary)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Management_CreateDatasetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDatasetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).CreateDatasetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Management/CreateDatasetVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).CreateDatasetVersion(ctx, req.(*CreateDatasetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_PromoteDatasetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteDatasetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).PromoteDatasetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Management/PromoteDatasetVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).PromoteDatasetVersion(ctx, req.(*PromoteDatasetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_DropDatasetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropDatasetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).DropDatasetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Management/DropDatasetVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).DropDatasetVersion(ctx, req.(*DropDatasetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetDatasetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DatasetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetDatasetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Management/GetDatasetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetDatasetStats(ctx, req.(*DatasetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Management/GetAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetAddress(ctx, req.(*GetAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ExportAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAddressesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagementServer).ExportAddresses(m, &managementExportAddressesServer{stream})
+}
+
+type Management_ExportAddressesServer interface {
+	Send(*Address) error
+	grpc.ServerStream
+}
+
+type managementExportAddressesServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementExportAddressesServer) Send(m *Address) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Management_CreateIngestJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIngestJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).CreateIngestJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Management/CreateIngestJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).CreateIngestJob(ctx, req.(*CreateIngestJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetIngestJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIngestJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetIngestJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geocoder.Management/GetIngestJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetIngestJob(ctx, req.(*GetIngestJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_IngestAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagementServer).IngestAddresses(&managementIngestAddressesServer{stream})
+}
+
+type Management_IngestAddressesServer interface {
+	Send(*IngestAck) error
+	Recv() (*IngestChunk, error)
+	grpc.ServerStream
+}
+
+type managementIngestAddressesServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementIngestAddressesServer) Send(m *IngestAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *managementIngestAddressesServer) Recv() (*IngestChunk, error) {
+	m := new(IngestChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Management_ServiceDesc is the grpc.ServiceDesc for Management service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Management_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "geocoder.Management",
+	HandlerType: (*ManagementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDatasetVersion",
+			Handler:    _Management_CreateDatasetVersion_Handler,
+		},
+		{
+			MethodName: "PromoteDatasetVersion",
+			Handler:    _Management_PromoteDatasetVersion_Handler,
+		},
+		{
+			MethodName: "DropDatasetVersion",
+			Handler:    _Management_DropDatasetVersion_Handler,
+		},
+		{
+			MethodName: "GetDatasetStats",
+			Handler:    _Management_GetDatasetStats_Handler,
+		},
+		{
+			MethodName: "GetAddress",
+			Handler:    _Management_GetAddress_Handler,
+		},
+		{
+			MethodName: "CreateIngestJob",
+			Handler:    _Management_CreateIngestJob_Handler,
+		},
+		{
+			MethodName: "GetIngestJob",
+			Handler:    _Management_GetIngestJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "InsertorReplaceAddressData",
+			Handler:       _Management_InsertorReplaceAddressData_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DeleteAddresses",
+			Handler:       _Management_DeleteAddresses_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ApplyAddressChanges",
+			Handler:       _Management_ApplyAddressChanges_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "InsertorReplaceStreetSegmentData",
+			Handler:       _Management_InsertorReplaceStreetSegmentData_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "InsertorReplaceBoundaryData",
+			Handler:       _Management_InsertorReplaceBoundaryData_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportAddresses",
+			Handler:       _Management_ExportAddresses_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IngestAddresses",
+			Handler:       _Management_IngestAddresses_Handler,
+			ServerStreams: true,
 			ClientStreams: true,
 		},
 	},
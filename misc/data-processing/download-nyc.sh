@@ -4,6 +4,7 @@
 # Webpage: https://data.cityofnewyork.us/City-Government/NYC-Address-Points/g6pj-hd8k
 
 # Prepares the dataset to the format accepted by the /geocoder.Management/InsertOrReplaceData call
+# Columns: ADDRESS_ID, the_geom, composite address, house number, street, borough, ZIP
 
 mkdir -p ./_data/
 
@@ -19,4 +20,4 @@ cat ./_data/original_nyc.csv |\
 			$(4) == 4 { $(4) = "QUEENS"; }  
 			$(4) == 5 { $(4) = "STATEN ISLAND"; }  
 		{ print; }'|\
-	awk -F ',' '{ print $2 "," $1 "," $3 " " $6 " " $4 " NEW YORK " $5}' | tr -s ' ' > ./_data/prepared_nyc.csv
+	awk -F ',' '{ print $2 "," $1 "," $3 " " $6 " " $4 " NEW YORK " $5 "," $3 "," $6 "," $4 "," $5}' | tr -s ' ' > ./_data/prepared_nyc.csv